@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnknownColumn is returned when a method is given a column name that is
+// not a column of the model of the DAO.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// ErrFullTable is returned when DeleteWhere or UpdateWhere is called with an
+// empty where clause and a context not created by AllowFullTable.
+var ErrFullTable = errors.New("refusing to write every row without AllowFullTable")
+
+type fullTableKey struct{}
+
+// AllowFullTable returns a copy of ctx letting DeleteWhere and UpdateWhere
+// run with an empty where clause, writing every row of the table.
+func AllowFullTable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fullTableKey{}, true)
+}
+
+func fullTableAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(fullTableKey{}).(bool)
+	return allowed
+}
+
+// LockMode is how FindByPkForUpdate and FindAllForUpdate behave when a row
+// they read is already locked by another transaction. It is an int, as in the
+// package of the DAO interfaces and every driver package, so that the DAOs of
+// any driver implement the interfaces. The lock methods reject values other
+// than the modes below.
+type LockMode = int
+
+const (
+	// LockWait waits for the other transaction to release the row.
+	LockWait LockMode = iota
+	// LockNoWait fails instead of waiting.
+	LockNoWait
+	// LockSkipLocked leaves the locked rows out of the results.
+	LockSkipLocked
+)
+
+// ErrNoTransaction is returned when rows are locked with a context not
+// carrying a transaction started by WithTransaction.
+var ErrNoTransaction = errors.New("locking rows requires a transaction")
+
+// ErrUnknownLockMode is returned when rows are locked with a LockMode other
+// than LockWait, LockNoWait and LockSkipLocked.
+var ErrUnknownLockMode = errors.New("unknown lock mode")
+
+func lockClause(lock LockMode) string {
+	switch lock {
+	case LockNoWait:
+		return " FOR UPDATE NOWAIT"
+	case LockSkipLocked:
+		return " FOR UPDATE SKIP LOCKED"
+	default:
+		return " FOR UPDATE"
+	}
+}
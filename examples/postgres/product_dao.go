@@ -3,9 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/Jibaru/gormless/examples"
 	"strings"
+	"time"
 )
 
 type Product = examples.Product
@@ -48,7 +50,7 @@ func (dao *ProductDAO) queryContext(ctx context.Context, query string, args ...i
 
 func (dao *ProductDAO) Create(ctx context.Context, m *Product) error {
 	query := `
-		INSERT INTO products (id, name, description, category, price, stock, created_at)
+		INSERT INTO "products" ("id", "name", "description", "category", "price", "stock", "created_at")
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
@@ -69,14 +71,14 @@ func (dao *ProductDAO) Create(ctx context.Context, m *Product) error {
 
 func (dao *ProductDAO) Update(ctx context.Context, m *Product) error {
 	query := `
-		UPDATE products
-		SET name = $1,
-			description = $2,
-			category = $3,
-			price = $4,
-			stock = $5,
-			created_at = $6
-		WHERE id = $7
+		UPDATE "products"
+		SET "name" = $1,
+			"description" = $2,
+			"category" = $3,
+			"price" = $4,
+			"stock" = $5,
+			"created_at" = $6
+		WHERE "id" = $7
 	`
 
 	_, err := dao.execContext(ctx, query,
@@ -101,30 +103,35 @@ func (dao *ProductDAO) PartialUpdate(ctx context.Context, pk string, fields map[
 	i := 1
 
 	for field, value := range fields {
-		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field, i))
+		switch field {
+		case "id", "name", "description", "category", "price", "stock", "created_at":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = $%d", field, i))
 		args = append(args, value)
 		i++
 	}
 
 	args = append(args, pk)
 
-	query := fmt.Sprintf(`UPDATE products SET %s WHERE id = $%d`, strings.Join(setClauses, ", "), i)
+	query := fmt.Sprintf(`UPDATE "products" SET %s WHERE "id" = $%d`, strings.Join(setClauses, ", "), i)
 
 	_, err := dao.execContext(ctx, query, args...)
 	return err
 }
 
 func (dao *ProductDAO) DeleteByPk(ctx context.Context, pk string) error {
-	query := `DELETE FROM products WHERE id = $1`
+	query := `DELETE FROM "products" WHERE "id" = $1`
 	_, err := dao.execContext(ctx, query, pk)
 	return err
 }
 
 func (dao *ProductDAO) FindByPk(ctx context.Context, pk string) (*Product, error) {
 	query := `
-		SELECT id, name, description, category, price, stock, created_at
-		FROM products
-		WHERE id = $1
+		SELECT "id", "name", "description", "category", "price", "stock", "created_at"
+		FROM "products"
+		WHERE "id" = $1
 	`
 	row := dao.queryRowContext(ctx, query, pk)
 
@@ -170,7 +177,7 @@ func (dao *ProductDAO) CreateMany(ctx context.Context, models []*Product) error
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO products (id, name, description, category, price, stock, created_at)
+		INSERT INTO "products" ("id", "name", "description", "category", "price", "stock", "created_at")
 		VALUES %s
 	`, strings.Join(placeholders, ", "))
 
@@ -184,14 +191,14 @@ func (dao *ProductDAO) UpdateMany(ctx context.Context, models []*Product) error
 	}
 
 	query := `
-		UPDATE products
-		SET name = $1,
-			description = $2,
-			category = $3,
-			price = $4,
-			stock = $5,
-			created_at = $6
-		WHERE id = $7
+		UPDATE "products"
+		SET "name" = $1,
+			"description" = $2,
+			"category" = $3,
+			"price" = $4,
+			"stock" = $5,
+			"created_at" = $6
+		WHERE "id" = $7
 	`
 
 	for _, model := range models {
@@ -224,15 +231,73 @@ func (dao *ProductDAO) DeleteManyByPks(ctx context.Context, pks []string) error
 		args[i] = pk
 	}
 
-	query := fmt.Sprintf(`DELETE FROM products WHERE id IN (%s)`, strings.Join(placeholders, ","))
+	query := fmt.Sprintf(`DELETE FROM "products" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
 	_, err := dao.execContext(ctx, query, args...)
 	return err
 }
 
+func (dao *ProductDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ProductDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "description", "category", "price", "stock", "created_at":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf("$%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "products" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (dao *ProductDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Product, error) {
 	query := `
-		SELECT id, name, description, category, price, stock, created_at
-		FROM products
+		SELECT "id", "name", "description", "category", "price", "stock", "created_at"
+		FROM "products"
 	`
 
 	if where != "" {
@@ -265,8 +330,8 @@ func (dao *ProductDAO) FindOne(ctx context.Context, where string, sort string, a
 
 func (dao *ProductDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Product, error) {
 	query := `
-		SELECT id, name, description, category, price, stock, created_at
-		FROM products
+		SELECT "id", "name", "description", "category", "price", "stock", "created_at"
+		FROM "products"
 	`
 
 	if where != "" {
@@ -308,10 +373,162 @@ func (dao *ProductDAO) FindAll(ctx context.Context, where string, sort string, a
 	return models, nil
 }
 
+func (dao *ProductDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Product, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "name", "description", "category", "price", "stock", "created_at":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "products"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Product
+	for rows.Next() {
+		var m Product
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "name":
+				dest[i] = &m.Name
+			case "description":
+				dest[i] = &m.Description
+			case "category":
+				dest[i] = &m.Category
+			case "price":
+				dest[i] = &m.Price
+			case "stock":
+				dest[i] = &m.Stock
+			case "created_at":
+				dest[i] = &m.CreatedAt
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ProductDAO) FindByPkForUpdate(ctx context.Context, pk string, lock LockMode) (*Product, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name", "description", "category", "price", "stock", "created_at" FROM "products" WHERE "id" = $1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Product
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		&m.Description,
+		&m.Category,
+		&m.Price,
+		&m.Stock,
+		&m.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ProductDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Product, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name", "description", "category", "price", "stock", "created_at" FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Product
+	for rows.Next() {
+		var m Product
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Description,
+			&m.Category,
+			&m.Price,
+			&m.Stock,
+			&m.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
 func (dao *ProductDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Product, error) {
 	query := `
-		SELECT id, name, description, category, price, stock, created_at
-		FROM products
+		SELECT "id", "name", "description", "category", "price", "stock", "created_at"
+		FROM "products"
 	`
 
 	if where != "" {
@@ -356,7 +573,7 @@ func (dao *ProductDAO) FindPaginated(ctx context.Context, limit, offset int, whe
 }
 
 func (dao *ProductDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
-	query := "SELECT COUNT(*) FROM products"
+	query := `SELECT COUNT(*) FROM "products"`
 
 	if where != "" {
 		query += " WHERE " + where
@@ -373,6 +590,214 @@ func (dao *ProductDAO) Count(ctx context.Context, where string, args ...interfac
 	return count, nil
 }
 
+func (dao *ProductDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ProductDAO) ExistsByPk(ctx context.Context, pk string) (bool, error) {
+	query := `SELECT 1 FROM "products" WHERE "id" = $1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ProductDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "name", "description", "category", "price", "stock", "created_at":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "products"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ProductDAO) SumPrice(ctx context.Context, where string, args ...interface{}) (float64, error) {
+	query := `SELECT SUM("price") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Float64, nil
+}
+
+func (dao *ProductDAO) AvgPrice(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("price") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *ProductDAO) MinPrice(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MIN("price") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ProductDAO) MaxPrice(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MAX("price") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ProductDAO) SumStock(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("stock") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *ProductDAO) AvgStock(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("stock") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *ProductDAO) MinStock(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN("stock") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ProductDAO) MaxStock(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX("stock") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ProductDAO) MinCreatedAt(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MIN("created_at") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ProductDAO) MaxCreatedAt(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MAX("created_at") FROM "products"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
 func (dao *ProductDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	tx, err := dao.db.BeginTx(ctx, nil)
 	if err != nil {
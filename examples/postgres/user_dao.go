@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/Jibaru/gormless/examples"
 	"strings"
@@ -48,7 +49,7 @@ func (dao *UserDAO) queryContext(ctx context.Context, query string, args ...inte
 
 func (dao *UserDAO) Create(ctx context.Context, m *User) error {
 	query := `
-		INSERT INTO User (user_key, username, Age)
+		INSERT INTO "User" ("user_key", "username", "Age")
 		VALUES ($1, $2, $3)
 	`
 
@@ -65,10 +66,10 @@ func (dao *UserDAO) Create(ctx context.Context, m *User) error {
 
 func (dao *UserDAO) Update(ctx context.Context, m *User) error {
 	query := `
-		UPDATE User
-		SET username = $1,
-			Age = $2
-		WHERE user_key = $3
+		UPDATE "User"
+		SET "username" = $1,
+			"Age" = $2
+		WHERE "user_key" = $3
 	`
 
 	_, err := dao.execContext(ctx, query,
@@ -89,30 +90,35 @@ func (dao *UserDAO) PartialUpdate(ctx context.Context, pk int, fields map[string
 	i := 1
 
 	for field, value := range fields {
-		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field, i))
+		switch field {
+		case "user_key", "username", "Age":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = $%d", field, i))
 		args = append(args, value)
 		i++
 	}
 
 	args = append(args, pk)
 
-	query := fmt.Sprintf(`UPDATE User SET %s WHERE user_key = $%d`, strings.Join(setClauses, ", "), i)
+	query := fmt.Sprintf(`UPDATE "User" SET %s WHERE "user_key" = $%d`, strings.Join(setClauses, ", "), i)
 
 	_, err := dao.execContext(ctx, query, args...)
 	return err
 }
 
 func (dao *UserDAO) DeleteByPk(ctx context.Context, pk int) error {
-	query := `DELETE FROM User WHERE user_key = $1`
+	query := `DELETE FROM "User" WHERE "user_key" = $1`
 	_, err := dao.execContext(ctx, query, pk)
 	return err
 }
 
 func (dao *UserDAO) FindByPk(ctx context.Context, pk int) (*User, error) {
 	query := `
-		SELECT user_key, username, Age
-		FROM User
-		WHERE user_key = $1
+		SELECT "user_key", "username", "Age"
+		FROM "User"
+		WHERE "user_key" = $1
 	`
 	row := dao.queryRowContext(ctx, query, pk)
 
@@ -150,7 +156,7 @@ func (dao *UserDAO) CreateMany(ctx context.Context, models []*User) error {
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO User (user_key, username, Age)
+		INSERT INTO "User" ("user_key", "username", "Age")
 		VALUES %s
 	`, strings.Join(placeholders, ", "))
 
@@ -164,10 +170,10 @@ func (dao *UserDAO) UpdateMany(ctx context.Context, models []*User) error {
 	}
 
 	query := `
-		UPDATE User
-		SET username = $1,
-			Age = $2
-		WHERE user_key = $3
+		UPDATE "User"
+		SET "username" = $1,
+			"Age" = $2
+		WHERE "user_key" = $3
 	`
 
 	for _, model := range models {
@@ -196,15 +202,73 @@ func (dao *UserDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
 		args[i] = pk
 	}
 
-	query := fmt.Sprintf(`DELETE FROM User WHERE user_key IN (%s)`, strings.Join(placeholders, ","))
+	query := fmt.Sprintf(`DELETE FROM "User" WHERE "user_key" IN (%s)`, strings.Join(placeholders, ","))
 	_, err := dao.execContext(ctx, query, args...)
 	return err
 }
 
+func (dao *UserDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *UserDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "user_key", "username", "Age":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf("$%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "User" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (dao *UserDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*User, error) {
 	query := `
-		SELECT user_key, username, Age
-		FROM User
+		SELECT "user_key", "username", "Age"
+		FROM "User"
 	`
 
 	if where != "" {
@@ -233,8 +297,8 @@ func (dao *UserDAO) FindOne(ctx context.Context, where string, sort string, args
 
 func (dao *UserDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*User, error) {
 	query := `
-		SELECT user_key, username, Age
-		FROM User
+		SELECT "user_key", "username", "Age"
+		FROM "User"
 	`
 
 	if where != "" {
@@ -272,10 +336,146 @@ func (dao *UserDAO) FindAll(ctx context.Context, where string, sort string, args
 	return models, nil
 }
 
+func (dao *UserDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*User, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "user_key", "username", "Age":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "User"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*User
+	for rows.Next() {
+		var m User
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "user_key":
+				dest[i] = &m.Key
+			case "username":
+				dest[i] = &m.Name
+			case "Age":
+				dest[i] = &m.Age
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *UserDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*User, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "user_key", "username", "Age" FROM "User" WHERE "user_key" = $1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m User
+	err := row.Scan(
+		&m.Key,
+		&m.Name,
+		&m.Age,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *UserDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*User, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "user_key", "username", "Age" FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*User
+	for rows.Next() {
+		var m User
+		err := rows.Scan(
+			&m.Key,
+			&m.Name,
+			&m.Age,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
 func (dao *UserDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*User, error) {
 	query := `
-		SELECT user_key, username, Age
-		FROM User
+		SELECT "user_key", "username", "Age"
+		FROM "User"
 	`
 
 	if where != "" {
@@ -316,7 +516,7 @@ func (dao *UserDAO) FindPaginated(ctx context.Context, limit, offset int, where
 }
 
 func (dao *UserDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
-	query := "SELECT COUNT(*) FROM User"
+	query := `SELECT COUNT(*) FROM "User"`
 
 	if where != "" {
 		query += " WHERE " + where
@@ -333,6 +533,163 @@ func (dao *UserDAO) Count(ctx context.Context, where string, args ...interface{}
 	return count, nil
 }
 
+func (dao *UserDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *UserDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := `SELECT 1 FROM "User" WHERE "user_key" = $1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *UserDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "user_key", "username", "Age":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "User"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *UserDAO) SumAge(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("Age") FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *UserDAO) AvgAge(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("Age") FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *UserDAO) MinAge(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN("Age") FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *UserDAO) MaxAge(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX("Age") FROM "User"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *UserDAO) Adults(ctx context.Context, age int) ([]*User, error) {
+	query := `SELECT "user_key", "username", "Age" FROM "User" WHERE "Age" >= $1 ORDER BY username`
+	rows, err := dao.queryContext(ctx, query, age)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*User
+	for rows.Next() {
+		var m User
+		err := rows.Scan(
+			&m.Key,
+			&m.Name,
+			&m.Age,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *UserDAO) Rename(ctx context.Context, name string, key int) (int64, error) {
+	query := `UPDATE "User" SET username = $1 WHERE user_key = $2`
+	result, err := dao.execContext(ctx, query, name, key)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (dao *UserDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	tx, err := dao.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -0,0 +1,5 @@
+-- name: Adults :many
+SELECT * FROM "User" WHERE "Age" >= $1 ORDER BY username;
+
+-- name: Rename :execrows
+UPDATE "User" SET username = $1 WHERE user_key = $2;
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Jibaru/gormless/internal/config"
 	"github.com/Jibaru/gormless/internal/generator"
+	"github.com/Jibaru/gormless/internal/naming"
 	"github.com/Jibaru/gormless/internal/parser"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +16,12 @@ var (
 	output       string
 	driver       string
 	interfaceOpt bool
+	columnNaming string
+	tableNaming  string
+	pluralTables bool
+	tagKeys      []string
+	configPath   string
+	schemaOpt    bool
 )
 
 var rootCmd = &cobra.Command{
@@ -29,7 +37,12 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
-		models, err := parser.ParseModels(input)
+		opts, err := parserOptions()
+		if err != nil {
+			return err
+		}
+
+		models, err := parser.ParseModelsWithOptions(input, opts)
 		if err != nil {
 			return err
 		}
@@ -42,7 +55,15 @@ var rootCmd = &cobra.Command{
 			return generator.GenerateDAOInterfaces(models, output)
 		}
 
-		return generator.GenerateDAOs(models, output, driver)
+		if err := generator.GenerateDAOs(models, output, driver); err != nil {
+			return err
+		}
+
+		if schemaOpt {
+			return generator.GenerateSchema(models, output, driver)
+		}
+
+		return nil
 	},
 }
 
@@ -51,6 +72,13 @@ func init() {
 	rootCmd.Flags().StringVarP(&output, "output", "o", "", "Path to output folder (required)")
 	rootCmd.Flags().StringVarP(&driver, "driver", "d", "", "Database driver: postgres, mysql, sqlserver, oracle, sqlite (required when not using --interface)")
 	rootCmd.Flags().BoolVar(&interfaceOpt, "interface", false, "Generate DAO interfaces instead of concrete implementations")
+	rootCmd.Flags().StringVar(&columnNaming, "column-naming", string(naming.AsIs), "Naming strategy for columns of fields without a column in their tag: snake, camel, as-is")
+	rootCmd.Flags().StringVar(&tableNaming, "table-naming", string(naming.AsIs), "Naming strategy for tables of models without a TableName method: snake, camel, as-is")
+	rootCmd.Flags().BoolVar(&pluralTables, "plural-tables", false, "Pluralize table names derived from model names")
+	rootCmd.Flags().StringSliceVar(&tagKeys, "tags", []string{"sql"}, "Struct tag keys read for column mappings, by priority: sql, db, gorm, json")
+
+	rootCmd.Flags().StringVar(&configPath, "config", "", "Path to a JSON configuration file")
+	rootCmd.Flags().BoolVar(&schemaOpt, "schema", false, "Also generate the DDL of the models in schema.sql")
 
 	rootCmd.MarkFlagRequired("input")
 	rootCmd.MarkFlagRequired("output")
@@ -67,6 +95,9 @@ func validateFlags() error {
 	if output == "" {
 		return fmt.Errorf("output folder not provided")
 	}
+	if interfaceOpt && schemaOpt {
+		return fmt.Errorf("schema generation requires a driver and cannot be used with --interface")
+	}
 	if !interfaceOpt {
 		if driver == "" {
 			return fmt.Errorf("driver not provided")
@@ -78,6 +109,43 @@ func validateFlags() error {
 	return nil
 }
 
+func parserOptions() (parser.Options, error) {
+	columnStrategy, err := naming.ParseStrategy(columnNaming)
+	if err != nil {
+		return parser.Options{}, err
+	}
+
+	tableStrategy, err := naming.ParseStrategy(tableNaming)
+	if err != nil {
+		return parser.Options{}, err
+	}
+
+	opts := parser.Options{
+		ColumnNaming: columnStrategy,
+		TableNaming:  tableStrategy,
+		PluralTables: pluralTables,
+		TagKeys:      tagKeys,
+	}
+
+	if configPath != "" {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return parser.Options{}, err
+		}
+
+		opts.Converters = make(map[string]parser.Converter, len(cfg.Converters))
+		for goType, converter := range cfg.Converters {
+			opts.Converters[goType] = parser.Converter{
+				Import: converter.Import,
+				Encode: converter.Encode,
+				Decode: converter.Decode,
+			}
+		}
+	}
+
+	return opts, nil
+}
+
 func checkInputExists() error {
 	if _, err := os.Stat(input); os.IsNotExist(err) {
 		return fmt.Errorf("input not exists")
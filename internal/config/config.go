@@ -0,0 +1,43 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config holds the settings read from a gormless configuration file.
+type Config struct {
+	// Converters maps Go types, as written in the models, to the functions
+	// converting them to and from their column value.
+	Converters map[string]Converter `json:"converters"`
+}
+
+// Converter names an encode/decode function pair, qualified by the package
+// imported from Import.
+type Converter struct {
+	Import string `json:"import"`
+	Encode string `json:"encode"`
+	Decode string `json:"decode"`
+}
+
+// Load reads the JSON configuration file at path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	for goType, converter := range cfg.Converters {
+		if converter.Encode == "" || converter.Decode == "" {
+			return Config{}, fmt.Errorf("the converter of %s must set both encode and decode", goType)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,67 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Jibaru/gormless/internal/config"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("converters", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gormless.json")
+		content := `{
+	"converters": {
+		"uuid.UUID": {
+			"import": "github.com/acme/app/convert",
+			"encode": "convert.EncodeUUID",
+			"decode": "convert.DecodeUUID"
+		}
+	}
+}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create config file: %v", err)
+		}
+
+		cfg, err := config.Load(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string]config.Converter{
+			"uuid.UUID": {
+				Import: "github.com/acme/app/convert",
+				Encode: "convert.EncodeUUID",
+				Decode: "convert.DecodeUUID",
+			},
+		}
+		if !reflect.DeepEqual(cfg.Converters, expected) {
+			t.Errorf("Converters mismatch.\nExpected: %+v\nGot: %+v", expected, cfg.Converters)
+		}
+	})
+
+	t.Run("converter without decode", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gormless.json")
+		content := `{"converters": {"uuid.UUID": {"encode": "convert.EncodeUUID"}}}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create config file: %v", err)
+		}
+
+		if _, err := config.Load(path); err == nil {
+			t.Fatal("expected an error for a converter without decode")
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gormless.json")
+		if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+			t.Fatalf("failed to create config file: %v", err)
+		}
+
+		if _, err := config.Load(path); err == nil {
+			t.Fatal("expected an error for invalid JSON")
+		}
+	})
+}
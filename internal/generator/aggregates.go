@@ -0,0 +1,338 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// aggregateKind classifies the fields aggregate methods are generated for.
+type aggregateKind int
+
+const (
+	aggregateNone aggregateKind = iota
+	// aggregateInteger fields get Sum, Avg, Min and Max, summed as int64.
+	aggregateInteger
+	// aggregateFloat fields get Sum, Avg, Min and Max, summed as float64.
+	aggregateFloat
+	// aggregateTime fields get Min and Max.
+	aggregateTime
+)
+
+// getAggregateKind returns the aggregates field supports, along with the Go
+// type Min and Max return, which drops the pointer of nullable fields. Keys,
+// scoping columns and columns the database cannot compare get none.
+func getAggregateKind(field parser.Field, d dialect) (aggregateKind, string) {
+	if field.IsPrimary || field.IsTenant || field.IsVersion || field.IsSoftDelete || field.IsEncrypted || field.IsJSON || field.Converter != nil || field.Enum != nil || d.isArray(field) {
+		return aggregateNone, ""
+	}
+
+	baseType := strings.TrimPrefix(field.Type, "*")
+	switch baseType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return aggregateInteger, baseType
+	case "float32", "float64":
+		return aggregateFloat, baseType
+	case "time.Time":
+		return aggregateTime, baseType
+	}
+	return aggregateNone, ""
+}
+
+// aggregatesUsePackage reports whether the aggregate or GroupBy methods of
+// model refer to the package named pkg, such as time or sql.
+func aggregatesUsePackage(model parser.Model, pkg string, d dialect) bool {
+	for _, field := range model.Fields {
+		if !strings.Contains(field.Type, pkg+".") {
+			continue
+		}
+		if kind, _ := getAggregateKind(field, d); kind != aggregateNone || isGroupable(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyType qualifies goType, as written in the model file, with the
+// package of model when it names a type declared there.
+func qualifyType(model parser.Model, goType string) string {
+	base := strings.TrimLeft(goType, "*[]")
+	if !token.IsExported(base) || strings.Contains(base, ".") {
+		return goType
+	}
+	return goType[:len(goType)-len(base)] + model.Package + "." + base
+}
+
+// isGroupable reports whether a GroupBy method is generated for field, which
+// the groupby option opts in.
+func isGroupable(field parser.Field) bool {
+	return field.IsGroupBy && !field.IsPrimary && !field.IsTenant && !field.IsVersion && !field.IsSoftDelete && !field.IsEncrypted && !field.IsJSON
+}
+
+// generateAggregateMethods generates the CountDistinct method, the Sum, Avg,
+// Min and Max methods of the numeric and time fields of model and the GroupBy
+// methods of its groupby fields.
+func generateAggregateMethods(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(generateCountDistinctMethod(model, daoName, d))
+
+	for _, field := range model.Fields {
+		kind, baseType := getAggregateKind(field, d)
+		if kind == aggregateInteger || kind == aggregateFloat {
+			content.WriteString(generateSumMethod(model, field, kind, daoName, d))
+			content.WriteString(generateAvgMethod(model, field, daoName, d))
+		}
+		if kind != aggregateNone {
+			content.WriteString(generateMinMaxMethod(model, field, baseType, "Min", daoName, d))
+			content.WriteString(generateMinMaxMethod(model, field, baseType, "Max", daoName, d))
+		}
+	}
+
+	for _, field := range model.Fields {
+		if isGroupable(field) {
+			content.WriteString(generateGroupByMethod(model, field, daoName, d))
+		}
+	}
+
+	return content.String()
+}
+
+// generateAggregateQuery renders the prelude of an aggregate method, up to the
+// query selecting expr with the caller provided where appended.
+func generateAggregateQuery(model parser.Model, expr, zeroResults string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+
+	content.WriteString(generateScopeWherePrelude(model, zeroResults))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", d.literal(fmt.Sprintf("SELECT %s FROM %s%s", expr, d.table(model), whereConditions(conditions)))))
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	return content.String()
+}
+
+// generateSumMethod sums field over the matching rows, returning zero when
+// none match.
+func generateSumMethod(model parser.Model, field parser.Field, kind aggregateKind, daoName string, d dialect) string {
+	var content strings.Builder
+
+	resultType, nullType, member := "int64", "sql.NullInt64", "Int64"
+	if kind == aggregateFloat {
+		resultType, nullType, member = "float64", "sql.NullFloat64", "Float64"
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Sum%s(ctx context.Context, where string, args ...interface{}) (%s, error) {\n", daoName, field.Name, resultType))
+	content.WriteString(generateAggregateQuery(model, fmt.Sprintf("SUM(%s)", d.quote(field.Column)), "0, ", d))
+
+	content.WriteString(fmt.Sprintf("\tvar sum %s\n", nullType))
+	content.WriteString("\tif err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {\n")
+	content.WriteString("\t\treturn 0, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(fmt.Sprintf("\treturn sum.%s, nil\n", member))
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateAvgMethod averages field over the matching rows, reporting false
+// when none match.
+func generateAvgMethod(model parser.Model, field parser.Field, daoName string, d dialect) string {
+	var content strings.Builder
+
+	column := d.quote(field.Column)
+	if d.averageCast != "" {
+		column = fmt.Sprintf(d.averageCast, column)
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Avg%s(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {\n", daoName, field.Name))
+	content.WriteString(generateAggregateQuery(model, fmt.Sprintf("AVG(%s)", column), "0, false, ", d))
+
+	content.WriteString("\tvar avg sql.NullFloat64\n")
+	content.WriteString("\tif err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {\n")
+	content.WriteString("\t\treturn 0, false, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn avg.Float64, avg.Valid, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateMinMaxMethod generates the Min or Max method of field, named by
+// aggregate, reporting false when no row matches.
+func generateMinMaxMethod(model parser.Model, field parser.Field, baseType, aggregate, daoName string, d dialect) string {
+	var content strings.Builder
+
+	var zero string
+	switch baseType {
+	case "time.Time":
+		zero = "time.Time{}"
+	default:
+		zero = "0"
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s%s(ctx context.Context, where string, args ...interface{}) (%s, bool, error) {\n", daoName, aggregate, field.Name, baseType))
+	content.WriteString(generateAggregateQuery(model, fmt.Sprintf("%s(%s)", strings.ToUpper(aggregate), d.quote(field.Column)), zero+", false, ", d))
+
+	scanArg := "&value"
+	if baseType == "time.Time" && d.textTimes {
+		scanArg = "textTime{&value}"
+	}
+
+	content.WriteString(fmt.Sprintf("\tvar value sql.Null[%s]\n", baseType))
+	content.WriteString(fmt.Sprintf("\tif err := dao.queryRowContext(ctx, query, args...).Scan(%s); err != nil {\n", scanArg))
+	content.WriteString(fmt.Sprintf("\t\treturn %s, false, err\n", zero))
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn value.V, value.Valid, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// hasTimeAggregate reports whether model has Min and Max methods of a time field.
+func hasTimeAggregate(model parser.Model, d dialect) bool {
+	for _, field := range model.Fields {
+		if kind, _ := getAggregateKind(field, d); kind == aggregateTime {
+			return true
+		}
+	}
+	return false
+}
+
+// generateTextTimeHelpers declares the scanner reading the times databases
+// with textTimes return as text.
+func generateTextTimeHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// textTimeLayouts are the layouts times are written in as text.\n")
+	content.WriteString("var textTimeLayouts = []string{\n")
+	for _, layout := range []string{
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02T15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	} {
+		content.WriteString(fmt.Sprintf("\t%q,\n", layout))
+	}
+	content.WriteString("}\n\n")
+
+	content.WriteString("// textTime scans a time the database may return as text into v, as SQLite\n")
+	content.WriteString("// does for MIN and MAX of a time column.\n")
+	content.WriteString("type textTime struct {\n")
+	content.WriteString("\tv *sql.Null[time.Time]\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (t textTime) Scan(src interface{}) error {\n")
+	content.WriteString("\tvar text string\n")
+	content.WriteString("\tswitch data := src.(type) {\n")
+	content.WriteString("\tcase string:\n")
+	content.WriteString("\t\ttext = data\n")
+	content.WriteString("\tcase []byte:\n")
+	content.WriteString("\t\ttext = string(data)\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn t.v.Scan(src)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tfor _, layout := range textTimeLayouts {\n")
+	content.WriteString("\t\tif value, err := time.Parse(layout, text); err == nil {\n")
+	content.WriteString("\t\t\tt.v.V, t.v.Valid = value, true\n")
+	content.WriteString("\t\t\treturn nil\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn fmt.Errorf(\"cannot parse %q as a time\", text)\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+// generateGroupByMethod counts the matching rows per value of field, in
+// value order. Values are read the way FindAll reads field.
+func generateGroupByMethod(model parser.Model, field parser.Field, daoName string, d dialect) string {
+	var content strings.Builder
+	column := d.quote(field.Column)
+	keyModel := parser.Model{Fields: []parser.Field{field}}
+	keyType := qualifyType(model, field.Type)
+	resultType := fmt.Sprintf("[]struct {\n\tKey %s\n\tCount int64\n}", keyType)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) GroupBy%s(ctx context.Context, where string, args ...interface{}) (%s, error) {\n", daoName, field.Name, resultType))
+	content.WriteString(generateAggregateQuery(model, fmt.Sprintf("%s, COUNT(*)", column), "nil, ", d))
+	content.WriteString(fmt.Sprintf("\tquery += %q\n\n", fmt.Sprintf(" GROUP BY %s ORDER BY %s", column, column)))
+
+	content.WriteString("\trows, err := dao.queryContext(ctx, query, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tdefer rows.Close()\n\n")
+
+	content.WriteString(fmt.Sprintf("\tvar groups %s\n", resultType))
+	content.WriteString("\tfor rows.Next() {\n")
+	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString("\t\tvar count int64\n")
+	content.WriteString(generateNullScanDeclarations(keyModel, "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tif err := rows.Scan(%s, &count); err != nil {\n", generateScanArg(d, field, "m")))
+	content.WriteString("\t\t\treturn nil, err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(keyModel, "m", "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tgroups = append(groups, struct {\n\t\t\tKey %s\n\t\t\tCount int64\n\t\t}{m.%s, count})\n", keyType, field.Name))
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif err := rows.Err(); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn groups, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateColumnCheck rejects with ErrUnknownColumn the value of the Go
+// variable name when it is not a column of model.
+func generateColumnCheck(model parser.Model, name, zeroResults, indent string) string {
+	var content strings.Builder
+
+	columns := make([]string, 0, len(model.Fields))
+	for _, field := range model.Fields {
+		columns = append(columns, fmt.Sprintf("%q", field.Column))
+	}
+
+	content.WriteString(fmt.Sprintf("%sswitch %s {\n", indent, name))
+	content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(columns, ", ")))
+	content.WriteString(indent + "default:\n")
+	content.WriteString(fmt.Sprintf("%s\treturn %sfmt.Errorf(\"%%w: %%q\", ErrUnknownColumn, %s)\n", indent, zeroResults, name))
+	content.WriteString(indent + "}\n")
+
+	return content.String()
+}
+
+// generateCountDistinctMethod counts the distinct values of a column chosen
+// by the caller among the columns of model.
+func generateCountDistinctMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {\n", daoName))
+	content.WriteString(generateColumnCheck(model, "column", "0, ", "\t"))
+	content.WriteString("\n")
+	content.WriteString(generateScopeWherePrelude(model, "0, "))
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT %s) FROM %s%s", d.quote("%s"), d.table(model), whereConditions(conditions))
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, column)\n\n", d.literal(query)))
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	content.WriteString("\tvar count int64\n")
+	content.WriteString("\tif err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {\n")
+	content.WriteString("\t\treturn 0, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn count, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// generateBulkMethods generates the DeleteWhere and UpdateWhere methods,
+// writing every row matching a where clause and returning how many were.
+func generateBulkMethods(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(generateDeleteWhereMethod(model, daoName, d))
+	content.WriteString(generateUpdateWhereMethod(model, daoName, d))
+
+	return content.String()
+}
+
+// generateFullTableGuard refuses an empty where unless the context allows
+// writing every row of the table.
+func generateFullTableGuard() string {
+	var content strings.Builder
+
+	content.WriteString("\tif where == \"\" && !fullTableAllowed(ctx) {\n")
+	content.WriteString("\t\treturn 0, ErrFullTable\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateRowsAffected ends a bulk method, returning the rows written by query.
+func generateRowsAffected() string {
+	var content strings.Builder
+
+	content.WriteString("\tresult, err := dao.execContext(ctx, query, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn 0, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn result.RowsAffected()\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateDeleteWhereMethod deletes the matching rows, stamping the soft delete
+// column instead of deleting them for models tagged with softdelete.
+func generateDeleteWhereMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {\n", daoName))
+	content.WriteString(generateFullTableGuard())
+	content.WriteString(generateScopeWherePrelude(model, "0, "))
+
+	field, ok := getSoftDeleteField(model)
+	if !ok {
+		content.WriteString(fmt.Sprintf("\tquery := %s\n\n", d.literal(fmt.Sprintf("DELETE FROM %s", d.table(model)))))
+	} else {
+		query := d.literal(fmt.Sprintf("UPDATE %s SET %s = %s%s", d.table(model), d.quote(field.Column), d.placeholder, whereConditions(conditions)))
+		if d.isPositional() {
+			// The deletion time is bound before any placeholder of where.
+			content.WriteString("\targs = append([]interface{}{time.Now()}, args...)\n")
+			content.WriteString(fmt.Sprintf("\tquery := %s\n\n", query))
+		} else {
+			content.WriteString("\targs = append(args, time.Now())\n")
+			content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, len(args))\n\n", query))
+		}
+	}
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	content.WriteString(generateRowsAffected())
+
+	return content.String()
+}
+
+// generateUpdateWhereMethod sets the columns of fields on the matching rows,
+// converting the values the way PartialUpdate does.
+func generateUpdateWhereMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+	versionField, versioned := getVersionField(model)
+	tenantField, tenanted := getTenantField(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {\n", daoName))
+	content.WriteString(generateFullTableGuard())
+	content.WriteString("\tif len(fields) == 0 {\n")
+	content.WriteString("\t\treturn 0, nil\n")
+	content.WriteString("\t}\n\n")
+
+	if tenanted {
+		content.WriteString(fmt.Sprintf("\tif _, ok := fields[%q]; ok {\n", tenantField.Column))
+		content.WriteString(fmt.Sprintf("\t\treturn 0, fmt.Errorf(\"fields must not contain the %s\")\n", tenantField.Column))
+		content.WriteString("\t}\n\n")
+	}
+	if versioned {
+		content.WriteString(fmt.Sprintf("\tif _, ok := fields[%q]; ok {\n", versionField.Column))
+		content.WriteString(fmt.Sprintf("\t\treturn 0, fmt.Errorf(\"fields must not contain the %s\")\n", versionField.Column))
+		content.WriteString("\t}\n\n")
+	}
+
+	content.WriteString(generateScopeWherePrelude(model, "0, "))
+
+	content.WriteString("\tsetClauses := make([]string, 0, len(fields))\n")
+	content.WriteString("\tsetArgs := make([]interface{}, 0, len(fields))\n")
+	content.WriteString("\tfor field, value := range fields {\n")
+	content.WriteString(generateColumnCheck(model, "field", "0, ", "\t\t"))
+	content.WriteString(generateValidFieldCall(model, "0, ", "\t\t"))
+	content.WriteString(generateFieldValueConversion(d, model, "\t\t"))
+	content.WriteString("\t\tsetArgs = append(setArgs, value)\n")
+	content.WriteString(fmt.Sprintf("\t\tsetClauses = append(setClauses, %q+field+%s)\n", d.openQuote, d.concatBind(d.closeQuote+" = ", "len(args)+len(setArgs)")))
+	content.WriteString("\t}\n")
+	if versioned {
+		content.WriteString(fmt.Sprintf("\tsetClauses = append(setClauses, %q)\n", fmt.Sprintf("%s = %s + 1", d.quote(versionField.Column), d.quote(versionField.Column))))
+	} else {
+		content.WriteString("\tif len(setClauses) == 0 {\n")
+		content.WriteString("\t\treturn 0, nil\n")
+		content.WriteString("\t}\n")
+	}
+	if d.isPositional() {
+		// The values set are bound before any placeholder of where.
+		content.WriteString("\targs = append(setArgs, args...)\n\n")
+	} else {
+		content.WriteString("\targs = append(args, setArgs...)\n\n")
+	}
+
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, strings.Join(setClauses, \", \"))\n\n", d.literal(fmt.Sprintf("UPDATE %s SET %%s%s", d.table(model), whereConditions(conditions)))))
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	content.WriteString(generateRowsAffected())
+
+	return content.String()
+}
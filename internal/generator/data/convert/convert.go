@@ -0,0 +1,39 @@
+package convert
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Code is stored upper-cased.
+type Code string
+
+// Cents is stored as an integer amount.
+type Cents int64
+
+func EncodeCode(code Code) (driver.Value, error) {
+	return strings.ToUpper(string(code)), nil
+}
+
+func DecodeCode(src interface{}) (Code, error) {
+	switch v := src.(type) {
+	case string:
+		return Code(v), nil
+	case []byte:
+		return Code(v), nil
+	}
+	return "", fmt.Errorf("cannot decode %T into a Code", src)
+}
+
+func EncodeCents(c Cents) (driver.Value, error) {
+	return int64(c), nil
+}
+
+func DecodeCents(src interface{}) (Cents, error) {
+	v, ok := src.(int64)
+	if !ok {
+		return 0, fmt.Errorf("cannot decode %T into Cents", src)
+	}
+	return Cents(v), nil
+}
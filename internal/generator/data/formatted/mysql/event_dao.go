@@ -0,0 +1,613 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Event = models.Event
+
+type EventDAO struct {
+	db *sql.DB
+}
+
+func NewEventDAO(db *sql.DB) *EventDAO {
+	return &EventDAO{db: db}
+}
+
+func (dao *EventDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *EventDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *EventDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *EventDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *EventDAO) Create(ctx context.Context, m *Event) error {
+	query := "INSERT INTO `events` (`id`, `name`, `payload`, `tags`) " +
+		"VALUES (?, ?, CAST(? AS JSON), CAST(? AS JSON))"
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Name,
+		jsonColumn{m.Payload},
+		jsonColumn{m.Tags},
+	)
+
+	return err
+}
+
+func (dao *EventDAO) Update(ctx context.Context, m *Event) error {
+	query := "UPDATE `events` " +
+		"SET `name` = ?, `payload` = CAST(? AS JSON), `tags` = CAST(? AS JSON) " +
+		"WHERE `id` = ?"
+
+	_, err := dao.execContext(ctx, query,
+		m.Name,
+		jsonColumn{m.Payload},
+		jsonColumn{m.Tags},
+		m.ID,
+	)
+	return err
+}
+
+func (dao *EventDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "payload", "tags":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "payload", "tags":
+			value = jsonColumn{value}
+		}
+		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", field))
+		args = append(args, value)
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf("UPDATE `events` SET %s WHERE `id` = ?", strings.Join(setClauses, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *EventDAO) DeleteByPk(ctx context.Context, pk int) error {
+	query := "DELETE FROM `events` WHERE `id` = ?"
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *EventDAO) FindByPk(ctx context.Context, pk int) (*Event, error) {
+	query := "SELECT `id`, `name`, `payload`, `tags` " +
+		"FROM `events` " +
+		"WHERE `id` = ?"
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Event
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		jsonColumn{&m.Payload},
+		jsonColumn{&m.Tags},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *EventDAO) CreateMany(ctx context.Context, models []*Event) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		placeholders[i] = "(?,?,CAST(? AS JSON),CAST(? AS JSON))"
+
+		args = append(args,
+			model.ID,
+			model.Name,
+			jsonColumn{model.Payload},
+			jsonColumn{model.Tags},
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO `events` (`id`, `name`, `payload`, `tags`) "+
+		"VALUES %s", strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *EventDAO) UpdateMany(ctx context.Context, models []*Event) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := "UPDATE `events` " +
+		"SET `name` = ?, `payload` = CAST(? AS JSON), `tags` = CAST(? AS JSON) " +
+		"WHERE `id` = ?"
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Name,
+			jsonColumn{model.Payload},
+			jsonColumn{model.Tags},
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *EventDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf("DELETE FROM `events` WHERE `id` IN (%s)", placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *EventDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := "DELETE FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *EventDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "payload", "tags":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "payload", "tags":
+			value = jsonColumn{value}
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf("UPDATE `events` SET %s", strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *EventDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Event, error) {
+	query := "SELECT `id`, `name`, `payload`, `tags` " +
+		"FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Event
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		jsonColumn{&m.Payload},
+		jsonColumn{&m.Tags},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *EventDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Event, error) {
+	query := "SELECT `id`, `name`, `payload`, `tags` " +
+		"FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Event
+	for rows.Next() {
+		var m Event
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			jsonColumn{&m.Payload},
+			jsonColumn{&m.Tags},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *EventDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Event, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "name", "payload", "tags":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "`" + column + "`"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `events`", strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Event
+	for rows.Next() {
+		var m Event
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "name":
+				dest[i] = &m.Name
+			case "payload":
+				dest[i] = jsonColumn{&m.Payload}
+			case "tags":
+				dest[i] = jsonColumn{&m.Tags}
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *EventDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Event, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `id`, `name`, `payload`, `tags` FROM `events` WHERE `id` = ?"
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Event
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		jsonColumn{&m.Payload},
+		jsonColumn{&m.Tags},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *EventDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Event, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `id`, `name`, `payload`, `tags` FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Event
+	for rows.Next() {
+		var m Event
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			jsonColumn{&m.Payload},
+			jsonColumn{&m.Tags},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *EventDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Event, error) {
+	query := "SELECT `id`, `name`, `payload`, `tags` " +
+		"FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Event
+	for rows.Next() {
+		var m Event
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			jsonColumn{&m.Payload},
+			jsonColumn{&m.Tags},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *EventDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := "SELECT COUNT(*) FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *EventDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := "SELECT 1 FROM `events`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *EventDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := "SELECT 1 FROM `events` WHERE `id` = ?"
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *EventDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "name", "payload", "tags":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT `%s`) FROM `events`", column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *EventDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,866 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Invoice = models.Invoice
+
+type InvoiceDAO struct {
+	db *sql.DB
+}
+
+func NewInvoiceDAO(db *sql.DB) *InvoiceDAO {
+	return &InvoiceDAO{db: db}
+}
+
+func (dao *InvoiceDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *InvoiceDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *InvoiceDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *InvoiceDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *InvoiceDAO) tenantID(ctx context.Context) (int64, bool) {
+	var tenantID int64
+	ok := tenantValue(ctx, &tenantID)
+	return tenantID, ok
+}
+
+func (dao *InvoiceDAO) scopeWhere(ctx context.Context, where string, args []interface{}) (string, []interface{}, bool) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return "", nil, false
+	}
+
+	args = append(args, tenantID)
+	condition := "`tenant_id` = ?"
+	if where != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+
+	return condition, args, true
+}
+
+func (dao *InvoiceDAO) Create(ctx context.Context, m *Invoice) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	m.TenantID = tenantID
+
+	query := "INSERT INTO `billing`.`invoices` (`id`, `tenant_id`, `number`, `amount`) " +
+		"VALUES (?, ?, ?, ?)"
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.TenantID,
+		m.Number,
+		m.Amount,
+	)
+
+	return err
+}
+
+func (dao *InvoiceDAO) Update(ctx context.Context, m *Invoice) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := "UPDATE `billing`.`invoices` " +
+		"SET `number` = ?, `amount` = ? " +
+		"WHERE `id` = ? AND `tenant_id` = ?"
+
+	_, err := dao.execContext(ctx, query,
+		m.Number,
+		m.Amount,
+		m.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (dao *InvoiceDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+2)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "number", "amount":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+
+	args = append(args, pk)
+	whereClause := "`id` = ?"
+	args = append(args, tenantID)
+	whereClause += " AND `tenant_id` = ?"
+
+	query := fmt.Sprintf("UPDATE `billing`.`invoices` SET %s WHERE %s", strings.Join(setClauses, ", "), whereClause)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *InvoiceDAO) DeleteByPk(ctx context.Context, pk int) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := "DELETE FROM `billing`.`invoices` WHERE `id` = ? AND `tenant_id` = ?"
+	_, err := dao.execContext(ctx, query, pk, tenantID)
+	return err
+}
+
+func (dao *InvoiceDAO) FindByPk(ctx context.Context, pk int) (*Invoice, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` " +
+		"FROM `billing`.`invoices` " +
+		"WHERE `id` = ? AND `tenant_id` = ?"
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m Invoice
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Number,
+		&m.Amount,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceDAO) CreateMany(ctx context.Context, models []*Invoice) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		model.TenantID = tenantID
+		placeholders[i] = "(?,?,?,?)"
+
+		args = append(args,
+			model.ID,
+			model.TenantID,
+			model.Number,
+			model.Amount,
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO `billing`.`invoices` (`id`, `tenant_id`, `number`, `amount`) "+
+		"VALUES %s", strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *InvoiceDAO) UpdateMany(ctx context.Context, models []*Invoice) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := "UPDATE `billing`.`invoices` " +
+		"SET `number` = ?, `amount` = ? " +
+		"WHERE `id` = ? AND `tenant_id` = ?"
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Number,
+			model.Amount,
+			model.ID,
+			tenantID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *InvoiceDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = pk
+	}
+
+	args = append(args, tenantID)
+	query := fmt.Sprintf("DELETE FROM `billing`.`invoices` WHERE `id` IN (%s) AND `tenant_id` = ?", placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *InvoiceDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := "DELETE FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *InvoiceDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return 0, fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "number", "amount":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf("UPDATE `billing`.`invoices` SET %s", strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *InvoiceDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Invoice, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` " +
+		"FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Invoice
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Number,
+		&m.Amount,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Invoice, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` " +
+		"FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Invoice
+	for rows.Next() {
+		var m Invoice
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Number,
+			&m.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Invoice, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "tenant_id", "number", "amount":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "`" + column + "`"
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `billing`.`invoices`", strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Invoice
+	for rows.Next() {
+		var m Invoice
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "tenant_id":
+				dest[i] = &m.TenantID
+			case "number":
+				dest[i] = &m.Number
+			case "amount":
+				dest[i] = &m.Amount
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Invoice, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` FROM `billing`.`invoices` WHERE `id` = ? AND `tenant_id` = ?"
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m Invoice
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Number,
+		&m.Amount,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Invoice, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Invoice
+	for rows.Next() {
+		var m Invoice
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Number,
+			&m.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Invoice, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` " +
+		"FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Invoice
+	for rows.Next() {
+		var m Invoice
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Number,
+			&m.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := "SELECT COUNT(*) FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *InvoiceDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := "SELECT 1 FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *InvoiceDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := "SELECT 1 FROM `billing`.`invoices` WHERE `id` = ? AND `tenant_id` = ?"
+	args := []interface{}{pk, tenantID}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *InvoiceDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "tenant_id", "number", "amount":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT `%s`) FROM `billing`.`invoices`", column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *InvoiceDAO) SumAmount(ctx context.Context, where string, args ...interface{}) (float64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := "SELECT SUM(`amount`) FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Float64, nil
+}
+
+func (dao *InvoiceDAO) AvgAmount(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := "SELECT AVG(`amount`) FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *InvoiceDAO) MinAmount(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := "SELECT MIN(`amount`) FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *InvoiceDAO) MaxAmount(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := "SELECT MAX(`amount`) FROM `billing`.`invoices`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *InvoiceDAO) FindByNumber(ctx context.Context, number string) (*Invoice, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `number`, `amount` FROM billing.invoices WHERE number = ? AND tenant_id = ?"
+	row := dao.queryRowContext(ctx, query, number, tenantID)
+
+	var m Invoice
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Number,
+		&m.Amount,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceDAO) VoidAbove(ctx context.Context, amount float64) (int64, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `DELETE FROM billing.invoices WHERE tenant_id = ? AND amount > ?`
+	result, err := dao.execContext(ctx, query, tenantID, amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *InvoiceDAO) PurgeTenant(ctx context.Context, tenantId int64) error {
+	query := `DELETE FROM billing.invoices WHERE tenant_id = ?`
+	_, err := dao.execContext(ctx, query, tenantId)
+	return err
+}
+
+func (dao *InvoiceDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,727 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+	"time"
+)
+
+type Page = models.Page
+
+type PageDAO struct {
+	db *sql.DB
+}
+
+func NewPageDAO(db *sql.DB) *PageDAO {
+	return &PageDAO{db: db}
+}
+
+func (dao *PageDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *PageDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *PageDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *PageDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *PageDAO) Create(ctx context.Context, m *Page) error {
+	query := "INSERT INTO `pages` (`id`, `title`, `version`) " +
+		"VALUES (?, ?, ?)"
+
+	_, err := dao.execContext(ctx, query,
+		m.ID,
+		m.Title,
+		m.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	row := dao.queryRowContext(ctx, "SELECT `slug`, `excerpt`, `updated_at` FROM `pages` WHERE `id` = ?", m.ID)
+	err = row.Scan(
+		&m.Slug,
+		&m.Excerpt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dao *PageDAO) Update(ctx context.Context, m *Page) error {
+	query := "UPDATE `pages` " +
+		"SET `title` = ?, `version` = `version` + 1 " +
+		"WHERE `id` = ? AND `version` = ?"
+
+	result, err := dao.execContext(ctx, query,
+		m.Title,
+		m.ID,
+		m.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+
+	m.Version++
+
+	row := dao.queryRowContext(ctx, "SELECT `slug`, `excerpt`, `updated_at` FROM `pages` WHERE `id` = ?", m.ID)
+	err = row.Scan(
+		&m.Slug,
+		&m.Excerpt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dao *PageDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	version, ok := fields["version"]
+	if !ok {
+		return fmt.Errorf("fields must contain the current version")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+2)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "slug", "excerpt", "updated_at", "version":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "slug", "excerpt", "updated_at":
+			continue
+		}
+		if field == "version" {
+			continue
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	setClauses = append(setClauses, "`version` = `version` + 1")
+
+	args = append(args, pk)
+	whereClause := "`id` = ?"
+	args = append(args, version)
+	whereClause += " AND `version` = ?"
+
+	query := fmt.Sprintf("UPDATE `pages` SET %s WHERE %s", strings.Join(setClauses, ", "), whereClause)
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+
+	return nil
+}
+
+func (dao *PageDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := "DELETE FROM `pages` WHERE `id` = ?"
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *PageDAO) FindByPk(ctx context.Context, pk int64) (*Page, error) {
+	query := "SELECT `id`, `title`, `slug`, `excerpt`, `updated_at`, `version` " +
+		"FROM `pages` " +
+		"WHERE `id` = ?"
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Page
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Slug,
+		&m.Excerpt,
+		&m.UpdatedAt,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PageDAO) CreateMany(ctx context.Context, models []*Page) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*3)
+
+	for i, model := range models {
+		placeholders[i] = "(?,?,?)"
+
+		args = append(args,
+			model.ID,
+			model.Title,
+			model.Version,
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO `pages` (`id`, `title`, `version`) "+
+		"VALUES %s", strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PageDAO) UpdateMany(ctx context.Context, models []*Page) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := "UPDATE `pages` " +
+		"SET `title` = ?, `version` = `version` + 1 " +
+		"WHERE `id` = ? AND `version` = ?"
+
+	for _, model := range models {
+		result, err := dao.execContext(ctx, query,
+			model.Title,
+			model.ID,
+			model.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrStaleObject
+		}
+
+		model.Version++
+	}
+
+	return nil
+}
+
+func (dao *PageDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf("DELETE FROM `pages` WHERE `id` IN (%s)", placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PageDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := "DELETE FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PageDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	if _, ok := fields["version"]; ok {
+		return 0, fmt.Errorf("fields must not contain the version")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "slug", "excerpt", "updated_at", "version":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "slug", "excerpt", "updated_at":
+			continue
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	setClauses = append(setClauses, "`version` = `version` + 1")
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf("UPDATE `pages` SET %s", strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PageDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Page, error) {
+	query := "SELECT `id`, `title`, `slug`, `excerpt`, `updated_at`, `version` " +
+		"FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Page
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Slug,
+		&m.Excerpt,
+		&m.UpdatedAt,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PageDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Page, error) {
+	query := "SELECT `id`, `title`, `slug`, `excerpt`, `updated_at`, `version` " +
+		"FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Page
+	for rows.Next() {
+		var m Page
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Slug,
+			&m.Excerpt,
+			&m.UpdatedAt,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PageDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Page, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "title", "slug", "excerpt", "updated_at", "version":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "`" + column + "`"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `pages`", strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Page
+	for rows.Next() {
+		var m Page
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "title":
+				dest[i] = &m.Title
+			case "slug":
+				dest[i] = &m.Slug
+			case "excerpt":
+				dest[i] = &m.Excerpt
+			case "updated_at":
+				dest[i] = &m.UpdatedAt
+			case "version":
+				dest[i] = &m.Version
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PageDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Page, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `id`, `title`, `slug`, `excerpt`, `updated_at`, `version` FROM `pages` WHERE `id` = ?"
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Page
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Slug,
+		&m.Excerpt,
+		&m.UpdatedAt,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PageDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Page, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `id`, `title`, `slug`, `excerpt`, `updated_at`, `version` FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Page
+	for rows.Next() {
+		var m Page
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Slug,
+			&m.Excerpt,
+			&m.UpdatedAt,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PageDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Page, error) {
+	query := "SELECT `id`, `title`, `slug`, `excerpt`, `updated_at`, `version` " +
+		"FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Page
+	for rows.Next() {
+		var m Page
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Slug,
+			&m.Excerpt,
+			&m.UpdatedAt,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PageDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := "SELECT COUNT(*) FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PageDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := "SELECT 1 FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PageDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := "SELECT 1 FROM `pages` WHERE `id` = ?"
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PageDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "title", "slug", "excerpt", "updated_at", "version":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT `%s`) FROM `pages`", column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PageDAO) MinUpdatedAt(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := "SELECT MIN(`updated_at`) FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PageDAO) MaxUpdatedAt(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := "SELECT MAX(`updated_at`) FROM `pages`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PageDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
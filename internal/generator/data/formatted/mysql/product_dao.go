@@ -0,0 +1,606 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/convert"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Product = models.Product
+
+type ProductDAO struct {
+	db *sql.DB
+}
+
+func NewProductDAO(db *sql.DB) *ProductDAO {
+	return &ProductDAO{db: db}
+}
+
+func (dao *ProductDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *ProductDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *ProductDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *ProductDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *ProductDAO) Create(ctx context.Context, m *Product) error {
+	query := "INSERT INTO `products` (`code`, `name`, `price`) " +
+		"VALUES (?, ?, ?)"
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		convertedValue(m.Code, convert.EncodeCode),
+		m.Name,
+		convertedValue(m.Price, convert.EncodeCents),
+	)
+
+	return err
+}
+
+func (dao *ProductDAO) Update(ctx context.Context, m *Product) error {
+	query := "UPDATE `products` " +
+		"SET `name` = ?, `price` = ? " +
+		"WHERE `code` = ?"
+
+	_, err := dao.execContext(ctx, query,
+		m.Name,
+		convertedValue(m.Price, convert.EncodeCents),
+		convertedValue(m.Code, convert.EncodeCode),
+	)
+	return err
+}
+
+func (dao *ProductDAO) PartialUpdate(ctx context.Context, pk convert.Code, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for field, value := range fields {
+		switch field {
+		case "code", "name", "price":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "code":
+			value = convertedValue(value, convert.EncodeCode)
+		case "price":
+			value = convertedValue(value, convert.EncodeCents)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", field))
+		args = append(args, value)
+	}
+
+	args = append(args, convertedValue(pk, convert.EncodeCode))
+
+	query := fmt.Sprintf("UPDATE `products` SET %s WHERE `code` = ?", strings.Join(setClauses, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ProductDAO) DeleteByPk(ctx context.Context, pk convert.Code) error {
+	query := "DELETE FROM `products` WHERE `code` = ?"
+	_, err := dao.execContext(ctx, query, convertedValue(pk, convert.EncodeCode))
+	return err
+}
+
+func (dao *ProductDAO) FindByPk(ctx context.Context, pk convert.Code) (*Product, error) {
+	query := "SELECT `code`, `name`, `price` " +
+		"FROM `products` " +
+		"WHERE `code` = ?"
+	row := dao.queryRowContext(ctx, query, convertedValue(pk, convert.EncodeCode))
+
+	var m Product
+	err := row.Scan(
+		convertedScanner(&m.Code, convert.DecodeCode),
+		&m.Name,
+		convertedScanner(&m.Price, convert.DecodeCents),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ProductDAO) CreateMany(ctx context.Context, models []*Product) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*3)
+
+	for i, model := range models {
+		placeholders[i] = "(?,?,?)"
+
+		args = append(args,
+			convertedValue(model.Code, convert.EncodeCode),
+			model.Name,
+			convertedValue(model.Price, convert.EncodeCents),
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO `products` (`code`, `name`, `price`) "+
+		"VALUES %s", strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ProductDAO) UpdateMany(ctx context.Context, models []*Product) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := "UPDATE `products` " +
+		"SET `name` = ?, `price` = ? " +
+		"WHERE `code` = ?"
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Name,
+			convertedValue(model.Price, convert.EncodeCents),
+			convertedValue(model.Code, convert.EncodeCode),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *ProductDAO) DeleteManyByPks(ctx context.Context, pks []convert.Code) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = convertedValue(pk, convert.EncodeCode)
+	}
+
+	query := fmt.Sprintf("DELETE FROM `products` WHERE `code` IN (%s)", placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ProductDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := "DELETE FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ProductDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "code", "name", "price":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "code":
+			value = convertedValue(value, convert.EncodeCode)
+		case "price":
+			value = convertedValue(value, convert.EncodeCents)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf("UPDATE `products` SET %s", strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ProductDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Product, error) {
+	query := "SELECT `code`, `name`, `price` " +
+		"FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Product
+	err := row.Scan(
+		convertedScanner(&m.Code, convert.DecodeCode),
+		&m.Name,
+		convertedScanner(&m.Price, convert.DecodeCents),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ProductDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Product, error) {
+	query := "SELECT `code`, `name`, `price` " +
+		"FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Product
+	for rows.Next() {
+		var m Product
+		err := rows.Scan(
+			convertedScanner(&m.Code, convert.DecodeCode),
+			&m.Name,
+			convertedScanner(&m.Price, convert.DecodeCents),
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ProductDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Product, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "code", "name", "price":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "`" + column + "`"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `products`", strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Product
+	for rows.Next() {
+		var m Product
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "code":
+				dest[i] = convertedScanner(&m.Code, convert.DecodeCode)
+			case "name":
+				dest[i] = &m.Name
+			case "price":
+				dest[i] = convertedScanner(&m.Price, convert.DecodeCents)
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ProductDAO) FindByPkForUpdate(ctx context.Context, pk convert.Code, lock LockMode) (*Product, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `code`, `name`, `price` FROM `products` WHERE `code` = ?"
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, convertedValue(pk, convert.EncodeCode))
+
+	var m Product
+	err := row.Scan(
+		convertedScanner(&m.Code, convert.DecodeCode),
+		&m.Name,
+		convertedScanner(&m.Price, convert.DecodeCents),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ProductDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Product, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `code`, `name`, `price` FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Product
+	for rows.Next() {
+		var m Product
+		err := rows.Scan(
+			convertedScanner(&m.Code, convert.DecodeCode),
+			&m.Name,
+			convertedScanner(&m.Price, convert.DecodeCents),
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ProductDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Product, error) {
+	query := "SELECT `code`, `name`, `price` " +
+		"FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Product
+	for rows.Next() {
+		var m Product
+		err := rows.Scan(
+			convertedScanner(&m.Code, convert.DecodeCode),
+			&m.Name,
+			convertedScanner(&m.Price, convert.DecodeCents),
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ProductDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := "SELECT COUNT(*) FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ProductDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := "SELECT 1 FROM `products`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ProductDAO) ExistsByPk(ctx context.Context, pk convert.Code) (bool, error) {
+	query := "SELECT 1 FROM `products` WHERE `code` = ?"
+	args := []interface{}{convertedValue(pk, convert.EncodeCode)}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ProductDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "code", "name", "price":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT `%s`) FROM `products`", column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ProductDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,821 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Tax = models.Tax
+
+type TaxDAO struct {
+	db *sql.DB
+}
+
+func NewTaxDAO(db *sql.DB) *TaxDAO {
+	return &TaxDAO{db: db}
+}
+
+func (dao *TaxDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *TaxDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *TaxDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *TaxDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *TaxDAO) tenantID(ctx context.Context) (int64, bool) {
+	var tenantID int64
+	ok := tenantValue(ctx, &tenantID)
+	return tenantID, ok
+}
+
+func (dao *TaxDAO) scopeWhere(ctx context.Context, where string, args []interface{}) (string, []interface{}, bool) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return "", nil, false
+	}
+
+	args = append(args, tenantID)
+	condition := "`tenant_id` = ?"
+	if where != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+
+	return condition, args, true
+}
+
+func (dao *TaxDAO) Create(ctx context.Context, m *Tax) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	m.TenantID = tenantID
+
+	query := "INSERT INTO `billing`.`taxes` (`id`, `tenant_id`, `name`, `rate`) " +
+		"VALUES (?, ?, ?, ?)"
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.TenantID,
+		m.Name,
+		m.Rate,
+	)
+
+	return err
+}
+
+func (dao *TaxDAO) Update(ctx context.Context, m *Tax) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := "UPDATE `billing`.`taxes` " +
+		"SET `name` = ?, `rate` = ? " +
+		"WHERE `id` = ? AND `tenant_id` = ?"
+
+	_, err := dao.execContext(ctx, query,
+		m.Name,
+		m.Rate,
+		m.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (dao *TaxDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+2)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "name", "rate":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+
+	args = append(args, pk)
+	whereClause := "`id` = ?"
+	args = append(args, tenantID)
+	whereClause += " AND `tenant_id` = ?"
+
+	query := fmt.Sprintf("UPDATE `billing`.`taxes` SET %s WHERE %s", strings.Join(setClauses, ", "), whereClause)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *TaxDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := "DELETE FROM `billing`.`taxes` WHERE `id` = ? AND `tenant_id` = ?"
+	_, err := dao.execContext(ctx, query, pk, tenantID)
+	return err
+}
+
+func (dao *TaxDAO) FindByPk(ctx context.Context, pk int64) (*Tax, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `name`, `rate` " +
+		"FROM `billing`.`taxes` " +
+		"WHERE `id` = ? AND `tenant_id` = ?"
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m Tax
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Name,
+		&m.Rate,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *TaxDAO) CreateMany(ctx context.Context, models []*Tax) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		model.TenantID = tenantID
+		placeholders[i] = "(?,?,?,?)"
+
+		args = append(args,
+			model.ID,
+			model.TenantID,
+			model.Name,
+			model.Rate,
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO `billing`.`taxes` (`id`, `tenant_id`, `name`, `rate`) "+
+		"VALUES %s", strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *TaxDAO) UpdateMany(ctx context.Context, models []*Tax) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := "UPDATE `billing`.`taxes` " +
+		"SET `name` = ?, `rate` = ? " +
+		"WHERE `id` = ? AND `tenant_id` = ?"
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Name,
+			model.Rate,
+			model.ID,
+			tenantID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *TaxDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = pk
+	}
+
+	args = append(args, tenantID)
+	query := fmt.Sprintf("DELETE FROM `billing`.`taxes` WHERE `id` IN (%s) AND `tenant_id` = ?", placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *TaxDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := "DELETE FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *TaxDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return 0, fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "name", "rate":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf("UPDATE `billing`.`taxes` SET %s", strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *TaxDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Tax, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `name`, `rate` " +
+		"FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Tax
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Name,
+		&m.Rate,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *TaxDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Tax, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `name`, `rate` " +
+		"FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Tax
+	for rows.Next() {
+		var m Tax
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Name,
+			&m.Rate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TaxDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Tax, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "tenant_id", "name", "rate":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "`" + column + "`"
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `billing`.`taxes`", strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Tax
+	for rows.Next() {
+		var m Tax
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "tenant_id":
+				dest[i] = &m.TenantID
+			case "name":
+				dest[i] = &m.Name
+			case "rate":
+				dest[i] = &m.Rate
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TaxDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Tax, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `name`, `rate` FROM `billing`.`taxes` WHERE `id` = ? AND `tenant_id` = ?"
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m Tax
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Name,
+		&m.Rate,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *TaxDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Tax, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `name`, `rate` FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Tax
+	for rows.Next() {
+		var m Tax
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Name,
+			&m.Rate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TaxDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Tax, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := "SELECT `id`, `tenant_id`, `name`, `rate` " +
+		"FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Tax
+	for rows.Next() {
+		var m Tax
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Name,
+			&m.Rate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TaxDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := "SELECT COUNT(*) FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *TaxDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := "SELECT 1 FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *TaxDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := "SELECT 1 FROM `billing`.`taxes` WHERE `id` = ? AND `tenant_id` = ?"
+	args := []interface{}{pk, tenantID}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *TaxDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "tenant_id", "name", "rate":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT `%s`) FROM `billing`.`taxes`", column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *TaxDAO) SumRate(ctx context.Context, where string, args ...interface{}) (float64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := "SELECT SUM(`rate`) FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Float64, nil
+}
+
+func (dao *TaxDAO) AvgRate(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := "SELECT AVG(`rate`) FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *TaxDAO) MinRate(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := "SELECT MIN(`rate`) FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *TaxDAO) MaxRate(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := "SELECT MAX(`rate`) FROM `billing`.`taxes`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *TaxDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,762 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Ticket = models.Ticket
+
+type TicketDAO struct {
+	db *sql.DB
+}
+
+func NewTicketDAO(db *sql.DB) *TicketDAO {
+	return &TicketDAO{db: db}
+}
+
+func (dao *TicketDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *TicketDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *TicketDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *TicketDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *TicketDAO) Valid(m *Ticket) error {
+	switch m.Status {
+	case "open", "closed":
+	default:
+		return fmt.Errorf("%w: Status %v is not a TicketStatus", ErrInvalidEnum, m.Status)
+	}
+	switch m.Priority {
+	case 1, 2:
+	default:
+		return fmt.Errorf("%w: Priority %v is not a Priority", ErrInvalidEnum, m.Priority)
+	}
+	switch m.Resolution {
+	case "", "fixed", "wontfix":
+	default:
+		return fmt.Errorf("%w: Resolution %v is not a TicketResolution", ErrInvalidEnum, m.Resolution)
+	}
+	return nil
+}
+
+func (dao *TicketDAO) validField(field string, value interface{}) error {
+	switch field {
+	case "status":
+		var v models.TicketStatus
+		switch value := value.(type) {
+		case models.TicketStatus:
+			v = value
+		case string:
+			v = models.TicketStatus(value)
+		default:
+			return fmt.Errorf("%w: status %v is not a TicketStatus", ErrInvalidEnum, value)
+		}
+		switch v {
+		case "open", "closed":
+		default:
+			return fmt.Errorf("%w: status %v is not a TicketStatus", ErrInvalidEnum, value)
+		}
+	case "priority":
+		var v models.Priority
+		switch value := value.(type) {
+		case models.Priority:
+			v = value
+		case int:
+			v = models.Priority(value)
+		default:
+			return fmt.Errorf("%w: priority %v is not a Priority", ErrInvalidEnum, value)
+		}
+		switch v {
+		case 1, 2:
+		default:
+			return fmt.Errorf("%w: priority %v is not a Priority", ErrInvalidEnum, value)
+		}
+	case "resolution":
+		var v models.TicketResolution
+		switch value := value.(type) {
+		case models.TicketResolution:
+			v = value
+		case string:
+			v = models.TicketResolution(value)
+		default:
+			return fmt.Errorf("%w: resolution %v is not a TicketResolution", ErrInvalidEnum, value)
+		}
+		switch v {
+		case "", "fixed", "wontfix":
+		default:
+			return fmt.Errorf("%w: resolution %v is not a TicketResolution", ErrInvalidEnum, value)
+		}
+	}
+	return nil
+}
+
+func (dao *TicketDAO) Create(ctx context.Context, m *Ticket) error {
+	if err := dao.Valid(m); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO `tickets` (`id`, `title`, `status`, `priority`, `resolution`) " +
+		"VALUES (?, ?, ?, ?, ?)"
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Title,
+		m.Status,
+		m.Priority,
+		nullIfZero(m.Resolution),
+	)
+
+	return err
+}
+
+func (dao *TicketDAO) Update(ctx context.Context, m *Ticket) error {
+	if err := dao.Valid(m); err != nil {
+		return err
+	}
+
+	query := "UPDATE `tickets` " +
+		"SET `title` = ?, `status` = ?, `priority` = ?, `resolution` = ? " +
+		"WHERE `id` = ?"
+
+	_, err := dao.execContext(ctx, query,
+		m.Title,
+		m.Status,
+		m.Priority,
+		nullIfZero(m.Resolution),
+		m.ID,
+	)
+	return err
+}
+
+func (dao *TicketDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "status", "priority", "resolution":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		if err := dao.validField(field, value); err != nil {
+			return err
+		}
+		switch field {
+		case "resolution":
+			value = nullIfZero(value)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("`%s` = ?", field))
+		args = append(args, value)
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf("UPDATE `tickets` SET %s WHERE `id` = ?", strings.Join(setClauses, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *TicketDAO) DeleteByPk(ctx context.Context, pk int) error {
+	query := "DELETE FROM `tickets` WHERE `id` = ?"
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *TicketDAO) FindByPk(ctx context.Context, pk int) (*Ticket, error) {
+	query := "SELECT `id`, `title`, `status`, `priority`, `resolution` " +
+		"FROM `tickets` " +
+		"WHERE `id` = ?"
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Ticket
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Status,
+		&m.Priority,
+		nullableValue(&m.Resolution),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *TicketDAO) CreateMany(ctx context.Context, models []*Ticket) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	for _, model := range models {
+		if err := dao.Valid(model); err != nil {
+			return err
+		}
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*5)
+
+	for i, model := range models {
+		placeholders[i] = "(?,?,?,?,?)"
+
+		args = append(args,
+			model.ID,
+			model.Title,
+			model.Status,
+			model.Priority,
+			nullIfZero(model.Resolution),
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO `tickets` (`id`, `title`, `status`, `priority`, `resolution`) "+
+		"VALUES %s", strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *TicketDAO) UpdateMany(ctx context.Context, models []*Ticket) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	for _, model := range models {
+		if err := dao.Valid(model); err != nil {
+			return err
+		}
+	}
+
+	query := "UPDATE `tickets` " +
+		"SET `title` = ?, `status` = ?, `priority` = ?, `resolution` = ? " +
+		"WHERE `id` = ?"
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Title,
+			model.Status,
+			model.Priority,
+			nullIfZero(model.Resolution),
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *TicketDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf("DELETE FROM `tickets` WHERE `id` IN (%s)", placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *TicketDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := "DELETE FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *TicketDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "status", "priority", "resolution":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		if err := dao.validField(field, value); err != nil {
+			return 0, err
+		}
+		switch field {
+		case "resolution":
+			value = nullIfZero(value)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "`"+field+"` = ?")
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf("UPDATE `tickets` SET %s", strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *TicketDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Ticket, error) {
+	query := "SELECT `id`, `title`, `status`, `priority`, `resolution` " +
+		"FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Ticket
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Status,
+		&m.Priority,
+		nullableValue(&m.Resolution),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *TicketDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Ticket, error) {
+	query := "SELECT `id`, `title`, `status`, `priority`, `resolution` " +
+		"FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Ticket
+	for rows.Next() {
+		var m Ticket
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Status,
+			&m.Priority,
+			nullableValue(&m.Resolution),
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TicketDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Ticket, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "title", "status", "priority", "resolution":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "`" + column + "`"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM `tickets`", strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Ticket
+	for rows.Next() {
+		var m Ticket
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "title":
+				dest[i] = &m.Title
+			case "status":
+				dest[i] = &m.Status
+			case "priority":
+				dest[i] = &m.Priority
+			case "resolution":
+				dest[i] = nullableValue(&m.Resolution)
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TicketDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Ticket, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `id`, `title`, `status`, `priority`, `resolution` FROM `tickets` WHERE `id` = ?"
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Ticket
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Status,
+		&m.Priority,
+		nullableValue(&m.Resolution),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *TicketDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Ticket, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := "SELECT `id`, `title`, `status`, `priority`, `resolution` FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Ticket
+	for rows.Next() {
+		var m Ticket
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Status,
+			&m.Priority,
+			nullableValue(&m.Resolution),
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TicketDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Ticket, error) {
+	query := "SELECT `id`, `title`, `status`, `priority`, `resolution` " +
+		"FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Ticket
+	for rows.Next() {
+		var m Ticket
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Status,
+			&m.Priority,
+			nullableValue(&m.Resolution),
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *TicketDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := "SELECT COUNT(*) FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *TicketDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := "SELECT 1 FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *TicketDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := "SELECT 1 FROM `tickets` WHERE `id` = ?"
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *TicketDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "title", "status", "priority", "resolution":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT `%s`) FROM `tickets`", column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *TicketDAO) GroupByStatus(ctx context.Context, where string, args ...interface{}) ([]struct {
+	Key   models.TicketStatus
+	Count int64
+}, error) {
+	query := "SELECT `status`, COUNT(*) FROM `tickets`"
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " GROUP BY `status` ORDER BY `status`"
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []struct {
+		Key   models.TicketStatus
+		Count int64
+	}
+	for rows.Next() {
+		var m Ticket
+		var count int64
+		if err := rows.Scan(&m.Status, &count); err != nil {
+			return nil, err
+		}
+		groups = append(groups, struct {
+			Key   models.TicketStatus
+			Count int64
+		}{m.Status, count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (dao *TicketDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,678 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Article = models.Article
+
+type ArticleDAO struct {
+	db *sql.DB
+}
+
+func NewArticleDAO(db *sql.DB) *ArticleDAO {
+	return &ArticleDAO{db: db}
+}
+
+func (dao *ArticleDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *ArticleDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *ArticleDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *ArticleDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *ArticleDAO) Create(ctx context.Context, m *Article) error {
+	query := `
+		INSERT INTO "articles" ("id", "title", "content", "version")
+		VALUES (:1, :2, :3, :4)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Title,
+		m.Content,
+		m.Version,
+	)
+
+	return err
+}
+
+func (dao *ArticleDAO) Update(ctx context.Context, m *Article) error {
+	query := `
+		UPDATE "articles"
+		SET "title" = :1,
+			"content" = :2,
+			"version" = "version" + 1
+		WHERE "id" = :3 AND "version" = :4
+	`
+
+	result, err := dao.execContext(ctx, query,
+		m.Title,
+		m.Content,
+		m.ID,
+		m.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+
+	m.Version++
+	return nil
+}
+
+func (dao *ArticleDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	version, ok := fields["version"]
+	if !ok {
+		return fmt.Errorf("fields must contain the current version")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+2)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "content", "version":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		if field == "version" {
+			continue
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)))
+	}
+	setClauses = append(setClauses, "\"version\" = \"version\" + 1")
+
+	args = append(args, pk)
+	whereClause := "\"id\" = " + fmt.Sprintf(":%d", len(args))
+	args = append(args, version)
+	whereClause += " AND \"version\" = " + fmt.Sprintf(":%d", len(args))
+
+	query := fmt.Sprintf(`UPDATE "articles" SET %s WHERE %s`, strings.Join(setClauses, ", "), whereClause)
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+
+	return nil
+}
+
+func (dao *ArticleDAO) DeleteByPk(ctx context.Context, pk int) error {
+	query := `DELETE FROM "articles" WHERE "id" = :1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *ArticleDAO) FindByPk(ctx context.Context, pk int) (*Article, error) {
+	query := `
+		SELECT "id", "title", "content", "version"
+		FROM "articles"
+		WHERE "id" = :1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Article
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Content,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ArticleDAO) CreateMany(ctx context.Context, models []*Article) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(:%d, :%d, :%d, :%d)",
+			i*4+1, i*4+2, i*4+3, i*4+4)
+
+		args = append(args,
+			model.ID,
+			model.Title,
+			model.Content,
+			model.Version,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "articles" ("id", "title", "content", "version")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ArticleDAO) UpdateMany(ctx context.Context, models []*Article) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "articles"
+		SET "title" = :1,
+			"content" = :2,
+			"version" = "version" + 1
+		WHERE "id" = :3 AND "version" = :4
+	`
+
+	for _, model := range models {
+		result, err := dao.execContext(ctx, query,
+			model.Title,
+			model.Content,
+			model.ID,
+			model.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrStaleObject
+		}
+
+		model.Version++
+	}
+
+	return nil
+}
+
+func (dao *ArticleDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "articles" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ArticleDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "articles"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ArticleDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	if _, ok := fields["version"]; ok {
+		return 0, fmt.Errorf("fields must not contain the version")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "content", "version":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	setClauses = append(setClauses, "\"version\" = \"version\" + 1")
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "articles" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ArticleDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Article, error) {
+	query := `
+		SELECT "id", "title", "content", "version"
+		FROM "articles"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Article
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Content,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ArticleDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Article, error) {
+	query := `
+		SELECT "id", "title", "content", "version"
+		FROM "articles"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Article
+	for rows.Next() {
+		var m Article
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Content,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ArticleDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Article, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "title", "content", "version":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "articles"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Article
+	for rows.Next() {
+		var m Article
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "title":
+				dest[i] = &m.Title
+			case "content":
+				dest[i] = &m.Content
+			case "version":
+				dest[i] = &m.Version
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ArticleDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Article, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "title", "content", "version" FROM "articles" WHERE "id" = :1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Article
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Content,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ArticleDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Article, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "title", "content", "version" FROM "articles"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Article
+	for rows.Next() {
+		var m Article
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Content,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ArticleDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Article, error) {
+	baseQuery := `
+		SELECT "id", "title", "content", "version"
+		FROM "articles"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Article
+	for rows.Next() {
+		var m Article
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Content,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ArticleDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "articles"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ArticleDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "articles"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ArticleDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := `SELECT 1 FROM "articles" WHERE "id" = :1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ArticleDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "title", "content", "version":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "articles"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ArticleDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
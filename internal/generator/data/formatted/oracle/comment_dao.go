@@ -0,0 +1,843 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Comment = models.Comment
+
+// CommentWithPost is a row of Comment joined with its Post.
+type CommentWithPost = struct {
+	Comment Comment
+	Post    *Post
+}
+
+type CommentDAO struct {
+	db *sql.DB
+}
+
+func NewCommentDAO(db *sql.DB) *CommentDAO {
+	return &CommentDAO{db: db}
+}
+
+func (dao *CommentDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *CommentDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *CommentDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *CommentDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *CommentDAO) Create(ctx context.Context, m *Comment) error {
+	query := `
+		INSERT INTO "comments" ("id", "post_id", "body")
+		VALUES (:1, :2, :3)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.PostID,
+		m.Body,
+	)
+
+	return err
+}
+
+func (dao *CommentDAO) Update(ctx context.Context, m *Comment) error {
+	query := `
+		UPDATE "comments"
+		SET "post_id" = :1,
+			"body" = :2
+		WHERE "id" = :3
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.PostID,
+		m.Body,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *CommentDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "post_id", "body":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = :%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "comments" SET %s WHERE "id" = :%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *CommentDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := `DELETE FROM "comments" WHERE "id" = :1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *CommentDAO) FindByPk(ctx context.Context, pk int64) (*Comment, error) {
+	query := `
+		SELECT "id", "post_id", "body"
+		FROM "comments"
+		WHERE "id" = :1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Comment
+	err := row.Scan(
+		&m.ID,
+		&m.PostID,
+		&m.Body,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *CommentDAO) CreateMany(ctx context.Context, models []*Comment) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*3)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(:%d, :%d, :%d)",
+			i*3+1, i*3+2, i*3+3)
+
+		args = append(args,
+			model.ID,
+			model.PostID,
+			model.Body,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "comments" ("id", "post_id", "body")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *CommentDAO) UpdateMany(ctx context.Context, models []*Comment) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "comments"
+		SET "post_id" = :1,
+			"body" = :2
+		WHERE "id" = :3
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.PostID,
+			model.Body,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *CommentDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "comments" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *CommentDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *CommentDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "post_id", "body":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "comments" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *CommentDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Comment, error) {
+	query := `
+		SELECT "id", "post_id", "body"
+		FROM "comments"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Comment
+	err := row.Scan(
+		&m.ID,
+		&m.PostID,
+		&m.Body,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *CommentDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Comment, error) {
+	query := `
+		SELECT "id", "post_id", "body"
+		FROM "comments"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Comment
+	for rows.Next() {
+		var m Comment
+		err := rows.Scan(
+			&m.ID,
+			&m.PostID,
+			&m.Body,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *CommentDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Comment, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "post_id", "body":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "comments"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Comment
+	for rows.Next() {
+		var m Comment
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "post_id":
+				dest[i] = &m.PostID
+			case "body":
+				dest[i] = &m.Body
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *CommentDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Comment, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "post_id", "body" FROM "comments" WHERE "id" = :1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Comment
+	err := row.Scan(
+		&m.ID,
+		&m.PostID,
+		&m.Body,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *CommentDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Comment, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "post_id", "body" FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Comment
+	for rows.Next() {
+		var m Comment
+		err := rows.Scan(
+			&m.ID,
+			&m.PostID,
+			&m.Body,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *CommentDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Comment, error) {
+	baseQuery := `
+		SELECT "id", "post_id", "body"
+		FROM "comments"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Comment
+	for rows.Next() {
+		var m Comment
+		err := rows.Scan(
+			&m.ID,
+			&m.PostID,
+			&m.Body,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *CommentDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *CommentDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *CommentDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := `SELECT 1 FROM "comments" WHERE "id" = :1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *CommentDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "post_id", "body":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "comments"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *CommentDAO) SumPostID(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("post_id") FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *CommentDAO) AvgPostID(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("post_id") FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *CommentDAO) MinPostID(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN("post_id") FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *CommentDAO) MaxPostID(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX("post_id") FROM "comments"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *CommentDAO) PreloadPost(ctx context.Context, models []*Comment) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	seen := make(map[int]bool, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		if seen[model.PostID] {
+			continue
+		}
+		seen[model.PostID] = true
+		keys = append(keys, model.PostID)
+	}
+
+	relatedDAO := &PostDAO{db: dao.db}
+	var related []*Post
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	byKey := make(map[int]*Post, len(related))
+	for _, r := range related {
+		byKey[r.ID] = r
+	}
+	for _, model := range models {
+		model.Post = byKey[model.PostID]
+	}
+
+	return nil
+}
+
+func (dao *CommentDAO) FindAllWithPost(ctx context.Context, where string, sort string, args ...interface{}) ([]*Comment, error) {
+	models, err := dao.FindAll(ctx, where, sort, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.PreloadPost(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *CommentDAO) JoinPost(ctx context.Context, where string, sort string, args ...interface{}) ([]*CommentWithPost, error) {
+	query := `
+		SELECT "comments"."id" AS "comments__id", "comments"."post_id" AS "comments__post_id", "comments"."body" AS "comments__body", "post"."id" AS "post__id", "post"."title" AS "post__title", "post"."body" AS "post__body", "post"."deleted_at" AS "post__deleted_at"
+		FROM "comments" "comments"
+		INNER JOIN "posts" "post" ON "post"."id" = "comments"."post_id" AND "post"."deleted_at" IS NULL
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*CommentWithPost
+	for rows.Next() {
+		var m Comment
+		var related Post
+		err := rows.Scan(
+			&m.ID,
+			&m.PostID,
+			&m.Body,
+			&related.ID,
+			&related.Title,
+			&related.Body,
+			&related.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &CommentWithPost{Comment: m}
+		result.Post = &related
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *CommentDAO) LeftJoinPost(ctx context.Context, where string, sort string, args ...interface{}) ([]*CommentWithPost, error) {
+	query := `
+		SELECT "comments"."id" AS "comments__id", "comments"."post_id" AS "comments__post_id", "comments"."body" AS "comments__body", "post"."id" AS "post__id", "post"."title" AS "post__title", "post"."body" AS "post__body", "post"."deleted_at" AS "post__deleted_at"
+		FROM "comments" "comments"
+		LEFT JOIN "posts" "post" ON "post"."id" = "comments"."post_id" AND "post"."deleted_at" IS NULL
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*CommentWithPost
+	for rows.Next() {
+		var m Comment
+		var related Post
+		var found bool
+		err := rows.Scan(
+			&m.ID,
+			&m.PostID,
+			&m.Body,
+			joinedColumn(&found, nullableValue(&related.ID)),
+			joinedColumn(&found, nullableValue(&related.Title)),
+			joinedColumn(&found, nullableValue(&related.Body)),
+			joinedColumn(&found, nullableValue(&related.DeletedAt)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &CommentWithPost{Comment: m}
+		if found {
+			result.Post = &related
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *CommentDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,631 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Document = models.Document
+
+type DocumentDAO struct {
+	db *sql.DB
+}
+
+func NewDocumentDAO(db *sql.DB) *DocumentDAO {
+	return &DocumentDAO{db: db}
+}
+
+func (dao *DocumentDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *DocumentDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *DocumentDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *DocumentDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *DocumentDAO) Create(ctx context.Context, m *Document) error {
+	query := `
+		INSERT INTO "documents" ("id", "title", "labels", "scores")
+		VALUES (:1, :2, :3, :4)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Title,
+		m.Labels,
+		m.Scores,
+	)
+
+	return err
+}
+
+func (dao *DocumentDAO) Update(ctx context.Context, m *Document) error {
+	query := `
+		UPDATE "documents"
+		SET "title" = :1,
+			"labels" = :2,
+			"scores" = :3
+		WHERE "id" = :4
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.Title,
+		m.Labels,
+		m.Scores,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *DocumentDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "labels", "scores":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = :%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "documents" SET %s WHERE "id" = :%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *DocumentDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := `DELETE FROM "documents" WHERE "id" = :1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *DocumentDAO) FindByPk(ctx context.Context, pk int64) (*Document, error) {
+	query := `
+		SELECT "id", "title", "labels", "scores"
+		FROM "documents"
+		WHERE "id" = :1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Document
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Labels,
+		&m.Scores,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *DocumentDAO) CreateMany(ctx context.Context, models []*Document) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(:%d, :%d, :%d, :%d)",
+			i*4+1, i*4+2, i*4+3, i*4+4)
+
+		args = append(args,
+			model.ID,
+			model.Title,
+			model.Labels,
+			model.Scores,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "documents" ("id", "title", "labels", "scores")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *DocumentDAO) UpdateMany(ctx context.Context, models []*Document) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "documents"
+		SET "title" = :1,
+			"labels" = :2,
+			"scores" = :3
+		WHERE "id" = :4
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Title,
+			model.Labels,
+			model.Scores,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *DocumentDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "documents" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *DocumentDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "documents"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *DocumentDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "labels", "scores":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "documents" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *DocumentDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Document, error) {
+	query := `
+		SELECT "id", "title", "labels", "scores"
+		FROM "documents"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Document
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Labels,
+		&m.Scores,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *DocumentDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Document, error) {
+	query := `
+		SELECT "id", "title", "labels", "scores"
+		FROM "documents"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Document
+	for rows.Next() {
+		var m Document
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Labels,
+			&m.Scores,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *DocumentDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Document, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "title", "labels", "scores":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "documents"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Document
+	for rows.Next() {
+		var m Document
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "title":
+				dest[i] = &m.Title
+			case "labels":
+				dest[i] = &m.Labels
+			case "scores":
+				dest[i] = &m.Scores
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *DocumentDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Document, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "title", "labels", "scores" FROM "documents" WHERE "id" = :1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Document
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Labels,
+		&m.Scores,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *DocumentDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Document, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "title", "labels", "scores" FROM "documents"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Document
+	for rows.Next() {
+		var m Document
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Labels,
+			&m.Scores,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *DocumentDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Document, error) {
+	baseQuery := `
+		SELECT "id", "title", "labels", "scores"
+		FROM "documents"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Document
+	for rows.Next() {
+		var m Document
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Labels,
+			&m.Scores,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *DocumentDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "documents"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *DocumentDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "documents"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *DocumentDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := `SELECT 1 FROM "documents" WHERE "id" = :1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *DocumentDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "title", "labels", "scores":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "documents"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *DocumentDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
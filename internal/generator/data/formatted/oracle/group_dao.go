@@ -0,0 +1,784 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Group = models.Group
+
+type GroupDAO struct {
+	db *sql.DB
+}
+
+func NewGroupDAO(db *sql.DB) *GroupDAO {
+	return &GroupDAO{db: db}
+}
+
+func (dao *GroupDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *GroupDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *GroupDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *GroupDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *GroupDAO) Create(ctx context.Context, m *Group) error {
+	query := `
+		INSERT INTO "groups" ("id", "name")
+		VALUES (:1, :2)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Name,
+	)
+
+	return err
+}
+
+func (dao *GroupDAO) Update(ctx context.Context, m *Group) error {
+	query := `
+		UPDATE "groups"
+		SET "name" = :1
+		WHERE "id" = :2
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.Name,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *GroupDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "name":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = :%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "groups" SET %s WHERE "id" = :%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *GroupDAO) DeleteByPk(ctx context.Context, pk int) error {
+	query := `DELETE FROM "groups" WHERE "id" = :1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *GroupDAO) FindByPk(ctx context.Context, pk int) (*Group, error) {
+	query := `
+		SELECT "id", "name"
+		FROM "groups"
+		WHERE "id" = :1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Group
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *GroupDAO) CreateMany(ctx context.Context, models []*Group) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*2)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(:%d, :%d)",
+			i*2+1, i*2+2)
+
+		args = append(args,
+			model.ID,
+			model.Name,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "groups" ("id", "name")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *GroupDAO) UpdateMany(ctx context.Context, models []*Group) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "groups"
+		SET "name" = :1
+		WHERE "id" = :2
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Name,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *GroupDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "groups" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *GroupDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "groups"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *GroupDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "name":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "groups" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *GroupDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Group, error) {
+	query := `
+		SELECT "id", "name"
+		FROM "groups"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Group
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *GroupDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Group, error) {
+	query := `
+		SELECT "id", "name"
+		FROM "groups"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Group
+	for rows.Next() {
+		var m Group
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *GroupDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Group, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "name":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "groups"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Group
+	for rows.Next() {
+		var m Group
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "name":
+				dest[i] = &m.Name
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *GroupDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Group, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name" FROM "groups" WHERE "id" = :1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Group
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *GroupDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Group, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name" FROM "groups"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Group
+	for rows.Next() {
+		var m Group
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *GroupDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Group, error) {
+	baseQuery := `
+		SELECT "id", "name"
+		FROM "groups"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Group
+	for rows.Next() {
+		var m Group
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *GroupDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "groups"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *GroupDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "groups"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *GroupDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := `SELECT 1 FROM "groups" WHERE "id" = :1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *GroupDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "name":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "groups"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *GroupDAO) AttachRoles(ctx context.Context, pk int, relatedPks ...int64) error {
+	if len(relatedPks) == 0 {
+		return nil
+	}
+
+	if len(relatedPks) > inListBatchSize/2 && dao.getTx(ctx) == nil {
+		return dao.WithTransaction(ctx, func(ctx context.Context) error {
+			return dao.AttachRoles(ctx, pk, relatedPks...)
+		})
+	}
+
+	for start := 0; start < len(relatedPks); start += inListBatchSize / 2 {
+		end := start + inListBatchSize/2
+		if end > len(relatedPks) {
+			end = len(relatedPks)
+		}
+		batch := relatedPks[start:end]
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)*2)
+		for i, relatedPk := range batch {
+			placeholders[i] = fmt.Sprintf(`INTO "group_roles" ("group_id", "role_id") VALUES (:%d, :%d)`, i*2+1, i*2+2)
+			args = append(args, pk, relatedPk)
+		}
+		query := fmt.Sprintf(`INSERT ALL %s SELECT 1 FROM DUAL`, strings.Join(placeholders, " "))
+		if _, err := dao.execContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *GroupDAO) DetachRoles(ctx context.Context, pk int, relatedPks ...int64) error {
+	if len(relatedPks) == 0 {
+		return nil
+	}
+
+	if len(relatedPks) > inListBatchSize && dao.getTx(ctx) == nil {
+		return dao.WithTransaction(ctx, func(ctx context.Context) error {
+			return dao.DetachRoles(ctx, pk, relatedPks...)
+		})
+	}
+
+	keys := make([]interface{}, len(relatedPks))
+	for i, relatedPk := range relatedPks {
+		keys[i] = relatedPk
+	}
+
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+2)
+		}
+		query := fmt.Sprintf(`DELETE FROM "group_roles" WHERE "group_id" = :1 AND "role_id" IN (%s)`, strings.Join(placeholders, ", "))
+		args := append([]interface{}{pk}, batch...)
+		if _, err := dao.execContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *GroupDAO) SyncRoles(ctx context.Context, pk int, relatedPks []int64) error {
+	if dao.getTx(ctx) == nil {
+		return dao.WithTransaction(ctx, func(ctx context.Context) error {
+			return dao.SyncRoles(ctx, pk, relatedPks)
+		})
+	}
+
+	query := `DELETE FROM "group_roles" WHERE "group_id" = :1`
+	if _, err := dao.execContext(ctx, query, pk); err != nil {
+		return err
+	}
+
+	return dao.AttachRoles(ctx, pk, relatedPks...)
+}
+
+func (dao *GroupDAO) LoadRoles(ctx context.Context, models []*Group) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	byKey := make(map[int]*Group, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		model.Roles = nil
+		if _, ok := byKey[model.ID]; ok {
+			continue
+		}
+		byKey[model.ID] = model
+		keys = append(keys, model.ID)
+	}
+
+	links := make(map[int][]int64, len(models))
+	seen := make(map[int64]bool)
+	var relatedKeys []interface{}
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		query := fmt.Sprintf(`SELECT "group_id", "role_id" FROM "group_roles" WHERE "group_id" IN (%s) ORDER BY "role_id"`, strings.Join(placeholders, ", "))
+		rows, err := dao.queryContext(ctx, query, batch...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var key int
+			var relatedKey int64
+			if err := rows.Scan(&key, &relatedKey); err != nil {
+				rows.Close()
+				return err
+			}
+			links[key] = append(links[key], relatedKey)
+			if seen[relatedKey] {
+				continue
+			}
+			seen[relatedKey] = true
+			relatedKeys = append(relatedKeys, relatedKey)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(relatedKeys) == 0 {
+		return nil
+	}
+
+	relatedDAO := &RoleDAO{db: dao.db}
+	var related []*Role
+	for start := 0; start < len(relatedKeys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(relatedKeys) {
+			end = len(relatedKeys)
+		}
+		batch := relatedKeys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	relatedByKey := make(map[int64]*Role, len(related))
+	for _, r := range related {
+		relatedByKey[r.ID] = r
+	}
+	for key, relatedKeys := range links {
+		model, ok := byKey[key]
+		if !ok {
+			continue
+		}
+		for _, relatedKey := range relatedKeys {
+			if r, ok := relatedByKey[relatedKey]; ok {
+				model.Roles = append(model.Roles, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (dao *GroupDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,1286 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type InvoiceLine = models.InvoiceLine
+
+// InvoiceLineWithInvoice is a row of InvoiceLine joined with its Invoice.
+type InvoiceLineWithInvoice = struct {
+	InvoiceLine InvoiceLine
+	Invoice     *Invoice
+}
+
+type InvoiceLineDAO struct {
+	db *sql.DB
+}
+
+func NewInvoiceLineDAO(db *sql.DB) *InvoiceLineDAO {
+	return &InvoiceLineDAO{db: db}
+}
+
+func (dao *InvoiceLineDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *InvoiceLineDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *InvoiceLineDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *InvoiceLineDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *InvoiceLineDAO) tenantID(ctx context.Context) (int32, bool) {
+	var tenantID int32
+	ok := tenantValue(ctx, &tenantID)
+	return tenantID, ok
+}
+
+func (dao *InvoiceLineDAO) scopeWhere(ctx context.Context, where string, args []interface{}) (string, []interface{}, bool) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return "", nil, false
+	}
+
+	args = append(args, tenantID)
+	condition := fmt.Sprintf("\"tenant_id\" = :%d", len(args))
+	if where != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+
+	return condition, args, true
+}
+
+func (dao *InvoiceLineDAO) Create(ctx context.Context, m *InvoiceLine) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	m.TenantID = tenantID
+
+	query := `
+		INSERT INTO "billing"."invoice_lines" ("id", "tenant_id", "invoice_id", "description")
+		VALUES (:1, :2, :3, :4)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.TenantID,
+		m.InvoiceID,
+		m.Description,
+	)
+
+	return err
+}
+
+func (dao *InvoiceLineDAO) Update(ctx context.Context, m *InvoiceLine) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := `
+		UPDATE "billing"."invoice_lines"
+		SET "invoice_id" = :1,
+			"description" = :2
+		WHERE "id" = :3 AND "tenant_id" = :4
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.InvoiceID,
+		m.Description,
+		m.ID,
+		tenantID,
+	)
+	return err
+}
+
+func (dao *InvoiceLineDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+2)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "invoice_id", "description":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)))
+	}
+
+	args = append(args, pk)
+	whereClause := "\"id\" = " + fmt.Sprintf(":%d", len(args))
+	args = append(args, tenantID)
+	whereClause += " AND \"tenant_id\" = " + fmt.Sprintf(":%d", len(args))
+
+	query := fmt.Sprintf(`UPDATE "billing"."invoice_lines" SET %s WHERE %s`, strings.Join(setClauses, ", "), whereClause)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *InvoiceLineDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := `DELETE FROM "billing"."invoice_lines" WHERE "id" = :1 AND "tenant_id" = :2`
+	_, err := dao.execContext(ctx, query, pk, tenantID)
+	return err
+}
+
+func (dao *InvoiceLineDAO) FindByPk(ctx context.Context, pk int64) (*InvoiceLine, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `
+		SELECT "id", "tenant_id", "invoice_id", "description"
+		FROM "billing"."invoice_lines"
+		WHERE "id" = :1 AND "tenant_id" = :2
+	`
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m InvoiceLine
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.InvoiceID,
+		&m.Description,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceLineDAO) CreateMany(ctx context.Context, models []*InvoiceLine) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		model.TenantID = tenantID
+		placeholders[i] = fmt.Sprintf("(:%d, :%d, :%d, :%d)",
+			i*4+1, i*4+2, i*4+3, i*4+4)
+
+		args = append(args,
+			model.ID,
+			model.TenantID,
+			model.InvoiceID,
+			model.Description,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "billing"."invoice_lines" ("id", "tenant_id", "invoice_id", "description")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *InvoiceLineDAO) UpdateMany(ctx context.Context, models []*InvoiceLine) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "billing"."invoice_lines"
+		SET "invoice_id" = :1,
+			"description" = :2
+		WHERE "id" = :3 AND "tenant_id" = :4
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.InvoiceID,
+			model.Description,
+			model.ID,
+			tenantID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *InvoiceLineDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	args = append(args, tenantID)
+	query := fmt.Sprintf(`DELETE FROM "billing"."invoice_lines" WHERE "id" IN (%s) AND "tenant_id" = :%d`, strings.Join(placeholders, ","), len(args))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *InvoiceLineDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `DELETE FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *InvoiceLineDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return 0, fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "invoice_id", "description":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "billing"."invoice_lines" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *InvoiceLineDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*InvoiceLine, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `
+		SELECT "id", "tenant_id", "invoice_id", "description"
+		FROM "billing"."invoice_lines"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m InvoiceLine
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.InvoiceID,
+		&m.Description,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceLineDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*InvoiceLine, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `
+		SELECT "id", "tenant_id", "invoice_id", "description"
+		FROM "billing"."invoice_lines"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*InvoiceLine
+	for rows.Next() {
+		var m InvoiceLine
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.InvoiceID,
+			&m.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceLineDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*InvoiceLine, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "tenant_id", "invoice_id", "description":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "billing"."invoice_lines"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*InvoiceLine
+	for rows.Next() {
+		var m InvoiceLine
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "tenant_id":
+				dest[i] = &m.TenantID
+			case "invoice_id":
+				dest[i] = &m.InvoiceID
+			case "description":
+				dest[i] = &m.Description
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceLineDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*InvoiceLine, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `SELECT "id", "tenant_id", "invoice_id", "description" FROM "billing"."invoice_lines" WHERE "id" = :1 AND "tenant_id" = :2`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m InvoiceLine
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.InvoiceID,
+		&m.Description,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *InvoiceLineDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*InvoiceLine, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `SELECT "id", "tenant_id", "invoice_id", "description" FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*InvoiceLine
+	for rows.Next() {
+		var m InvoiceLine
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.InvoiceID,
+			&m.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceLineDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*InvoiceLine, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	baseQuery := `
+		SELECT "id", "tenant_id", "invoice_id", "description"
+		FROM "billing"."invoice_lines"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*InvoiceLine
+	for rows.Next() {
+		var m InvoiceLine
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.InvoiceID,
+			&m.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceLineDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `SELECT COUNT(*) FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *InvoiceLineDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := `SELECT 1 FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *InvoiceLineDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := `SELECT 1 FROM "billing"."invoice_lines" WHERE "id" = :1 AND "tenant_id" = :2`
+	args := []interface{}{pk, tenantID}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *InvoiceLineDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "tenant_id", "invoice_id", "description":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "billing"."invoice_lines"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *InvoiceLineDAO) SumInvoiceID(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `SELECT SUM("invoice_id") FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *InvoiceLineDAO) AvgInvoiceID(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := `SELECT AVG("invoice_id") FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *InvoiceLineDAO) MinInvoiceID(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := `SELECT MIN("invoice_id") FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *InvoiceLineDAO) MaxInvoiceID(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, false, ErrMissingTenant
+	}
+
+	query := `SELECT MAX("invoice_id") FROM "billing"."invoice_lines"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *InvoiceLineDAO) PreloadInvoice(ctx context.Context, models []*InvoiceLine) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	seen := make(map[int]bool, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		if seen[model.InvoiceID] {
+			continue
+		}
+		seen[model.InvoiceID] = true
+		keys = append(keys, model.InvoiceID)
+	}
+
+	relatedDAO := &InvoiceDAO{db: dao.db}
+	var related []*Invoice
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	byKey := make(map[int]*Invoice, len(related))
+	for _, r := range related {
+		byKey[r.ID] = r
+	}
+	for _, model := range models {
+		model.Invoice = byKey[model.InvoiceID]
+	}
+
+	return nil
+}
+
+func (dao *InvoiceLineDAO) FindAllWithInvoice(ctx context.Context, where string, sort string, args ...interface{}) ([]*InvoiceLine, error) {
+	models, err := dao.FindAll(ctx, where, sort, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.PreloadInvoice(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *InvoiceLineDAO) JoinInvoice(ctx context.Context, where string, sort string, args ...interface{}) ([]*InvoiceLineWithInvoice, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	args = append(args, tenantID)
+	condition := fmt.Sprintf("\"invoice_lines\".\"tenant_id\" = :%d", len(args))
+	if where != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+	where = condition
+
+	query := `
+		SELECT "invoice_lines"."id" AS "invoice_lines__id", "invoice_lines"."tenant_id" AS "invoice_lines__tenant_id", "invoice_lines"."invoice_id" AS "invoice_lines__invoice_id", "invoice_lines"."description" AS "invoice_lines__description", "invoice"."id" AS "invoice__id", "invoice"."tenant_id" AS "invoice__tenant_id", "invoice"."number" AS "invoice__number", "invoice"."amount" AS "invoice__amount"
+		FROM "billing"."invoice_lines" "invoice_lines"
+		INNER JOIN "billing"."invoices" "invoice" ON "invoice"."id" = "invoice_lines"."invoice_id" AND "invoice"."tenant_id" = "invoice_lines"."tenant_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*InvoiceLineWithInvoice
+	for rows.Next() {
+		var m InvoiceLine
+		var related Invoice
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.InvoiceID,
+			&m.Description,
+			&related.ID,
+			&related.TenantID,
+			&related.Number,
+			&related.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &InvoiceLineWithInvoice{InvoiceLine: m}
+		result.Invoice = &related
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *InvoiceLineDAO) LeftJoinInvoice(ctx context.Context, where string, sort string, args ...interface{}) ([]*InvoiceLineWithInvoice, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	args = append(args, tenantID)
+	condition := fmt.Sprintf("\"invoice_lines\".\"tenant_id\" = :%d", len(args))
+	if where != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+	where = condition
+
+	query := `
+		SELECT "invoice_lines"."id" AS "invoice_lines__id", "invoice_lines"."tenant_id" AS "invoice_lines__tenant_id", "invoice_lines"."invoice_id" AS "invoice_lines__invoice_id", "invoice_lines"."description" AS "invoice_lines__description", "invoice"."id" AS "invoice__id", "invoice"."tenant_id" AS "invoice__tenant_id", "invoice"."number" AS "invoice__number", "invoice"."amount" AS "invoice__amount"
+		FROM "billing"."invoice_lines" "invoice_lines"
+		LEFT JOIN "billing"."invoices" "invoice" ON "invoice"."id" = "invoice_lines"."invoice_id" AND "invoice"."tenant_id" = "invoice_lines"."tenant_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*InvoiceLineWithInvoice
+	for rows.Next() {
+		var m InvoiceLine
+		var related Invoice
+		var found bool
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.InvoiceID,
+			&m.Description,
+			joinedColumn(&found, nullableValue(&related.ID)),
+			joinedColumn(&found, nullableValue(&related.TenantID)),
+			joinedColumn(&found, nullableValue(&related.Number)),
+			joinedColumn(&found, nullableValue(&related.Amount)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &InvoiceLineWithInvoice{InvoiceLine: m}
+		if found {
+			result.Invoice = &related
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *InvoiceLineDAO) checkTaxesTenant(ctx context.Context, tenantID int32, pk int64, relatedPks []int64) error {
+	var count int64
+	query := `SELECT COUNT(*) FROM "billing"."invoice_lines" WHERE "id" = :1 AND "tenant_id" = :2`
+	if err := dao.queryRowContext(ctx, query, pk, tenantID).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return sql.ErrNoRows
+	}
+
+	seen := make(map[int64]bool, len(relatedPks))
+	keys := make([]interface{}, 0, len(relatedPks))
+	for _, relatedPk := range relatedPks {
+		if seen[relatedPk] {
+			continue
+		}
+		seen[relatedPk] = true
+		keys = append(keys, relatedPk)
+	}
+
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+2)
+		}
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM "billing"."taxes" WHERE "tenant_id" = :1 AND "id" IN (%s)`, strings.Join(placeholders, ", "))
+		var found int64
+		if err := dao.queryRowContext(ctx, query, append([]interface{}{tenantID}, batch...)...).Scan(&found); err != nil {
+			return err
+		}
+		if found != int64(len(batch)) {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
+
+func (dao *InvoiceLineDAO) AttachTaxes(ctx context.Context, pk int64, relatedPks ...int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(relatedPks) == 0 {
+		return nil
+	}
+
+	if len(relatedPks) > inListBatchSize/2 && dao.getTx(ctx) == nil {
+		return dao.WithTransaction(ctx, func(ctx context.Context) error {
+			return dao.AttachTaxes(ctx, pk, relatedPks...)
+		})
+	}
+
+	if err := dao.checkTaxesTenant(ctx, tenantID, pk, relatedPks); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(relatedPks); start += inListBatchSize / 2 {
+		end := start + inListBatchSize/2
+		if end > len(relatedPks) {
+			end = len(relatedPks)
+		}
+		batch := relatedPks[start:end]
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)*2)
+		for i, relatedPk := range batch {
+			placeholders[i] = fmt.Sprintf(`INTO "billing"."invoice_line_taxes" ("invoice_line_id", "tax_id") VALUES (:%d, :%d)`, i*2+1, i*2+2)
+			args = append(args, pk, relatedPk)
+		}
+		query := fmt.Sprintf(`INSERT ALL %s SELECT 1 FROM DUAL`, strings.Join(placeholders, " "))
+		if _, err := dao.execContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *InvoiceLineDAO) DetachTaxes(ctx context.Context, pk int64, relatedPks ...int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(relatedPks) == 0 {
+		return nil
+	}
+
+	if len(relatedPks) > inListBatchSize && dao.getTx(ctx) == nil {
+		return dao.WithTransaction(ctx, func(ctx context.Context) error {
+			return dao.DetachTaxes(ctx, pk, relatedPks...)
+		})
+	}
+
+	if err := dao.checkTaxesTenant(ctx, tenantID, pk, relatedPks); err != nil {
+		return err
+	}
+
+	keys := make([]interface{}, len(relatedPks))
+	for i, relatedPk := range relatedPks {
+		keys[i] = relatedPk
+	}
+
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+2)
+		}
+		query := fmt.Sprintf(`DELETE FROM "billing"."invoice_line_taxes" WHERE "invoice_line_id" = :1 AND "tax_id" IN (%s)`, strings.Join(placeholders, ", "))
+		args := append([]interface{}{pk}, batch...)
+		if _, err := dao.execContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *InvoiceLineDAO) SyncTaxes(ctx context.Context, pk int64, relatedPks []int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if dao.getTx(ctx) == nil {
+		return dao.WithTransaction(ctx, func(ctx context.Context) error {
+			return dao.SyncTaxes(ctx, pk, relatedPks)
+		})
+	}
+
+	if err := dao.checkTaxesTenant(ctx, tenantID, pk, nil); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM "billing"."invoice_line_taxes" WHERE "invoice_line_id" = :1`
+	if _, err := dao.execContext(ctx, query, pk); err != nil {
+		return err
+	}
+
+	return dao.AttachTaxes(ctx, pk, relatedPks...)
+}
+
+func (dao *InvoiceLineDAO) LoadTaxes(ctx context.Context, models []*InvoiceLine) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	byKey := make(map[int64]*InvoiceLine, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		model.Taxes = nil
+		if _, ok := byKey[model.ID]; ok {
+			continue
+		}
+		byKey[model.ID] = model
+		keys = append(keys, model.ID)
+	}
+
+	links := make(map[int64][]int64, len(models))
+	seen := make(map[int64]bool)
+	var relatedKeys []interface{}
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		query := fmt.Sprintf(`SELECT "invoice_line_id", "tax_id" FROM "billing"."invoice_line_taxes" WHERE "invoice_line_id" IN (%s) ORDER BY "tax_id"`, strings.Join(placeholders, ", "))
+		rows, err := dao.queryContext(ctx, query, batch...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var key int64
+			var relatedKey int64
+			if err := rows.Scan(&key, &relatedKey); err != nil {
+				rows.Close()
+				return err
+			}
+			links[key] = append(links[key], relatedKey)
+			if seen[relatedKey] {
+				continue
+			}
+			seen[relatedKey] = true
+			relatedKeys = append(relatedKeys, relatedKey)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(relatedKeys) == 0 {
+		return nil
+	}
+
+	relatedDAO := &TaxDAO{db: dao.db}
+	var related []*Tax
+	for start := 0; start < len(relatedKeys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(relatedKeys) {
+			end = len(relatedKeys)
+		}
+		batch := relatedKeys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	relatedByKey := make(map[int64]*Tax, len(related))
+	for _, r := range related {
+		relatedByKey[r.ID] = r
+	}
+	for key, relatedKeys := range links {
+		model, ok := byKey[key]
+		if !ok {
+			continue
+		}
+		for _, relatedKey := range relatedKeys {
+			if r, ok := relatedByKey[relatedKey]; ok {
+				model.Taxes = append(model.Taxes, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (dao *InvoiceLineDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
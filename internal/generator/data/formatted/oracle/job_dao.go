@@ -0,0 +1,1084 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+	"time"
+)
+
+type Job = models.Job
+
+type JobDAO struct {
+	db *sql.DB
+}
+
+func NewJobDAO(db *sql.DB) *JobDAO {
+	return &JobDAO{db: db}
+}
+
+func (dao *JobDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *JobDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *JobDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *JobDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *JobDAO) tenantID(ctx context.Context) (int64, bool) {
+	var tenantID int64
+	ok := tenantValue(ctx, &tenantID)
+	return tenantID, ok
+}
+
+func (dao *JobDAO) scopeWhere(ctx context.Context, where string, args []interface{}) (string, []interface{}, bool) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return "", nil, false
+	}
+
+	args = append(args, tenantID)
+	condition := fmt.Sprintf("\"tenant_id\" = :%d", len(args))
+	if where != "" {
+		condition = "(" + where + ") AND " + condition
+	}
+
+	return condition, args, true
+}
+
+func (dao *JobDAO) Valid(m *Job) error {
+	switch m.Status {
+	case "pending", "running", "done":
+	default:
+		return fmt.Errorf("%w: Status %v is not a JobStatus", ErrInvalidEnum, m.Status)
+	}
+	return nil
+}
+
+func (dao *JobDAO) validField(field string, value interface{}) error {
+	switch field {
+	case "status":
+		var v models.JobStatus
+		switch value := value.(type) {
+		case models.JobStatus:
+			v = value
+		case string:
+			v = models.JobStatus(value)
+		default:
+			return fmt.Errorf("%w: status %v is not a JobStatus", ErrInvalidEnum, value)
+		}
+		switch v {
+		case "pending", "running", "done":
+		default:
+			return fmt.Errorf("%w: status %v is not a JobStatus", ErrInvalidEnum, value)
+		}
+	}
+	return nil
+}
+
+func (dao *JobDAO) Create(ctx context.Context, m *Job) error {
+	if err := dao.Valid(m); err != nil {
+		return err
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	m.TenantID = tenantID
+
+	query := `
+		INSERT INTO "jobs" ("id", "tenant_id", "payload", "status", "leased_until", "version")
+		VALUES (:1, :2, :3, :4, :5, :6)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.TenantID,
+		m.Payload,
+		m.Status,
+		m.LeasedUntil,
+		m.Version,
+	)
+
+	return err
+}
+
+func (dao *JobDAO) Update(ctx context.Context, m *Job) error {
+	if err := dao.Valid(m); err != nil {
+		return err
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := `
+		UPDATE "jobs"
+		SET "payload" = :1,
+			"status" = :2,
+			"leased_until" = :3,
+			"version" = "version" + 1
+		WHERE "id" = :4 AND "tenant_id" = :5 AND "version" = :6
+	`
+
+	result, err := dao.execContext(ctx, query,
+		m.Payload,
+		m.Status,
+		m.LeasedUntil,
+		m.ID,
+		tenantID,
+		m.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+
+	m.Version++
+	return nil
+}
+
+func (dao *JobDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	version, ok := fields["version"]
+	if !ok {
+		return fmt.Errorf("fields must contain the current version")
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+2)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "payload", "status", "leased_until", "version":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		if err := dao.validField(field, value); err != nil {
+			return err
+		}
+		if field == "version" {
+			continue
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)))
+	}
+	setClauses = append(setClauses, "\"version\" = \"version\" + 1")
+
+	args = append(args, pk)
+	whereClause := "\"id\" = " + fmt.Sprintf(":%d", len(args))
+	args = append(args, tenantID)
+	whereClause += " AND \"tenant_id\" = " + fmt.Sprintf(":%d", len(args))
+	args = append(args, version)
+	whereClause += " AND \"version\" = " + fmt.Sprintf(":%d", len(args))
+
+	query := fmt.Sprintf(`UPDATE "jobs" SET %s WHERE %s`, strings.Join(setClauses, ", "), whereClause)
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+
+	return nil
+}
+
+func (dao *JobDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := `DELETE FROM "jobs" WHERE "id" = :1 AND "tenant_id" = :2`
+	_, err := dao.execContext(ctx, query, pk, tenantID)
+	return err
+}
+
+func (dao *JobDAO) FindByPk(ctx context.Context, pk int64) (*Job, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `
+		SELECT "id", "tenant_id", "payload", "status", "leased_until", "version"
+		FROM "jobs"
+		WHERE "id" = :1 AND "tenant_id" = :2
+	`
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m Job
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Payload,
+		&m.Status,
+		&m.LeasedUntil,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *JobDAO) CreateMany(ctx context.Context, models []*Job) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	for _, model := range models {
+		if err := dao.Valid(model); err != nil {
+			return err
+		}
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*6)
+
+	for i, model := range models {
+		model.TenantID = tenantID
+		placeholders[i] = fmt.Sprintf("(:%d, :%d, :%d, :%d, :%d, :%d)",
+			i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+
+		args = append(args,
+			model.ID,
+			model.TenantID,
+			model.Payload,
+			model.Status,
+			model.LeasedUntil,
+			model.Version,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "jobs" ("id", "tenant_id", "payload", "status", "leased_until", "version")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *JobDAO) UpdateMany(ctx context.Context, models []*Job) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	for _, model := range models {
+		if err := dao.Valid(model); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE "jobs"
+		SET "payload" = :1,
+			"status" = :2,
+			"leased_until" = :3,
+			"version" = "version" + 1
+		WHERE "id" = :4 AND "tenant_id" = :5 AND "version" = :6
+	`
+
+	for _, model := range models {
+		result, err := dao.execContext(ctx, query,
+			model.Payload,
+			model.Status,
+			model.LeasedUntil,
+			model.ID,
+			tenantID,
+			model.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrStaleObject
+		}
+
+		model.Version++
+	}
+
+	return nil
+}
+
+func (dao *JobDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	args = append(args, tenantID)
+	query := fmt.Sprintf(`DELETE FROM "jobs" WHERE "id" IN (%s) AND "tenant_id" = :%d`, strings.Join(placeholders, ","), len(args))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *JobDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `DELETE FROM "jobs"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *JobDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	if _, ok := fields["tenant_id"]; ok {
+		return 0, fmt.Errorf("fields must not contain the tenant_id")
+	}
+
+	if _, ok := fields["version"]; ok {
+		return 0, fmt.Errorf("fields must not contain the version")
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "tenant_id", "payload", "status", "leased_until", "version":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		if err := dao.validField(field, value); err != nil {
+			return 0, err
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	setClauses = append(setClauses, "\"version\" = \"version\" + 1")
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "jobs" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *JobDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Job, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `
+		SELECT "id", "tenant_id", "payload", "status", "leased_until", "version"
+		FROM "jobs"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Job
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Payload,
+		&m.Status,
+		&m.LeasedUntil,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *JobDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Job, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `
+		SELECT "id", "tenant_id", "payload", "status", "leased_until", "version"
+		FROM "jobs"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Job
+	for rows.Next() {
+		var m Job
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Payload,
+			&m.Status,
+			&m.LeasedUntil,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *JobDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Job, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "tenant_id", "payload", "status", "leased_until", "version":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "jobs"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Job
+	for rows.Next() {
+		var m Job
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "tenant_id":
+				dest[i] = &m.TenantID
+			case "payload":
+				dest[i] = &m.Payload
+			case "status":
+				dest[i] = &m.Status
+			case "leased_until":
+				dest[i] = &m.LeasedUntil
+			case "version":
+				dest[i] = &m.Version
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *JobDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Job, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `SELECT "id", "tenant_id", "payload", "status", "leased_until", "version" FROM "jobs" WHERE "id" = :1 AND "tenant_id" = :2`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk, tenantID)
+
+	var m Job
+	err := row.Scan(
+		&m.ID,
+		&m.TenantID,
+		&m.Payload,
+		&m.Status,
+		&m.LeasedUntil,
+		&m.Version,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *JobDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Job, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `SELECT "id", "tenant_id", "payload", "status", "leased_until", "version" FROM "jobs"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Job
+	for rows.Next() {
+		var m Job
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Payload,
+			&m.Status,
+			&m.LeasedUntil,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *JobDAO) Claim(ctx context.Context, n int, leaseDuration time.Duration) ([]*Job, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	if dao.getTx(ctx) == nil {
+		var claimed []*Job
+		err := dao.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			claimed, err = dao.Claim(ctx, n, leaseDuration)
+			return err
+		})
+		return claimed, err
+	}
+
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	query := `SELECT "id", "tenant_id", "payload", "status", "leased_until", "version" FROM "jobs" WHERE "status" = :1 AND "tenant_id" = :2 ORDER BY "id"` + lockClause(LockSkipLocked)
+	rows, err := dao.queryContext(ctx, query, "pending", tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Job
+	for len(models) < n && rows.Next() {
+		var m Job
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Payload,
+			&m.Status,
+			&m.LeasedUntil,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	leasedUntil := time.Now().Add(leaseDuration)
+	keys := make([]interface{}, len(models))
+	for i, m := range models {
+		keys[i] = m.ID
+	}
+
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+4)
+		}
+		query := fmt.Sprintf(`UPDATE "jobs" SET "status" = :1, "leased_until" = :2, "version" = "version" + 1 WHERE "status" = :3 AND "id" IN (%s)`, strings.Join(placeholders, ", "))
+		args := append([]interface{}{"running", leasedUntil, "pending"}, batch...)
+		if _, err := dao.execContext(ctx, query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, m := range models {
+		m.Status = "running"
+		leasedUntil := leasedUntil
+		m.LeasedUntil = &leasedUntil
+		m.Version++
+	}
+
+	return models, nil
+}
+
+func (dao *JobDAO) Ack(ctx context.Context, pk int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := `UPDATE "jobs" SET "status" = :1, "version" = "version" + 1 WHERE "id" = :2 AND "status" = :3 AND "tenant_id" = :4`
+	result, err := dao.execContext(ctx, query, "done", pk, "running", tenantID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: Job %v", ErrNotClaimed, pk)
+	}
+
+	return nil
+}
+
+func (dao *JobDAO) Release(ctx context.Context, pk int64) error {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return ErrMissingTenant
+	}
+
+	query := `UPDATE "jobs" SET "status" = :1, "version" = "version" + 1 WHERE "id" = :2 AND "status" = :3 AND "tenant_id" = :4`
+	result, err := dao.execContext(ctx, query, "pending", pk, "running", tenantID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: Job %v", ErrNotClaimed, pk)
+	}
+
+	return nil
+}
+
+func (dao *JobDAO) ReapExpired(ctx context.Context) (int64, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `UPDATE "jobs" SET "status" = :1, "version" = "version" + 1 WHERE "status" = :2 AND "leased_until" < :3 AND "tenant_id" = :4`
+	result, err := dao.execContext(ctx, query, "pending", "running", time.Now(), tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *JobDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Job, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return nil, ErrMissingTenant
+	}
+
+	baseQuery := `
+		SELECT "id", "tenant_id", "payload", "status", "leased_until", "version"
+		FROM "jobs"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Job
+	for rows.Next() {
+		var m Job
+		err := rows.Scan(
+			&m.ID,
+			&m.TenantID,
+			&m.Payload,
+			&m.Status,
+			&m.LeasedUntil,
+			&m.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *JobDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := `SELECT COUNT(*) FROM "jobs"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *JobDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := `SELECT 1 FROM "jobs"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *JobDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	tenantID, ok := dao.tenantID(ctx)
+	if !ok {
+		return false, ErrMissingTenant
+	}
+
+	query := `SELECT 1 FROM "jobs" WHERE "id" = :1 AND "tenant_id" = :2`
+	args := []interface{}{pk, tenantID}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *JobDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "tenant_id", "payload", "status", "leased_until", "version":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return 0, ErrMissingTenant
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "jobs"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *JobDAO) MinLeasedUntil(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return time.Time{}, false, ErrMissingTenant
+	}
+
+	query := `SELECT MIN("leased_until") FROM "jobs"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *JobDAO) MaxLeasedUntil(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	where, args, ok := dao.scopeWhere(ctx, where, args)
+	if !ok {
+		return time.Time{}, false, ErrMissingTenant
+	}
+
+	query := `SELECT MAX("leased_until") FROM "jobs"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *JobDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,1079 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Order = models.Order
+
+// OrderWithUser is a row of Order joined with its User.
+type OrderWithUser = struct {
+	Order Order
+	User  *User
+}
+
+// OrderWithItems is a row of Order joined with one of its Items.
+type OrderWithItems = struct {
+	Order Order
+	Items *OrderItem
+}
+
+type OrderDAO struct {
+	db *sql.DB
+}
+
+func NewOrderDAO(db *sql.DB) *OrderDAO {
+	return &OrderDAO{db: db}
+}
+
+func (dao *OrderDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *OrderDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *OrderDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *OrderDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *OrderDAO) Create(ctx context.Context, m *Order) error {
+	query := `
+		INSERT INTO "orders" ("id", "user_id", "total")
+		VALUES (:1, :2, :3)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.UserID,
+		m.Total,
+	)
+
+	return err
+}
+
+func (dao *OrderDAO) Update(ctx context.Context, m *Order) error {
+	query := `
+		UPDATE "orders"
+		SET "user_id" = :1,
+			"total" = :2
+		WHERE "id" = :3
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.UserID,
+		m.Total,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *OrderDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "user_id", "total":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = :%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "orders" SET %s WHERE "id" = :%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *OrderDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := `DELETE FROM "orders" WHERE "id" = :1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *OrderDAO) FindByPk(ctx context.Context, pk int64) (*Order, error) {
+	query := `
+		SELECT "id", "user_id", "total"
+		FROM "orders"
+		WHERE "id" = :1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Order
+	err := row.Scan(
+		&m.ID,
+		&m.UserID,
+		&m.Total,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *OrderDAO) CreateMany(ctx context.Context, models []*Order) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*3)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(:%d, :%d, :%d)",
+			i*3+1, i*3+2, i*3+3)
+
+		args = append(args,
+			model.ID,
+			model.UserID,
+			model.Total,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "orders" ("id", "user_id", "total")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *OrderDAO) UpdateMany(ctx context.Context, models []*Order) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "orders"
+		SET "user_id" = :1,
+			"total" = :2
+		WHERE "id" = :3
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.UserID,
+			model.Total,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *OrderDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "orders" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *OrderDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *OrderDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "user_id", "total":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf(":%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "orders" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *OrderDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Order, error) {
+	query := `
+		SELECT "id", "user_id", "total"
+		FROM "orders"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Order
+	err := row.Scan(
+		&m.ID,
+		&m.UserID,
+		&m.Total,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *OrderDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Order, error) {
+	query := `
+		SELECT "id", "user_id", "total"
+		FROM "orders"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Order
+	for rows.Next() {
+		var m Order
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Order, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "user_id", "total":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "orders"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Order
+	for rows.Next() {
+		var m Order
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "user_id":
+				dest[i] = &m.UserID
+			case "total":
+				dest[i] = &m.Total
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Order, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "user_id", "total" FROM "orders" WHERE "id" = :1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Order
+	err := row.Scan(
+		&m.ID,
+		&m.UserID,
+		&m.Total,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *OrderDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Order, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "user_id", "total" FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Order
+	for rows.Next() {
+		var m Order
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Order, error) {
+	baseQuery := `
+		SELECT "id", "user_id", "total"
+		FROM "orders"
+	`
+
+	if where != "" {
+		baseQuery += " WHERE " + where
+	}
+
+	if sort != "" {
+		baseQuery += " ORDER BY " + sort
+	} else {
+		baseQuery += " ORDER BY ROWID"
+	}
+
+	query := fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, baseQuery, offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Order
+	for rows.Next() {
+		var m Order
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *OrderDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " FETCH FIRST 1 ROWS ONLY"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *OrderDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := `SELECT 1 FROM "orders" WHERE "id" = :1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *OrderDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "user_id", "total":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "orders"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *OrderDAO) SumUserID(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("user_id") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *OrderDAO) AvgUserID(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("user_id") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *OrderDAO) MinUserID(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN("user_id") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderDAO) MaxUserID(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX("user_id") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderDAO) SumTotal(ctx context.Context, where string, args ...interface{}) (float64, error) {
+	query := `SELECT SUM("total") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Float64, nil
+}
+
+func (dao *OrderDAO) AvgTotal(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("total") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *OrderDAO) MinTotal(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MIN("total") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderDAO) MaxTotal(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MAX("total") FROM "orders"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderDAO) PreloadUser(ctx context.Context, models []*Order) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	seen := make(map[int]bool, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		if model.UserID == nil || seen[*model.UserID] {
+			continue
+		}
+		seen[*model.UserID] = true
+		keys = append(keys, *model.UserID)
+	}
+
+	relatedDAO := &UserDAO{db: dao.db}
+	var related []*User
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	byKey := make(map[int]*User, len(related))
+	for _, r := range related {
+		byKey[r.ID] = r
+	}
+	for _, model := range models {
+		if model.UserID == nil {
+			model.User = nil
+			continue
+		}
+		model.User = byKey[*model.UserID]
+	}
+
+	return nil
+}
+
+func (dao *OrderDAO) FindAllWithUser(ctx context.Context, where string, sort string, args ...interface{}) ([]*Order, error) {
+	models, err := dao.FindAll(ctx, where, sort, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.PreloadUser(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderDAO) JoinUser(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderWithUser, error) {
+	query := `
+		SELECT "orders"."id" AS "orders__id", "orders"."user_id" AS "orders__user_id", "orders"."total" AS "orders__total", "user"."id" AS "user__id", "user"."name" AS "user__name", "user"."email" AS "user__email", "user"."password" AS "user__password", "user"."age" AS "user__age", "user"."deleted_at" AS "user__deleted_at"
+		FROM "orders" "orders"
+		INNER JOIN "users" "user" ON "user"."id" = "orders"."user_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*OrderWithUser
+	for rows.Next() {
+		var m Order
+		var related User
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+			&related.ID,
+			&related.Name,
+			&related.Email,
+			&related.Password,
+			&related.Age,
+			&related.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &OrderWithUser{Order: m}
+		result.User = &related
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *OrderDAO) LeftJoinUser(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderWithUser, error) {
+	query := `
+		SELECT "orders"."id" AS "orders__id", "orders"."user_id" AS "orders__user_id", "orders"."total" AS "orders__total", "user"."id" AS "user__id", "user"."name" AS "user__name", "user"."email" AS "user__email", "user"."password" AS "user__password", "user"."age" AS "user__age", "user"."deleted_at" AS "user__deleted_at"
+		FROM "orders" "orders"
+		LEFT JOIN "users" "user" ON "user"."id" = "orders"."user_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*OrderWithUser
+	for rows.Next() {
+		var m Order
+		var related User
+		var found bool
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+			joinedColumn(&found, nullableValue(&related.ID)),
+			joinedColumn(&found, nullableValue(&related.Name)),
+			joinedColumn(&found, nullableValue(&related.Email)),
+			joinedColumn(&found, nullableValue(&related.Password)),
+			joinedColumn(&found, nullableValue(&related.Age)),
+			joinedColumn(&found, nullableValue(&related.DeletedAt)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &OrderWithUser{Order: m}
+		if found {
+			result.User = &related
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *OrderDAO) PreloadItems(ctx context.Context, models []*Order) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	byKey := make(map[int64]*Order, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		model.Items = nil
+		if _, ok := byKey[model.ID]; ok {
+			continue
+		}
+		byKey[model.ID] = model
+		keys = append(keys, model.ID)
+	}
+
+	relatedDAO := &OrderItemDAO{db: dao.db}
+	var related []*OrderItem
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf(":%d", i+1)
+		}
+		where := fmt.Sprintf(`"order_id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "\"id\"", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	for _, r := range related {
+		if r.OrderID == nil {
+			continue
+		}
+		if model, ok := byKey[*r.OrderID]; ok {
+			model.Items = append(model.Items, r)
+		}
+	}
+
+	return nil
+}
+
+func (dao *OrderDAO) FindAllWithItems(ctx context.Context, where string, sort string, args ...interface{}) ([]*Order, error) {
+	models, err := dao.FindAll(ctx, where, sort, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.PreloadItems(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderDAO) JoinItems(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderWithItems, error) {
+	query := `
+		SELECT "orders"."id" AS "orders__id", "orders"."user_id" AS "orders__user_id", "orders"."total" AS "orders__total", "items"."id" AS "items__id", "items"."order_id" AS "items__order_id", "items"."sku" AS "items__sku", "items"."quantity" AS "items__quantity"
+		FROM "orders" "orders"
+		INNER JOIN "order_items" "items" ON "items"."order_id" = "orders"."id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*OrderWithItems
+	for rows.Next() {
+		var m Order
+		var related OrderItem
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+			&related.ID,
+			&related.OrderID,
+			&related.Sku,
+			&related.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &OrderWithItems{Order: m}
+		result.Items = &related
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *OrderDAO) LeftJoinItems(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderWithItems, error) {
+	query := `
+		SELECT "orders"."id" AS "orders__id", "orders"."user_id" AS "orders__user_id", "orders"."total" AS "orders__total", "items"."id" AS "items__id", "items"."order_id" AS "items__order_id", "items"."sku" AS "items__sku", "items"."quantity" AS "items__quantity"
+		FROM "orders" "orders"
+		LEFT JOIN "order_items" "items" ON "items"."order_id" = "orders"."id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*OrderWithItems
+	for rows.Next() {
+		var m Order
+		var related OrderItem
+		var found bool
+		err := rows.Scan(
+			&m.ID,
+			&m.UserID,
+			&m.Total,
+			joinedColumn(&found, nullableValue(&related.ID)),
+			joinedColumn(&found, nullableValue(&related.OrderID)),
+			joinedColumn(&found, nullableValue(&related.Sku)),
+			joinedColumn(&found, nullableValue(&related.Quantity)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &OrderWithItems{Order: m}
+		if found {
+			result.Items = &related
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *OrderDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,920 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type OrderItem = models.OrderItem
+
+// OrderItemWithOrder is a row of OrderItem joined with its Order.
+type OrderItemWithOrder = struct {
+	OrderItem OrderItem
+	Order     *Order
+}
+
+type OrderItemDAO struct {
+	db *sql.DB
+}
+
+func NewOrderItemDAO(db *sql.DB) *OrderItemDAO {
+	return &OrderItemDAO{db: db}
+}
+
+func (dao *OrderItemDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *OrderItemDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *OrderItemDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *OrderItemDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *OrderItemDAO) Create(ctx context.Context, m *OrderItem) error {
+	query := `
+		INSERT INTO "order_items" ("id", "order_id", "sku", "quantity")
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.OrderID,
+		m.Sku,
+		m.Quantity,
+	)
+
+	return err
+}
+
+func (dao *OrderItemDAO) Update(ctx context.Context, m *OrderItem) error {
+	query := `
+		UPDATE "order_items"
+		SET "order_id" = $1,
+			"sku" = $2,
+			"quantity" = $3
+		WHERE "id" = $4
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.OrderID,
+		m.Sku,
+		m.Quantity,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *OrderItemDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "order_id", "sku", "quantity":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = $%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "order_items" SET %s WHERE "id" = $%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *OrderItemDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := `DELETE FROM "order_items" WHERE "id" = $1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *OrderItemDAO) FindByPk(ctx context.Context, pk int64) (*OrderItem, error) {
+	query := `
+		SELECT "id", "order_id", "sku", "quantity"
+		FROM "order_items"
+		WHERE "id" = $1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m OrderItem
+	err := row.Scan(
+		&m.ID,
+		&m.OrderID,
+		&m.Sku,
+		&m.Quantity,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *OrderItemDAO) CreateMany(ctx context.Context, models []*OrderItem) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d)",
+			i*4+1, i*4+2, i*4+3, i*4+4)
+
+		args = append(args,
+			model.ID,
+			model.OrderID,
+			model.Sku,
+			model.Quantity,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "order_items" ("id", "order_id", "sku", "quantity")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *OrderItemDAO) UpdateMany(ctx context.Context, models []*OrderItem) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "order_items"
+		SET "order_id" = $1,
+			"sku" = $2,
+			"quantity" = $3
+		WHERE "id" = $4
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.OrderID,
+			model.Sku,
+			model.Quantity,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *OrderItemDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "order_items" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *OrderItemDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *OrderItemDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "order_id", "sku", "quantity":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf("$%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "order_items" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *OrderItemDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*OrderItem, error) {
+	query := `
+		SELECT "id", "order_id", "sku", "quantity"
+		FROM "order_items"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m OrderItem
+	err := row.Scan(
+		&m.ID,
+		&m.OrderID,
+		&m.Sku,
+		&m.Quantity,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *OrderItemDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderItem, error) {
+	query := `
+		SELECT "id", "order_id", "sku", "quantity"
+		FROM "order_items"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*OrderItem
+	for rows.Next() {
+		var m OrderItem
+		err := rows.Scan(
+			&m.ID,
+			&m.OrderID,
+			&m.Sku,
+			&m.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderItemDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*OrderItem, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "order_id", "sku", "quantity":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "order_items"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*OrderItem
+	for rows.Next() {
+		var m OrderItem
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "order_id":
+				dest[i] = &m.OrderID
+			case "sku":
+				dest[i] = &m.Sku
+			case "quantity":
+				dest[i] = &m.Quantity
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderItemDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*OrderItem, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "order_id", "sku", "quantity" FROM "order_items" WHERE "id" = $1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m OrderItem
+	err := row.Scan(
+		&m.ID,
+		&m.OrderID,
+		&m.Sku,
+		&m.Quantity,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *OrderItemDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*OrderItem, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "order_id", "sku", "quantity" FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*OrderItem
+	for rows.Next() {
+		var m OrderItem
+		err := rows.Scan(
+			&m.ID,
+			&m.OrderID,
+			&m.Sku,
+			&m.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderItemDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*OrderItem, error) {
+	query := `
+		SELECT "id", "order_id", "sku", "quantity"
+		FROM "order_items"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*OrderItem
+	for rows.Next() {
+		var m OrderItem
+		err := rows.Scan(
+			&m.ID,
+			&m.OrderID,
+			&m.Sku,
+			&m.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderItemDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *OrderItemDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *OrderItemDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := `SELECT 1 FROM "order_items" WHERE "id" = $1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *OrderItemDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "order_id", "sku", "quantity":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "order_items"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *OrderItemDAO) SumOrderID(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("order_id") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *OrderItemDAO) AvgOrderID(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("order_id") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *OrderItemDAO) MinOrderID(ctx context.Context, where string, args ...interface{}) (int64, bool, error) {
+	query := `SELECT MIN("order_id") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderItemDAO) MaxOrderID(ctx context.Context, where string, args ...interface{}) (int64, bool, error) {
+	query := `SELECT MAX("order_id") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderItemDAO) SumQuantity(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("quantity") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *OrderItemDAO) AvgQuantity(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("quantity") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *OrderItemDAO) MinQuantity(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN("quantity") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderItemDAO) MaxQuantity(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX("quantity") FROM "order_items"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *OrderItemDAO) PreloadOrder(ctx context.Context, models []*OrderItem) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	seen := make(map[int64]bool, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		if model.OrderID == nil || seen[*model.OrderID] {
+			continue
+		}
+		seen[*model.OrderID] = true
+		keys = append(keys, *model.OrderID)
+	}
+
+	relatedDAO := &OrderDAO{db: dao.db}
+	var related []*Order
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	byKey := make(map[int64]*Order, len(related))
+	for _, r := range related {
+		byKey[r.ID] = r
+	}
+	for _, model := range models {
+		if model.OrderID == nil {
+			continue
+		}
+		if r, ok := byKey[*model.OrderID]; ok {
+			model.Order = *r
+		}
+	}
+
+	return nil
+}
+
+func (dao *OrderItemDAO) FindAllWithOrder(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderItem, error) {
+	models, err := dao.FindAll(ctx, where, sort, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.PreloadOrder(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *OrderItemDAO) JoinOrder(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderItemWithOrder, error) {
+	query := `
+		SELECT "order_items"."id" AS "order_items__id", "order_items"."order_id" AS "order_items__order_id", "order_items"."sku" AS "order_items__sku", "order_items"."quantity" AS "order_items__quantity", "order"."id" AS "order__id", "order"."user_id" AS "order__user_id", "order"."total" AS "order__total"
+		FROM "order_items" "order_items"
+		INNER JOIN "orders" "order" ON "order"."id" = "order_items"."order_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*OrderItemWithOrder
+	for rows.Next() {
+		var m OrderItem
+		var related Order
+		err := rows.Scan(
+			&m.ID,
+			&m.OrderID,
+			&m.Sku,
+			&m.Quantity,
+			&related.ID,
+			&related.UserID,
+			&related.Total,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &OrderItemWithOrder{OrderItem: m}
+		result.Order = &related
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *OrderItemDAO) LeftJoinOrder(ctx context.Context, where string, sort string, args ...interface{}) ([]*OrderItemWithOrder, error) {
+	query := `
+		SELECT "order_items"."id" AS "order_items__id", "order_items"."order_id" AS "order_items__order_id", "order_items"."sku" AS "order_items__sku", "order_items"."quantity" AS "order_items__quantity", "order"."id" AS "order__id", "order"."user_id" AS "order__user_id", "order"."total" AS "order__total"
+		FROM "order_items" "order_items"
+		LEFT JOIN "orders" "order" ON "order"."id" = "order_items"."order_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*OrderItemWithOrder
+	for rows.Next() {
+		var m OrderItem
+		var related Order
+		var found bool
+		err := rows.Scan(
+			&m.ID,
+			&m.OrderID,
+			&m.Sku,
+			&m.Quantity,
+			joinedColumn(&found, nullableValue(&related.ID)),
+			joinedColumn(&found, nullableValue(&related.UserID)),
+			joinedColumn(&found, nullableValue(&related.Total)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &OrderItemWithOrder{OrderItem: m}
+		if found {
+			result.Order = &related
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *OrderItemDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
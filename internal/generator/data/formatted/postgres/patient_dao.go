@@ -0,0 +1,656 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Patient = models.Patient
+
+type PatientDAO struct {
+	db        *sql.DB
+	encryptor Encryptor
+}
+
+func NewPatientDAO(db *sql.DB, encryptor Encryptor) *PatientDAO {
+	return &PatientDAO{db: db, encryptor: encryptor}
+}
+
+func (dao *PatientDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *PatientDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *PatientDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *PatientDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *PatientDAO) Create(ctx context.Context, m *Patient) error {
+	query := `
+		INSERT INTO "patients" ("id", "name", "ssn", "phone", "notes")
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Name,
+		encryptedColumn{dao.encryptor, m.SSN},
+		encryptedColumn{dao.encryptor, nullIfZero(m.Phone)},
+		encryptedColumn{dao.encryptor, m.Notes},
+	)
+
+	return err
+}
+
+func (dao *PatientDAO) Update(ctx context.Context, m *Patient) error {
+	query := `
+		UPDATE "patients"
+		SET "name" = $1,
+			"ssn" = $2,
+			"phone" = $3,
+			"notes" = $4
+		WHERE "id" = $5
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.Name,
+		encryptedColumn{dao.encryptor, m.SSN},
+		encryptedColumn{dao.encryptor, nullIfZero(m.Phone)},
+		encryptedColumn{dao.encryptor, m.Notes},
+		m.ID,
+	)
+	return err
+}
+
+func (dao *PatientDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "ssn", "phone", "notes":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "ssn", "notes":
+			value = encryptedColumn{dao.encryptor, value}
+		case "phone":
+			value = encryptedColumn{dao.encryptor, nullIfZero(value)}
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = $%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "patients" SET %s WHERE "id" = $%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PatientDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := `DELETE FROM "patients" WHERE "id" = $1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *PatientDAO) FindByPk(ctx context.Context, pk int64) (*Patient, error) {
+	query := `
+		SELECT "id", "name", "ssn", "phone", "notes"
+		FROM "patients"
+		WHERE "id" = $1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Patient
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		encryptedColumn{dao.encryptor, &m.SSN},
+		encryptedColumn{dao.encryptor, &m.Phone},
+		encryptedColumn{dao.encryptor, &m.Notes},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PatientDAO) CreateMany(ctx context.Context, models []*Patient) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*5)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)",
+			i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+
+		args = append(args,
+			model.ID,
+			model.Name,
+			encryptedColumn{dao.encryptor, model.SSN},
+			encryptedColumn{dao.encryptor, nullIfZero(model.Phone)},
+			encryptedColumn{dao.encryptor, model.Notes},
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "patients" ("id", "name", "ssn", "phone", "notes")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PatientDAO) UpdateMany(ctx context.Context, models []*Patient) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "patients"
+		SET "name" = $1,
+			"ssn" = $2,
+			"phone" = $3,
+			"notes" = $4
+		WHERE "id" = $5
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Name,
+			encryptedColumn{dao.encryptor, model.SSN},
+			encryptedColumn{dao.encryptor, nullIfZero(model.Phone)},
+			encryptedColumn{dao.encryptor, model.Notes},
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *PatientDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "patients" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PatientDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "patients"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PatientDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "ssn", "phone", "notes":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "ssn", "notes":
+			value = encryptedColumn{dao.encryptor, value}
+		case "phone":
+			value = encryptedColumn{dao.encryptor, nullIfZero(value)}
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf("$%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "patients" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PatientDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Patient, error) {
+	query := `
+		SELECT "id", "name", "ssn", "phone", "notes"
+		FROM "patients"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Patient
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		encryptedColumn{dao.encryptor, &m.SSN},
+		encryptedColumn{dao.encryptor, &m.Phone},
+		encryptedColumn{dao.encryptor, &m.Notes},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PatientDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Patient, error) {
+	query := `
+		SELECT "id", "name", "ssn", "phone", "notes"
+		FROM "patients"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Patient
+	for rows.Next() {
+		var m Patient
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			encryptedColumn{dao.encryptor, &m.SSN},
+			encryptedColumn{dao.encryptor, &m.Phone},
+			encryptedColumn{dao.encryptor, &m.Notes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PatientDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Patient, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "name", "ssn", "phone", "notes":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "patients"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Patient
+	for rows.Next() {
+		var m Patient
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "name":
+				dest[i] = &m.Name
+			case "ssn":
+				dest[i] = encryptedColumn{dao.encryptor, &m.SSN}
+			case "phone":
+				dest[i] = encryptedColumn{dao.encryptor, &m.Phone}
+			case "notes":
+				dest[i] = encryptedColumn{dao.encryptor, &m.Notes}
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PatientDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Patient, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name", "ssn", "phone", "notes" FROM "patients" WHERE "id" = $1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Patient
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		encryptedColumn{dao.encryptor, &m.SSN},
+		encryptedColumn{dao.encryptor, &m.Phone},
+		encryptedColumn{dao.encryptor, &m.Notes},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PatientDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Patient, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name", "ssn", "phone", "notes" FROM "patients"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Patient
+	for rows.Next() {
+		var m Patient
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			encryptedColumn{dao.encryptor, &m.SSN},
+			encryptedColumn{dao.encryptor, &m.Phone},
+			encryptedColumn{dao.encryptor, &m.Notes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PatientDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Patient, error) {
+	query := `
+		SELECT "id", "name", "ssn", "phone", "notes"
+		FROM "patients"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Patient
+	for rows.Next() {
+		var m Patient
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			encryptedColumn{dao.encryptor, &m.SSN},
+			encryptedColumn{dao.encryptor, &m.Phone},
+			encryptedColumn{dao.encryptor, &m.Notes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PatientDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "patients"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PatientDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "patients"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PatientDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := `SELECT 1 FROM "patients" WHERE "id" = $1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PatientDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "name", "ssn", "phone", "notes":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "patients"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PatientDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
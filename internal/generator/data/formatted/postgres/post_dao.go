@@ -0,0 +1,732 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+	"time"
+)
+
+type Post = models.Post
+
+type PostDAO struct {
+	db *sql.DB
+}
+
+func NewPostDAO(db *sql.DB) *PostDAO {
+	return &PostDAO{db: db}
+}
+
+func (dao *PostDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *PostDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *PostDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *PostDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *PostDAO) Create(ctx context.Context, m *Post) error {
+	query := `
+		INSERT INTO "posts" ("id", "title", "body", "deleted_at")
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Title,
+		m.Body,
+		m.DeletedAt,
+	)
+
+	return err
+}
+
+func (dao *PostDAO) Update(ctx context.Context, m *Post) error {
+	query := `
+		UPDATE "posts"
+		SET "title" = $1,
+			"body" = $2,
+			"deleted_at" = $3
+		WHERE "id" = $4
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.Title,
+		m.Body,
+		m.DeletedAt,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *PostDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "body", "deleted_at":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = $%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "posts" SET %s WHERE "id" = $%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PostDAO) DeleteByPk(ctx context.Context, pk int) error {
+	query := `UPDATE "posts" SET "deleted_at" = $1 WHERE "id" = $2 AND "deleted_at" IS NULL`
+	_, err := dao.execContext(ctx, query, time.Now(), pk)
+	return err
+}
+
+func (dao *PostDAO) FindByPk(ctx context.Context, pk int) (*Post, error) {
+	query := `
+		SELECT "id", "title", "body", "deleted_at"
+		FROM "posts"
+		WHERE "id" = $1 AND "deleted_at" IS NULL
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Post
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Body,
+		&m.DeletedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PostDAO) CreateMany(ctx context.Context, models []*Post) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*4)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d)",
+			i*4+1, i*4+2, i*4+3, i*4+4)
+
+		args = append(args,
+			model.ID,
+			model.Title,
+			model.Body,
+			model.DeletedAt,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "posts" ("id", "title", "body", "deleted_at")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PostDAO) UpdateMany(ctx context.Context, models []*Post) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "posts"
+		SET "title" = $1,
+			"body" = $2,
+			"deleted_at" = $3
+		WHERE "id" = $4
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Title,
+			model.Body,
+			model.DeletedAt,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *PostDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, 0, len(pks)+1)
+	args = append(args, time.Now())
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, pk)
+	}
+
+	query := fmt.Sprintf(`UPDATE "posts" SET "deleted_at" = $1 WHERE "id" IN (%s) AND "deleted_at" IS NULL`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PostDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	args = append(args, time.Now())
+	query := fmt.Sprintf(`UPDATE "posts" SET "deleted_at" = $%d WHERE "deleted_at" IS NULL`, len(args))
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PostDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "title", "body", "deleted_at":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf("$%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "posts" SET %s WHERE "deleted_at" IS NULL`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PostDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Post, error) {
+	query := `
+		SELECT "id", "title", "body", "deleted_at"
+		FROM "posts"
+		WHERE "deleted_at" IS NULL
+	`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Post
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Body,
+		&m.DeletedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PostDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Post, error) {
+	query := `
+		SELECT "id", "title", "body", "deleted_at"
+		FROM "posts"
+		WHERE "deleted_at" IS NULL
+	`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Post
+	for rows.Next() {
+		var m Post
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Body,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PostDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Post, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "title", "body", "deleted_at":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "posts" WHERE "deleted_at" IS NULL`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Post
+	for rows.Next() {
+		var m Post
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "title":
+				dest[i] = &m.Title
+			case "body":
+				dest[i] = &m.Body
+			case "deleted_at":
+				dest[i] = &m.DeletedAt
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PostDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Post, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "title", "body", "deleted_at" FROM "posts" WHERE "id" = $1 AND "deleted_at" IS NULL`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Post
+	err := row.Scan(
+		&m.ID,
+		&m.Title,
+		&m.Body,
+		&m.DeletedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PostDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Post, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "title", "body", "deleted_at" FROM "posts" WHERE "deleted_at" IS NULL`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Post
+	for rows.Next() {
+		var m Post
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Body,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PostDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Post, error) {
+	query := `
+		SELECT "id", "title", "body", "deleted_at"
+		FROM "posts"
+		WHERE "deleted_at" IS NULL
+	`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Post
+	for rows.Next() {
+		var m Post
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Body,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PostDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "posts" WHERE "deleted_at" IS NULL`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PostDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "posts" WHERE "deleted_at" IS NULL`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PostDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := `SELECT 1 FROM "posts" WHERE "id" = $1 AND "deleted_at" IS NULL`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PostDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "title", "body", "deleted_at":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "posts" WHERE "deleted_at" IS NULL`, column)
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PostDAO) Restore(ctx context.Context, pk int) error {
+	query := `UPDATE "posts" SET "deleted_at" = NULL WHERE "id" = $1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *PostDAO) HardDelete(ctx context.Context, pk int) error {
+	query := `DELETE FROM "posts" WHERE "id" = $1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *PostDAO) FindAllWithDeleted(ctx context.Context, where string, sort string, args ...interface{}) ([]*Post, error) {
+	query := `
+		SELECT "id", "title", "body", "deleted_at"
+		FROM "posts"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Post
+	for rows.Next() {
+		var m Post
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Body,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PostDAO) FindAllOnlyDeleted(ctx context.Context, where string, sort string, args ...interface{}) ([]*Post, error) {
+	query := `
+		SELECT "id", "title", "body", "deleted_at"
+		FROM "posts"
+		WHERE "deleted_at" IS NOT NULL
+	`
+
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Post
+	for rows.Next() {
+		var m Post
+		err := rows.Scan(
+			&m.ID,
+			&m.Title,
+			&m.Body,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PostDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,9 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/Jibaru/gormless/internal/generator/data/models"
 	"strings"
+	"time"
 )
 
 type User = models.User
@@ -48,7 +50,7 @@ func (dao *UserDAO) queryContext(ctx context.Context, query string, args ...inte
 
 func (dao *UserDAO) Create(ctx context.Context, m *User) error {
 	query := `
-		INSERT INTO users (id, name, email, password, age, deleted_at)
+		INSERT INTO "users" ("id", "name", "email", "password", "age", "deleted_at")
 		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
@@ -68,13 +70,13 @@ func (dao *UserDAO) Create(ctx context.Context, m *User) error {
 
 func (dao *UserDAO) Update(ctx context.Context, m *User) error {
 	query := `
-		UPDATE users
-		SET name = $1,
-			email = $2,
-			password = $3,
-			age = $4,
-			deleted_at = $5
-		WHERE id = $6
+		UPDATE "users"
+		SET "name" = $1,
+			"email" = $2,
+			"password" = $3,
+			"age" = $4,
+			"deleted_at" = $5
+		WHERE "id" = $6
 	`
 
 	_, err := dao.execContext(ctx, query,
@@ -98,30 +100,35 @@ func (dao *UserDAO) PartialUpdate(ctx context.Context, pk int, fields map[string
 	i := 1
 
 	for field, value := range fields {
-		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field, i))
+		switch field {
+		case "id", "name", "email", "password", "age", "deleted_at":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = $%d", field, i))
 		args = append(args, value)
 		i++
 	}
 
 	args = append(args, pk)
 
-	query := fmt.Sprintf(`UPDATE users SET %s WHERE id = $%d`, strings.Join(setClauses, ", "), i)
+	query := fmt.Sprintf(`UPDATE "users" SET %s WHERE "id" = $%d`, strings.Join(setClauses, ", "), i)
 
 	_, err := dao.execContext(ctx, query, args...)
 	return err
 }
 
 func (dao *UserDAO) DeleteByPk(ctx context.Context, pk int) error {
-	query := `DELETE FROM users WHERE id = $1`
+	query := `DELETE FROM "users" WHERE "id" = $1`
 	_, err := dao.execContext(ctx, query, pk)
 	return err
 }
 
 func (dao *UserDAO) FindByPk(ctx context.Context, pk int) (*User, error) {
 	query := `
-		SELECT id, name, email, password, age, deleted_at
-		FROM users
-		WHERE id = $1
+		SELECT "id", "name", "email", "password", "age", "deleted_at"
+		FROM "users"
+		WHERE "id" = $1
 	`
 	row := dao.queryRowContext(ctx, query, pk)
 
@@ -165,7 +172,7 @@ func (dao *UserDAO) CreateMany(ctx context.Context, models []*User) error {
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO users (id, name, email, password, age, deleted_at)
+		INSERT INTO "users" ("id", "name", "email", "password", "age", "deleted_at")
 		VALUES %s
 	`, strings.Join(placeholders, ", "))
 
@@ -179,13 +186,13 @@ func (dao *UserDAO) UpdateMany(ctx context.Context, models []*User) error {
 	}
 
 	query := `
-		UPDATE users
-		SET name = $1,
-			email = $2,
-			password = $3,
-			age = $4,
-			deleted_at = $5
-		WHERE id = $6
+		UPDATE "users"
+		SET "name" = $1,
+			"email" = $2,
+			"password" = $3,
+			"age" = $4,
+			"deleted_at" = $5
+		WHERE "id" = $6
 	`
 
 	for _, model := range models {
@@ -217,15 +224,73 @@ func (dao *UserDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
 		args[i] = pk
 	}
 
-	query := fmt.Sprintf(`DELETE FROM users WHERE id IN (%s)`, strings.Join(placeholders, ","))
+	query := fmt.Sprintf(`DELETE FROM "users" WHERE "id" IN (%s)`, strings.Join(placeholders, ","))
 	_, err := dao.execContext(ctx, query, args...)
 	return err
 }
 
+func (dao *UserDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *UserDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "email", "password", "age", "deleted_at":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = "+fmt.Sprintf("$%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE "users" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (dao *UserDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*User, error) {
 	query := `
-		SELECT id, name, email, password, age, deleted_at
-		FROM users
+		SELECT "id", "name", "email", "password", "age", "deleted_at"
+		FROM "users"
 	`
 
 	if where != "" {
@@ -257,8 +322,8 @@ func (dao *UserDAO) FindOne(ctx context.Context, where string, sort string, args
 
 func (dao *UserDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*User, error) {
 	query := `
-		SELECT id, name, email, password, age, deleted_at
-		FROM users
+		SELECT "id", "name", "email", "password", "age", "deleted_at"
+		FROM "users"
 	`
 
 	if where != "" {
@@ -299,10 +364,158 @@ func (dao *UserDAO) FindAll(ctx context.Context, where string, sort string, args
 	return models, nil
 }
 
+func (dao *UserDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*User, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "name", "email", "password", "age", "deleted_at":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "users"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*User
+	for rows.Next() {
+		var m User
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "name":
+				dest[i] = &m.Name
+			case "email":
+				dest[i] = &m.Email
+			case "password":
+				dest[i] = &m.Password
+			case "age":
+				dest[i] = &m.Age
+			case "deleted_at":
+				dest[i] = &m.DeletedAt
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *UserDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*User, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name", "email", "password", "age", "deleted_at" FROM "users" WHERE "id" = $1`
+	query += lockClause(lock)
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m User
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		&m.Email,
+		&m.Password,
+		&m.Age,
+		&m.DeletedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *UserDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*User, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT "id", "name", "email", "password", "age", "deleted_at" FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+	query += lockClause(lock)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*User
+	for rows.Next() {
+		var m User
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Email,
+			&m.Password,
+			&m.Age,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
 func (dao *UserDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*User, error) {
 	query := `
-		SELECT id, name, email, password, age, deleted_at
-		FROM users
+		SELECT "id", "name", "email", "password", "age", "deleted_at"
+		FROM "users"
 	`
 
 	if where != "" {
@@ -346,7 +559,7 @@ func (dao *UserDAO) FindPaginated(ctx context.Context, limit, offset int, where
 }
 
 func (dao *UserDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
-	query := "SELECT COUNT(*) FROM users"
+	query := `SELECT COUNT(*) FROM "users"`
 
 	if where != "" {
 		query += " WHERE " + where
@@ -363,6 +576,340 @@ func (dao *UserDAO) Count(ctx context.Context, where string, args ...interface{}
 	return count, nil
 }
 
+func (dao *UserDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *UserDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := `SELECT 1 FROM "users" WHERE "id" = $1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *UserDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "name", "email", "password", "age", "deleted_at":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "users"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *UserDAO) SumAge(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("age") FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *UserDAO) AvgAge(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("age") FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *UserDAO) MinAge(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN("age") FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *UserDAO) MaxAge(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX("age") FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *UserDAO) MinDeletedAt(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MIN("deleted_at") FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *UserDAO) MaxDeletedAt(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MAX("deleted_at") FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *UserDAO) GroupByEmail(ctx context.Context, where string, args ...interface{}) ([]struct {
+	Key   *string
+	Count int64
+}, error) {
+	query := `SELECT "email", COUNT(*) FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " GROUP BY \"email\" ORDER BY \"email\""
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []struct {
+		Key   *string
+		Count int64
+	}
+	for rows.Next() {
+		var m User
+		var count int64
+		if err := rows.Scan(&m.Email, &count); err != nil {
+			return nil, err
+		}
+		groups = append(groups, struct {
+			Key   *string
+			Count int64
+		}{m.Email, count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (dao *UserDAO) GroupByAge(ctx context.Context, where string, args ...interface{}) ([]struct {
+	Key   int
+	Count int64
+}, error) {
+	query := `SELECT "age", COUNT(*) FROM "users"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " GROUP BY \"age\" ORDER BY \"age\""
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []struct {
+		Key   int
+		Count int64
+	}
+	for rows.Next() {
+		var m User
+		var count int64
+		if err := rows.Scan(&m.Age, &count); err != nil {
+			return nil, err
+		}
+		groups = append(groups, struct {
+			Key   int
+			Count int64
+		}{m.Age, count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (dao *UserDAO) ActiveAdults(ctx context.Context, age int) ([]*User, error) {
+	query := `SELECT "id", "name", "email", "password", "age", "deleted_at" FROM users WHERE age >= $1 AND deleted_at IS NULL ORDER BY name`
+	rows, err := dao.queryContext(ctx, query, age)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*User
+	for rows.Next() {
+		var m User
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Email,
+			&m.Password,
+			&m.Age,
+			&m.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *UserDAO) FindByEmail(ctx context.Context, email *string) (*User, error) {
+	query := `SELECT id, name, email, password, age, deleted_at FROM users WHERE email = $1`
+	row := dao.queryRowContext(ctx, query, email)
+
+	var m User
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		&m.Email,
+		&m.Password,
+		&m.Age,
+		&m.DeletedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+type CountByAgeRow = struct {
+	Age         int
+	Total       int64
+	LastDeleted *time.Time
+}
+
+func (dao *UserDAO) CountByAge(ctx context.Context, name string) ([]*CountByAgeRow, error) {
+	query := `SELECT age, COUNT(*) AS total, MAX(deleted_at) AS last_deleted FROM users WHERE name LIKE $1 GROUP BY age`
+	rows, err := dao.queryContext(ctx, query, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*CountByAgeRow
+	for rows.Next() {
+		var m CountByAgeRow
+		err := rows.Scan(
+			&m.Age,
+			&m.Total,
+			&m.LastDeleted,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *UserDAO) Rename(ctx context.Context, name string, id int) error {
+	query := `UPDATE users SET name = $1 WHERE id = $2`
+	_, err := dao.execContext(ctx, query, name, id)
+	return err
+}
+
+func (dao *UserDAO) PurgeDeleted(ctx context.Context, deletedAt *time.Time) (int64, error) {
+	query := `DELETE FROM users WHERE deleted_at < $1`
+	result, err := dao.execContext(ctx, query, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (dao *UserDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	tx, err := dao.db.BeginTx(ctx, nil)
 	if err != nil {
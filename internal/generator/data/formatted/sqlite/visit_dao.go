@@ -0,0 +1,770 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+)
+
+type Visit = models.Visit
+
+// VisitWithPatient is a row of Visit joined with its Patient.
+type VisitWithPatient = struct {
+	Visit   Visit
+	Patient *Patient
+}
+
+type VisitDAO struct {
+	db        *sql.DB
+	encryptor Encryptor
+}
+
+func NewVisitDAO(db *sql.DB, encryptor Encryptor) *VisitDAO {
+	return &VisitDAO{db: db, encryptor: encryptor}
+}
+
+func (dao *VisitDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *VisitDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *VisitDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *VisitDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *VisitDAO) Create(ctx context.Context, m *Visit) error {
+	query := `
+		INSERT INTO "visits" ("id", "patient_id", "reason")
+		VALUES (?, ?, ?)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.PatientID,
+		m.Reason,
+	)
+
+	return err
+}
+
+func (dao *VisitDAO) Update(ctx context.Context, m *Visit) error {
+	query := `
+		UPDATE "visits"
+		SET "patient_id" = ?,
+			"reason" = ?
+		WHERE "id" = ?
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.PatientID,
+		m.Reason,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *VisitDAO) PartialUpdate(ctx context.Context, pk int64, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+
+	for field, value := range fields {
+		switch field {
+		case "id", "patient_id", "reason":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("\"%s\" = ?", field))
+		args = append(args, value)
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE "visits" SET %s WHERE "id" = ?`, strings.Join(setClauses, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *VisitDAO) DeleteByPk(ctx context.Context, pk int64) error {
+	query := `DELETE FROM "visits" WHERE "id" = ?`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *VisitDAO) FindByPk(ctx context.Context, pk int64) (*Visit, error) {
+	query := `
+		SELECT "id", "patient_id", "reason"
+		FROM "visits"
+		WHERE "id" = ?
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Visit
+	err := row.Scan(
+		&m.ID,
+		&m.PatientID,
+		&m.Reason,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *VisitDAO) CreateMany(ctx context.Context, models []*Visit) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*3)
+
+	for i, model := range models {
+		placeholders[i] = "(?,?,?)"
+
+		args = append(args,
+			model.ID,
+			model.PatientID,
+			model.Reason,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO "visits" ("id", "patient_id", "reason")
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *VisitDAO) UpdateMany(ctx context.Context, models []*Visit) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE "visits"
+		SET "patient_id" = ?,
+			"reason" = ?
+		WHERE "id" = ?
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.PatientID,
+			model.Reason,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *VisitDAO) DeleteManyByPks(ctx context.Context, pks []int64) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := strings.Repeat("?,", len(pks)-1) + "?"
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM "visits" WHERE "id" IN (%s)`, placeholders)
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *VisitDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *VisitDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "patient_id", "reason":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "\""+field+"\" = ?")
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(setArgs, args...)
+
+	query := fmt.Sprintf(`UPDATE "visits" SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *VisitDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Visit, error) {
+	query := `
+		SELECT "id", "patient_id", "reason"
+		FROM "visits"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Visit
+	err := row.Scan(
+		&m.ID,
+		&m.PatientID,
+		&m.Reason,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *VisitDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Visit, error) {
+	query := `
+		SELECT "id", "patient_id", "reason"
+		FROM "visits"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Visit
+	for rows.Next() {
+		var m Visit
+		err := rows.Scan(
+			&m.ID,
+			&m.PatientID,
+			&m.Reason,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *VisitDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Visit, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "patient_id", "reason":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "\"" + column + "\""
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM "visits"`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Visit
+	for rows.Next() {
+		var m Visit
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "patient_id":
+				dest[i] = &m.PatientID
+			case "reason":
+				dest[i] = &m.Reason
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *VisitDAO) FindByPkForUpdate(ctx context.Context, pk int64, lock LockMode) (*Visit, error) {
+	return nil, ErrLockUnsupported
+}
+
+func (dao *VisitDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Visit, error) {
+	return nil, ErrLockUnsupported
+}
+
+func (dao *VisitDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Visit, error) {
+	query := `
+		SELECT "id", "patient_id", "reason"
+		FROM "visits"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Visit
+	for rows.Next() {
+		var m Visit
+		err := rows.Scan(
+			&m.ID,
+			&m.PatientID,
+			&m.Reason,
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *VisitDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *VisitDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT 1 FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	query += " LIMIT 1"
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *VisitDAO) ExistsByPk(ctx context.Context, pk int64) (bool, error) {
+	query := `SELECT 1 FROM "visits" WHERE "id" = ?`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *VisitDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "patient_id", "reason":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT "%s") FROM "visits"`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *VisitDAO) SumPatientID(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM("patient_id") FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *VisitDAO) AvgPatientID(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG("patient_id") FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *VisitDAO) MinPatientID(ctx context.Context, where string, args ...interface{}) (int64, bool, error) {
+	query := `SELECT MIN("patient_id") FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *VisitDAO) MaxPatientID(ctx context.Context, where string, args ...interface{}) (int64, bool, error) {
+	query := `SELECT MAX("patient_id") FROM "visits"`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *VisitDAO) PreloadPatient(ctx context.Context, models []*Visit) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	seen := make(map[int64]bool, len(models))
+	keys := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		if seen[model.PatientID] {
+			continue
+		}
+		seen[model.PatientID] = true
+		keys = append(keys, model.PatientID)
+	}
+
+	relatedDAO := &PatientDAO{db: dao.db, encryptor: dao.encryptor}
+	var related []*Patient
+	for start := 0; start < len(keys); start += inListBatchSize {
+		end := start + inListBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch := keys[start:end]
+		placeholders := make([]string, len(batch))
+		for i := range batch {
+			placeholders[i] = "?"
+		}
+		where := fmt.Sprintf(`"id" IN (%s)`, strings.Join(placeholders, ", "))
+		found, err := relatedDAO.FindAll(ctx, where, "", batch...)
+		if err != nil {
+			return err
+		}
+		related = append(related, found...)
+	}
+
+	byKey := make(map[int64]*Patient, len(related))
+	for _, r := range related {
+		byKey[r.ID] = r
+	}
+	for _, model := range models {
+		model.Patient = byKey[model.PatientID]
+	}
+
+	return nil
+}
+
+func (dao *VisitDAO) FindAllWithPatient(ctx context.Context, where string, sort string, args ...interface{}) ([]*Visit, error) {
+	models, err := dao.FindAll(ctx, where, sort, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.PreloadPatient(ctx, models); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *VisitDAO) JoinPatient(ctx context.Context, where string, sort string, args ...interface{}) ([]*VisitWithPatient, error) {
+	query := `
+		SELECT "visits"."id" AS "visits__id", "visits"."patient_id" AS "visits__patient_id", "visits"."reason" AS "visits__reason", "patient"."id" AS "patient__id", "patient"."name" AS "patient__name", "patient"."ssn" AS "patient__ssn", "patient"."phone" AS "patient__phone", "patient"."notes" AS "patient__notes"
+		FROM "visits" "visits"
+		INNER JOIN "patients" "patient" ON "patient"."id" = "visits"."patient_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*VisitWithPatient
+	for rows.Next() {
+		var m Visit
+		var related Patient
+		err := rows.Scan(
+			&m.ID,
+			&m.PatientID,
+			&m.Reason,
+			&related.ID,
+			&related.Name,
+			encryptedColumn{dao.encryptor, &related.SSN},
+			encryptedColumn{dao.encryptor, &related.Phone},
+			encryptedColumn{dao.encryptor, &related.Notes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &VisitWithPatient{Visit: m}
+		result.Patient = &related
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *VisitDAO) LeftJoinPatient(ctx context.Context, where string, sort string, args ...interface{}) ([]*VisitWithPatient, error) {
+	query := `
+		SELECT "visits"."id" AS "visits__id", "visits"."patient_id" AS "visits__patient_id", "visits"."reason" AS "visits__reason", "patient"."id" AS "patient__id", "patient"."name" AS "patient__name", "patient"."ssn" AS "patient__ssn", "patient"."phone" AS "patient__phone", "patient"."notes" AS "patient__notes"
+		FROM "visits" "visits"
+		LEFT JOIN "patients" "patient" ON "patient"."id" = "visits"."patient_id"
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*VisitWithPatient
+	for rows.Next() {
+		var m Visit
+		var related Patient
+		var found bool
+		err := rows.Scan(
+			&m.ID,
+			&m.PatientID,
+			&m.Reason,
+			joinedColumn(&found, nullableValue(&related.ID)),
+			joinedColumn(&found, nullableValue(&related.Name)),
+			joinedColumn(&found, encryptedColumn{dao.encryptor, &related.SSN}),
+			joinedColumn(&found, encryptedColumn{dao.encryptor, &related.Phone}),
+			joinedColumn(&found, encryptedColumn{dao.encryptor, &related.Notes}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		result := &VisitWithPatient{Visit: m}
+		if found {
+			result.Patient = &related
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (dao *VisitDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
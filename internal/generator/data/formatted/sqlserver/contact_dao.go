@@ -0,0 +1,803 @@
+package sqlserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+	"time"
+)
+
+type Contact = models.Contact
+
+type ContactDAO struct {
+	db *sql.DB
+}
+
+func NewContactDAO(db *sql.DB) *ContactDAO {
+	return &ContactDAO{db: db}
+}
+
+func (dao *ContactDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *ContactDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *ContactDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *ContactDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *ContactDAO) Create(ctx context.Context, m *Contact) error {
+	query := `
+		INSERT INTO [contacts] ([id], [name], [bio], [age], [last_seen], [phone])
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Name,
+		m.Bio,
+		m.Age,
+		nullIfZero(m.LastSeen),
+		m.Phone,
+	)
+
+	return err
+}
+
+func (dao *ContactDAO) Update(ctx context.Context, m *Contact) error {
+	query := `
+		UPDATE [contacts]
+		SET [name] = @p1,
+			[bio] = @p2,
+			[age] = @p3,
+			[last_seen] = @p4,
+			[phone] = @p5
+		WHERE [id] = @p6
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.Name,
+		m.Bio,
+		m.Age,
+		nullIfZero(m.LastSeen),
+		m.Phone,
+		m.ID,
+	)
+	return err
+}
+
+func (dao *ContactDAO) PartialUpdate(ctx context.Context, pk int, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "bio", "age", "last_seen", "phone":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "last_seen":
+			value = nullIfZero(value)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("[%s] = @p%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE [contacts] SET %s WHERE [id] = @p%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ContactDAO) DeleteByPk(ctx context.Context, pk int) error {
+	query := `DELETE FROM [contacts] WHERE [id] = @p1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *ContactDAO) FindByPk(ctx context.Context, pk int) (*Contact, error) {
+	query := `
+		SELECT [id], [name], [bio], [age], [last_seen], [phone]
+		FROM [contacts]
+		WHERE [id] = @p1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Contact
+	var nullBio sql.NullString
+	var nullAge sql.NullInt64
+	var nullLastSeen sql.NullTime
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		&nullBio,
+		&nullAge,
+		&nullLastSeen,
+		&m.Phone,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.Bio = nullBio.String
+	m.Age = int(nullAge.Int64)
+	m.LastSeen = nullLastSeen.Time
+
+	return &m, nil
+}
+
+func (dao *ContactDAO) CreateMany(ctx context.Context, models []*Contact) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*6)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(@p%d, @p%d, @p%d, @p%d, @p%d, @p%d)",
+			i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+
+		args = append(args,
+			model.ID,
+			model.Name,
+			model.Bio,
+			model.Age,
+			nullIfZero(model.LastSeen),
+			model.Phone,
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO [contacts] ([id], [name], [bio], [age], [last_seen], [phone])
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ContactDAO) UpdateMany(ctx context.Context, models []*Contact) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE [contacts]
+		SET [name] = @p1,
+			[bio] = @p2,
+			[age] = @p3,
+			[last_seen] = @p4,
+			[phone] = @p5
+		WHERE [id] = @p6
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Name,
+			model.Bio,
+			model.Age,
+			nullIfZero(model.LastSeen),
+			model.Phone,
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *ContactDAO) DeleteManyByPks(ctx context.Context, pks []int) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf("@p%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM [contacts] WHERE [id] IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *ContactDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ContactDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "name", "bio", "age", "last_seen", "phone":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "last_seen":
+			value = nullIfZero(value)
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "["+field+"] = "+fmt.Sprintf("@p%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE [contacts] SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *ContactDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Contact, error) {
+	query := `
+		SELECT [id], [name], [bio], [age], [last_seen], [phone]
+		FROM [contacts]
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Contact
+	var nullBio sql.NullString
+	var nullAge sql.NullInt64
+	var nullLastSeen sql.NullTime
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		&nullBio,
+		&nullAge,
+		&nullLastSeen,
+		&m.Phone,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.Bio = nullBio.String
+	m.Age = int(nullAge.Int64)
+	m.LastSeen = nullLastSeen.Time
+
+	return &m, nil
+}
+
+func (dao *ContactDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Contact, error) {
+	query := `
+		SELECT [id], [name], [bio], [age], [last_seen], [phone]
+		FROM [contacts]
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Contact
+	for rows.Next() {
+		var m Contact
+		var nullBio sql.NullString
+		var nullAge sql.NullInt64
+		var nullLastSeen sql.NullTime
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&nullBio,
+			&nullAge,
+			&nullLastSeen,
+			&m.Phone,
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.Bio = nullBio.String
+		m.Age = int(nullAge.Int64)
+		m.LastSeen = nullLastSeen.Time
+
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ContactDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Contact, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "name", "bio", "age", "last_seen", "phone":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "[" + column + "]"
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM [contacts]`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Contact
+	for rows.Next() {
+		var m Contact
+		var nullBio sql.NullString
+		var nullAge sql.NullInt64
+		var nullLastSeen sql.NullTime
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "name":
+				dest[i] = &m.Name
+			case "bio":
+				dest[i] = &nullBio
+			case "age":
+				dest[i] = &nullAge
+			case "last_seen":
+				dest[i] = &nullLastSeen
+			case "phone":
+				dest[i] = &m.Phone
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		m.Bio = nullBio.String
+		m.Age = int(nullAge.Int64)
+		m.LastSeen = nullLastSeen.Time
+
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ContactDAO) FindByPkForUpdate(ctx context.Context, pk int, lock LockMode) (*Contact, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT [id], [name], [bio], [age], [last_seen], [phone] FROM [contacts]` + lockHint(lock) + ` WHERE [id] = @p1`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Contact
+	var nullBio sql.NullString
+	var nullAge sql.NullInt64
+	var nullLastSeen sql.NullTime
+	err := row.Scan(
+		&m.ID,
+		&m.Name,
+		&nullBio,
+		&nullAge,
+		&nullLastSeen,
+		&m.Phone,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.Bio = nullBio.String
+	m.Age = int(nullAge.Int64)
+	m.LastSeen = nullLastSeen.Time
+
+	return &m, nil
+}
+
+func (dao *ContactDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Contact, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT [id], [name], [bio], [age], [last_seen], [phone] FROM [contacts]` + lockHint(lock) + ``
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Contact
+	for rows.Next() {
+		var m Contact
+		var nullBio sql.NullString
+		var nullAge sql.NullInt64
+		var nullLastSeen sql.NullTime
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&nullBio,
+			&nullAge,
+			&nullLastSeen,
+			&m.Phone,
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.Bio = nullBio.String
+		m.Age = int(nullAge.Int64)
+		m.LastSeen = nullLastSeen.Time
+
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ContactDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Contact, error) {
+	query := `
+		SELECT [id], [name], [bio], [age], [last_seen], [phone]
+		FROM [contacts]
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	} else {
+		query += " ORDER BY (SELECT NULL)"
+	}
+
+	query += fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Contact
+	for rows.Next() {
+		var m Contact
+		var nullBio sql.NullString
+		var nullAge sql.NullInt64
+		var nullLastSeen sql.NullTime
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&nullBio,
+			&nullAge,
+			&nullLastSeen,
+			&m.Phone,
+		)
+		if err != nil {
+			return nil, err
+		}
+		m.Bio = nullBio.String
+		m.Age = int(nullAge.Int64)
+		m.LastSeen = nullLastSeen.Time
+
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *ContactDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ContactDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT TOP 1 1 FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ContactDAO) ExistsByPk(ctx context.Context, pk int) (bool, error) {
+	query := `SELECT 1 FROM [contacts] WHERE [id] = @p1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *ContactDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "name", "bio", "age", "last_seen", "phone":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT [%s]) FROM [contacts]`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *ContactDAO) SumAge(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT SUM([age]) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullInt64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Int64, nil
+}
+
+func (dao *ContactDAO) AvgAge(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG(CAST([age] AS FLOAT)) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *ContactDAO) MinAge(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MIN([age]) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ContactDAO) MaxAge(ctx context.Context, where string, args ...interface{}) (int, bool, error) {
+	query := `SELECT MAX([age]) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[int]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ContactDAO) MinLastSeen(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MIN([last_seen]) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ContactDAO) MaxLastSeen(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MAX([last_seen]) FROM [contacts]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *ContactDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package sqlserver
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// ErrUnknownColumn is returned when a method is given a column name that is
+// not a column of the model of the DAO.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// ErrFullTable is returned when DeleteWhere or UpdateWhere is called with an
+// empty where clause and a context not created by AllowFullTable.
+var ErrFullTable = errors.New("refusing to write every row without AllowFullTable")
+
+type fullTableKey struct{}
+
+// AllowFullTable returns a copy of ctx letting DeleteWhere and UpdateWhere
+// run with an empty where clause, writing every row of the table.
+func AllowFullTable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fullTableKey{}, true)
+}
+
+func fullTableAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(fullTableKey{}).(bool)
+	return allowed
+}
+
+// LockMode is how FindByPkForUpdate and FindAllForUpdate behave when a row
+// they read is already locked by another transaction. It is an int, as in the
+// package of the DAO interfaces and every driver package, so that the DAOs of
+// any driver implement the interfaces. The lock methods reject values other
+// than the modes below.
+type LockMode = int
+
+const (
+	// LockWait waits for the other transaction to release the row.
+	LockWait LockMode = iota
+	// LockNoWait fails instead of waiting.
+	LockNoWait
+	// LockSkipLocked leaves the locked rows out of the results.
+	LockSkipLocked
+)
+
+// ErrNoTransaction is returned when rows are locked with a context not
+// carrying a transaction started by WithTransaction.
+var ErrNoTransaction = errors.New("locking rows requires a transaction")
+
+// ErrUnknownLockMode is returned when rows are locked with a LockMode other
+// than LockWait, LockNoWait and LockSkipLocked.
+var ErrUnknownLockMode = errors.New("unknown lock mode")
+
+func lockHint(lock LockMode) string {
+	switch lock {
+	case LockNoWait:
+		return " WITH (UPDLOCK, ROWLOCK, NOWAIT)"
+	case LockSkipLocked:
+		return " WITH (UPDLOCK, ROWLOCK, READPAST)"
+	default:
+		return " WITH (UPDLOCK, ROWLOCK)"
+	}
+}
+
+// ErrStaleObject is returned when an update of a versioned record matches no
+// row because the record was modified since it was read.
+var ErrStaleObject = errors.New("stale object: record was modified concurrently")
+
+// ErrMissingTenant is returned when a tenant scoped DAO is used with a
+// context carrying no tenant convertible to the tenant field of its model.
+var ErrMissingTenant = errors.New("missing tenant in context")
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx scoping tenant aware DAOs to the given
+// tenant. id is converted to the type of the tenant field of each model,
+// between integer types or from a type of the same kind.
+func WithTenant(ctx context.Context, id interface{}) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// tenantValue stores the tenant of ctx in target, a pointer to a tenant
+// field, converting between integer types as long as the value fits, and
+// reports whether ctx carries a tenant of a type convertible to it.
+func tenantValue(ctx context.Context, target interface{}) bool {
+	value := reflect.ValueOf(ctx.Value(tenantKey{}))
+	dest := reflect.ValueOf(target).Elem()
+	if !value.IsValid() {
+		return false
+	}
+
+	switch {
+	case value.Type() == dest.Type():
+		dest.Set(value)
+	case value.CanInt() && dest.CanInt():
+		if dest.OverflowInt(value.Int()) {
+			return false
+		}
+		dest.SetInt(value.Int())
+	case value.CanInt() && dest.CanUint():
+		if value.Int() < 0 || dest.OverflowUint(uint64(value.Int())) {
+			return false
+		}
+		dest.SetUint(uint64(value.Int()))
+	case value.CanUint() && dest.CanUint():
+		if dest.OverflowUint(value.Uint()) {
+			return false
+		}
+		dest.SetUint(value.Uint())
+	case value.CanUint() && dest.CanInt():
+		if value.Uint() > math.MaxInt64 || dest.OverflowInt(int64(value.Uint())) {
+			return false
+		}
+		dest.SetInt(int64(value.Uint()))
+	case value.Kind() == dest.Kind() && value.Type().ConvertibleTo(dest.Type()):
+		dest.Set(value.Convert(dest.Type()))
+	default:
+		return false
+	}
+	return true
+}
+
+// jsonColumn marshals v into a JSON column on write and unmarshals the column
+// into v, which must then be a pointer, on scan.
+type jsonColumn struct {
+	v interface{}
+}
+
+func (c jsonColumn) Value() (driver.Value, error) {
+	data, err := json.Marshal(c.v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c jsonColumn) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, c.v)
+	case string:
+		return json.Unmarshal([]byte(data), c.v)
+	}
+	return fmt.Errorf("cannot scan %T into a JSON column", src)
+}
+
+// nullIfZero returns nil, written as NULL, for the zero value of v.
+func nullIfZero(v interface{}) interface{} {
+	if z, ok := v.(interface{ IsZero() bool }); ok {
+		if z.IsZero() {
+			return nil
+		}
+		return v
+	}
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return nil
+	}
+	return v
+}
+
+type scannerFunc func(src interface{}) error
+
+func (f scannerFunc) Scan(src interface{}) error {
+	return f(src)
+}
+
+// nullableValue scans a column into dest, leaving the zero value for NULL.
+func nullableValue[T any](dest *T) sql.Scanner {
+	return scannerFunc(func(src interface{}) error {
+		var value sql.Null[T]
+		if err := value.Scan(src); err != nil {
+			return err
+		}
+		*dest = value.V
+		return nil
+	})
+}
+
+type valuerFunc func() (driver.Value, error)
+
+func (f valuerFunc) Value() (driver.Value, error) {
+	return f()
+}
+
+// convertedValue encodes value with encode when it has the type encode
+// converts, and returns it unchanged otherwise.
+func convertedValue[T any](value interface{}, encode func(T) (driver.Value, error)) interface{} {
+	v, ok := value.(T)
+	if !ok {
+		return value
+	}
+	return valuerFunc(func() (driver.Value, error) {
+		return encode(v)
+	})
+}
+
+// convertedScanner scans a column into dest through decode.
+func convertedScanner[T any](dest *T, decode func(interface{}) (T, error)) sql.Scanner {
+	return scannerFunc(func(src interface{}) error {
+		v, err := decode(src)
+		if err != nil {
+			return err
+		}
+		*dest = v
+		return nil
+	})
+}
+
+// joinedColumn scans a column of the optional side of a LEFT JOIN through
+// scanner, skipping NULL and recording in found whether the column held a value.
+func joinedColumn(found *bool, scanner sql.Scanner) sql.Scanner {
+	return scannerFunc(func(src interface{}) error {
+		if src == nil {
+			return nil
+		}
+		*found = true
+		return scanner.Scan(src)
+	})
+}
+
+// inListBatchSize is the most keys the relation and queue methods bind in one
+// IN list, splitting longer lists into several queries to stay within the
+// limits of sqlserver on list items and query parameters.
+const inListBatchSize = 2000
+
+// ErrNotClaimed is returned by Ack and Release for a row of a queue model that
+// is not claimed, either because it does not exist or because its lease expired
+// and was reaped. The error names the model and the primary key of the row.
+var ErrNotClaimed = errors.New("not claimed")
+
+// ErrInvalidEnum is returned when a model is written with an enum field
+// holding a value outside the constants declared for its type.
+var ErrInvalidEnum = errors.New("invalid enum value")
+
+// Encryptor encrypts the values of encrypted columns before they are written
+// and decrypts them when they are scanned.
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// NewAESEncryptor returns an Encryptor sealing values with AES-GCM under key,
+// which must be 16, 24 or 32 bytes long. Each value is stored as a random
+// nonce followed by the sealed value.
+func NewAESEncryptor(key []byte) (Encryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return aesEncryptor{aead: aead}, nil
+}
+
+type aesEncryptor struct {
+	aead cipher.AEAD
+}
+
+func (e aesEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return e.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (e aesEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	size := e.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errors.New("encrypted value is too short")
+	}
+	return e.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+}
+
+// encryptedColumn encrypts v, a string or []byte, on write and decrypts the
+// column into v, which must then be a *string or *[]byte, on scan. A nil v
+// is written as NULL.
+type encryptedColumn struct {
+	encryptor Encryptor
+	v         interface{}
+}
+
+func (c encryptedColumn) Value() (driver.Value, error) {
+	switch v := c.v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return c.encryptor.Encrypt([]byte(v))
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+		return c.encryptor.Encrypt(v)
+	}
+	return nil, fmt.Errorf("cannot encrypt %T", c.v)
+}
+
+func (c encryptedColumn) Scan(src interface{}) error {
+	var ciphertext []byte
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		ciphertext = data
+	case string:
+		ciphertext = []byte(data)
+	default:
+		return fmt.Errorf("cannot scan %T into an encrypted column", src)
+	}
+
+	plaintext, err := c.encryptor.Decrypt(ciphertext)
+	if err != nil {
+		return err
+	}
+
+	switch dest := c.v.(type) {
+	case *string:
+		*dest = string(plaintext)
+	case *[]byte:
+		*dest = plaintext
+	default:
+		return fmt.Errorf("cannot decrypt into %T", c.v)
+	}
+	return nil
+}
@@ -0,0 +1,828 @@
+package sqlserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
+	"strings"
+	"time"
+)
+
+type Payment = models.Payment
+
+type PaymentDAO struct {
+	db *sql.DB
+}
+
+func NewPaymentDAO(db *sql.DB) *PaymentDAO {
+	return &PaymentDAO{db: db}
+}
+
+func (dao *PaymentDAO) getTx(ctx context.Context) *sql.Tx {
+	if tx, ok := ctx.Value("currentTx").(*sql.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+func (dao *PaymentDAO) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return dao.db.ExecContext(ctx, query, args...)
+}
+
+func (dao *PaymentDAO) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return dao.db.QueryRowContext(ctx, query, args...)
+}
+
+func (dao *PaymentDAO) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := dao.getTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return dao.db.QueryContext(ctx, query, args...)
+}
+
+func (dao *PaymentDAO) Create(ctx context.Context, m *Payment) error {
+	query := `
+		INSERT INTO [payments] ([id], [reference], [code], [amount], [fee], [paid_on], [attributes])
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7)
+	`
+
+	_, err := dao.execContext(
+		ctx,
+		query,
+		m.ID,
+		m.Reference,
+		m.Code,
+		m.Amount,
+		m.Fee,
+		m.PaidOn,
+		jsonColumn{m.Attributes},
+	)
+
+	return err
+}
+
+func (dao *PaymentDAO) Update(ctx context.Context, m *Payment) error {
+	query := `
+		UPDATE [payments]
+		SET [reference] = @p1,
+			[code] = @p2,
+			[amount] = @p3,
+			[fee] = @p4,
+			[paid_on] = @p5,
+			[attributes] = @p6
+		WHERE [id] = @p7
+	`
+
+	_, err := dao.execContext(ctx, query,
+		m.Reference,
+		m.Code,
+		m.Amount,
+		m.Fee,
+		m.PaidOn,
+		jsonColumn{m.Attributes},
+		m.ID,
+	)
+	return err
+}
+
+func (dao *PaymentDAO) PartialUpdate(ctx context.Context, pk string, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	i := 1
+
+	for field, value := range fields {
+		switch field {
+		case "id", "reference", "code", "amount", "fee", "paid_on", "attributes":
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "attributes":
+			value = jsonColumn{value}
+		}
+		setClauses = append(setClauses, fmt.Sprintf("[%s] = @p%d", field, i))
+		args = append(args, value)
+		i++
+	}
+
+	args = append(args, pk)
+
+	query := fmt.Sprintf(`UPDATE [payments] SET %s WHERE [id] = @p%d`, strings.Join(setClauses, ", "), i)
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PaymentDAO) DeleteByPk(ctx context.Context, pk string) error {
+	query := `DELETE FROM [payments] WHERE [id] = @p1`
+	_, err := dao.execContext(ctx, query, pk)
+	return err
+}
+
+func (dao *PaymentDAO) FindByPk(ctx context.Context, pk string) (*Payment, error) {
+	query := `
+		SELECT [id], [reference], [code], [amount], [fee], [paid_on], [attributes]
+		FROM [payments]
+		WHERE [id] = @p1
+	`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Payment
+	err := row.Scan(
+		&m.ID,
+		&m.Reference,
+		&m.Code,
+		&m.Amount,
+		&m.Fee,
+		&m.PaidOn,
+		jsonColumn{&m.Attributes},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PaymentDAO) CreateMany(ctx context.Context, models []*Payment) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(models))
+	args := make([]interface{}, 0, len(models)*7)
+
+	for i, model := range models {
+		placeholders[i] = fmt.Sprintf("(@p%d, @p%d, @p%d, @p%d, @p%d, @p%d, @p%d)",
+			i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7)
+
+		args = append(args,
+			model.ID,
+			model.Reference,
+			model.Code,
+			model.Amount,
+			model.Fee,
+			model.PaidOn,
+			jsonColumn{model.Attributes},
+		)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO [payments] ([id], [reference], [code], [amount], [fee], [paid_on], [attributes])
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PaymentDAO) UpdateMany(ctx context.Context, models []*Payment) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE [payments]
+		SET [reference] = @p1,
+			[code] = @p2,
+			[amount] = @p3,
+			[fee] = @p4,
+			[paid_on] = @p5,
+			[attributes] = @p6
+		WHERE [id] = @p7
+	`
+
+	for _, model := range models {
+		_, err := dao.execContext(ctx, query,
+			model.Reference,
+			model.Code,
+			model.Amount,
+			model.Fee,
+			model.PaidOn,
+			jsonColumn{model.Attributes},
+			model.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (dao *PaymentDAO) DeleteManyByPks(ctx context.Context, pks []string) error {
+	if len(pks) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(pks))
+	args := make([]interface{}, len(pks))
+	for i, pk := range pks {
+		placeholders[i] = fmt.Sprintf("@p%d", i+1)
+		args[i] = pk
+	}
+
+	query := fmt.Sprintf(`DELETE FROM [payments] WHERE [id] IN (%s)`, strings.Join(placeholders, ","))
+	_, err := dao.execContext(ctx, query, args...)
+	return err
+}
+
+func (dao *PaymentDAO) DeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	query := `DELETE FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PaymentDAO) UpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error) {
+	if where == "" && !fullTableAllowed(ctx) {
+		return 0, ErrFullTable
+	}
+
+	if len(fields) == 0 {
+		return 0, nil
+	}
+
+	setClauses := make([]string, 0, len(fields))
+	setArgs := make([]interface{}, 0, len(fields))
+	for field, value := range fields {
+		switch field {
+		case "id", "reference", "code", "amount", "fee", "paid_on", "attributes":
+		default:
+			return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, field)
+		}
+		switch field {
+		case "attributes":
+			value = jsonColumn{value}
+		}
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, "["+field+"] = "+fmt.Sprintf("@p%d", len(args)+len(setArgs)))
+	}
+	if len(setClauses) == 0 {
+		return 0, nil
+	}
+	args = append(args, setArgs...)
+
+	query := fmt.Sprintf(`UPDATE [payments] SET %s`, strings.Join(setClauses, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	result, err := dao.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (dao *PaymentDAO) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*Payment, error) {
+	query := `
+		SELECT [id], [reference], [code], [amount], [fee], [paid_on], [attributes]
+		FROM [payments]
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var m Payment
+	err := row.Scan(
+		&m.ID,
+		&m.Reference,
+		&m.Code,
+		&m.Amount,
+		&m.Fee,
+		&m.PaidOn,
+		jsonColumn{&m.Attributes},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PaymentDAO) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*Payment, error) {
+	query := `
+		SELECT [id], [reference], [code], [amount], [fee], [paid_on], [attributes]
+		FROM [payments]
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Payment
+	for rows.Next() {
+		var m Payment
+		err := rows.Scan(
+			&m.ID,
+			&m.Reference,
+			&m.Code,
+			&m.Amount,
+			&m.Fee,
+			&m.PaidOn,
+			jsonColumn{&m.Attributes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PaymentDAO) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*Payment, error) {
+	if len(columns) == 0 {
+		return dao.FindAll(ctx, where, sort, args...)
+	}
+
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id", "reference", "code", "amount", "fee", "paid_on", "attributes":
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+		}
+		selected[i] = "[" + column + "]"
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM [payments]`, strings.Join(selected, ", "))
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Payment
+	for rows.Next() {
+		var m Payment
+		dest := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dest[i] = &m.ID
+			case "reference":
+				dest[i] = &m.Reference
+			case "code":
+				dest[i] = &m.Code
+			case "amount":
+				dest[i] = &m.Amount
+			case "fee":
+				dest[i] = &m.Fee
+			case "paid_on":
+				dest[i] = &m.PaidOn
+			case "attributes":
+				dest[i] = jsonColumn{&m.Attributes}
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PaymentDAO) FindByPkForUpdate(ctx context.Context, pk string, lock LockMode) (*Payment, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT [id], [reference], [code], [amount], [fee], [paid_on], [attributes] FROM [payments]` + lockHint(lock) + ` WHERE [id] = @p1`
+	row := dao.queryRowContext(ctx, query, pk)
+
+	var m Payment
+	err := row.Scan(
+		&m.ID,
+		&m.Reference,
+		&m.Code,
+		&m.Amount,
+		&m.Fee,
+		&m.PaidOn,
+		jsonColumn{&m.Attributes},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PaymentDAO) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*Payment, error) {
+	switch lock {
+	case LockWait, LockNoWait, LockSkipLocked:
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownLockMode, lock)
+	}
+
+	if dao.getTx(ctx) == nil {
+		return nil, ErrNoTransaction
+	}
+
+	query := `SELECT [id], [reference], [code], [amount], [fee], [paid_on], [attributes] FROM [payments]` + lockHint(lock) + ``
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	}
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Payment
+	for rows.Next() {
+		var m Payment
+		err := rows.Scan(
+			&m.ID,
+			&m.Reference,
+			&m.Code,
+			&m.Amount,
+			&m.Fee,
+			&m.PaidOn,
+			jsonColumn{&m.Attributes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PaymentDAO) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*Payment, error) {
+	query := `
+		SELECT [id], [reference], [code], [amount], [fee], [paid_on], [attributes]
+		FROM [payments]
+	`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	if sort != "" {
+		query += " ORDER BY " + sort
+	} else {
+		query += " ORDER BY (SELECT NULL)"
+	}
+
+	query += fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+
+	rows, err := dao.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []*Payment
+	for rows.Next() {
+		var m Payment
+		err := rows.Scan(
+			&m.ID,
+			&m.Reference,
+			&m.Code,
+			&m.Amount,
+			&m.Fee,
+			&m.PaidOn,
+			jsonColumn{&m.Attributes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (dao *PaymentDAO) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	query := `SELECT COUNT(*) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	row := dao.queryRowContext(ctx, query, args...)
+
+	var count int64
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PaymentDAO) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {
+	query := `SELECT TOP 1 1 FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PaymentDAO) ExistsByPk(ctx context.Context, pk string) (bool, error) {
+	query := `SELECT 1 FROM [payments] WHERE [id] = @p1`
+	args := []interface{}{pk}
+
+	var one int
+	err := dao.queryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (dao *PaymentDAO) CountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error) {
+	switch column {
+	case "id", "reference", "code", "amount", "fee", "paid_on", "attributes":
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownColumn, column)
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT [%s]) FROM [payments]`, column)
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var count int64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (dao *PaymentDAO) SumAmount(ctx context.Context, where string, args ...interface{}) (float64, error) {
+	query := `SELECT SUM([amount]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Float64, nil
+}
+
+func (dao *PaymentDAO) AvgAmount(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG(CAST([amount] AS FLOAT)) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *PaymentDAO) MinAmount(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MIN([amount]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PaymentDAO) MaxAmount(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MAX([amount]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PaymentDAO) SumFee(ctx context.Context, where string, args ...interface{}) (float64, error) {
+	query := `SELECT SUM([fee]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var sum sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&sum); err != nil {
+		return 0, err
+	}
+
+	return sum.Float64, nil
+}
+
+func (dao *PaymentDAO) AvgFee(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT AVG(CAST([fee] AS FLOAT)) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var avg sql.NullFloat64
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&avg); err != nil {
+		return 0, false, err
+	}
+
+	return avg.Float64, avg.Valid, nil
+}
+
+func (dao *PaymentDAO) MinFee(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MIN([fee]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PaymentDAO) MaxFee(ctx context.Context, where string, args ...interface{}) (float64, bool, error) {
+	query := `SELECT MAX([fee]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[float64]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PaymentDAO) MinPaidOn(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MIN([paid_on]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PaymentDAO) MaxPaidOn(ctx context.Context, where string, args ...interface{}) (time.Time, bool, error) {
+	query := `SELECT MAX([paid_on]) FROM [payments]`
+
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	var value sql.Null[time.Time]
+	if err := dao.queryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return time.Time{}, false, err
+	}
+
+	return value.V, value.Valid, nil
+}
+
+func (dao *PaymentDAO) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := dao.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ctxWithTx := context.WithValue(ctx, "currentTx", tx)
+
+	err = fn(ctxWithTx)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
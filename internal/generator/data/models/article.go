@@ -0,0 +1,12 @@
+package models
+
+type Article struct {
+	ID      int    `sql:"id,primary"`
+	Title   string `sql:"title"`
+	Content string `sql:"content"`
+	Version int    `sql:"version,version"`
+}
+
+func (a *Article) TableName() string {
+	return "articles"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Contact struct {
+	ID       int            `sql:"id,primary"`
+	Name     string         `sql:"name"`
+	Bio      string         `sql:"bio,nullable"`
+	Age      int            `sql:"age,nullable"`
+	LastSeen time.Time      `sql:"last_seen,nullzero"`
+	Phone    sql.NullString `sql:"phone"`
+}
+
+func (c *Contact) TableName() string {
+	return "contacts"
+}
@@ -0,0 +1,12 @@
+package models
+
+type Document struct {
+	ID     int64    `sql:"id,primary"`
+	Title  string   `sql:"title"`
+	Labels []string `sql:"labels"`
+	Scores []int64  `sql:"scores"`
+}
+
+func (d *Document) TableName() string {
+	return "documents"
+}
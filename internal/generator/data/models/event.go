@@ -0,0 +1,12 @@
+package models
+
+type Event struct {
+	ID      int                    `sql:"id,primary"`
+	Name    string                 `sql:"name"`
+	Payload map[string]interface{} `sql:"payload,json"`
+	Tags    []string               `sql:"tags,json"`
+}
+
+func (e *Event) TableName() string {
+	return "events"
+}
@@ -0,0 +1,20 @@
+package models
+
+type Group struct {
+	ID    int     `sql:"id,primary"`
+	Name  string  `sql:"name"`
+	Roles []*Role `rel:"many_to_many,Role,group_roles,group_id,role_id"`
+}
+
+func (g *Group) TableName() string {
+	return "groups"
+}
+
+type Role struct {
+	ID   int64  `sql:"id,primary"`
+	Name string `sql:"name"`
+}
+
+func (r *Role) TableName() string {
+	return "roles"
+}
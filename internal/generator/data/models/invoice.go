@@ -0,0 +1,48 @@
+package models
+
+type Invoice struct {
+	ID       int     `sql:"id,primary"`
+	TenantID int64   `sql:"tenant_id,tenant"`
+	Number   string  `sql:"number"`
+	Amount   float64 `sql:"amount"`
+}
+
+func (i *Invoice) TableName() string {
+	return "invoices"
+}
+
+func (i *Invoice) Schema() string {
+	return "billing"
+}
+
+type InvoiceLine struct {
+	ID          int64    `sql:"id,primary"`
+	TenantID    int32    `sql:"tenant_id,tenant"`
+	InvoiceID   int      `sql:"invoice_id"`
+	Description string   `sql:"description"`
+	Invoice     *Invoice `rel:"belongs_to,Invoice,invoice_id"`
+	Taxes       []*Tax   `rel:"many_to_many,Tax,invoice_line_taxes,invoice_line_id,tax_id"`
+}
+
+func (l *InvoiceLine) TableName() string {
+	return "invoice_lines"
+}
+
+func (l *InvoiceLine) Schema() string {
+	return "billing"
+}
+
+type Tax struct {
+	ID       int64   `sql:"id,primary"`
+	TenantID int64   `sql:"tenant_id,tenant"`
+	Name     string  `sql:"name"`
+	Rate     float64 `sql:"rate"`
+}
+
+func (t *Tax) TableName() string {
+	return "taxes"
+}
+
+func (t *Tax) Schema() string {
+	return "billing"
+}
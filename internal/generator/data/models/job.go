@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+)
+
+//gormless:queue claimed=running
+type Job struct {
+	ID          int64      `sql:"id,primary"`
+	TenantID    int64      `sql:"tenant_id,tenant"`
+	Payload     string     `sql:"payload"`
+	Status      JobStatus  `sql:"status"`
+	LeasedUntil *time.Time `sql:"leased_until"`
+	Version     int        `sql:"version,version"`
+}
+
+func (j *Job) TableName() string {
+	return "jobs"
+}
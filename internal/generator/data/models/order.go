@@ -0,0 +1,25 @@
+package models
+
+type Order struct {
+	ID     int64        `sql:"id,primary"`
+	UserID *int         `sql:"user_id"`
+	Total  float64      `sql:"total"`
+	User   *User        `rel:"belongs_to,User,user_id"`
+	Items  []*OrderItem `rel:"has_many,OrderItem,order_id"`
+}
+
+func (o *Order) TableName() string {
+	return "orders"
+}
+
+type OrderItem struct {
+	ID       int64  `sql:"id,primary"`
+	OrderID  *int64 `sql:"order_id"`
+	Sku      string `sql:"sku"`
+	Quantity int    `sql:"quantity"`
+	Order    Order  `rel:"belongs_to,Order,order_id"`
+}
+
+func (o *OrderItem) TableName() string {
+	return "order_items"
+}
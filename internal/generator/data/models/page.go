@@ -0,0 +1,19 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Page struct {
+	ID        int64          `sql:"id,primary"`
+	Title     string         `sql:"title"`
+	Slug      string         `sql:"slug,generated"`
+	Excerpt   sql.NullString `sql:"excerpt,generated"`
+	UpdatedAt time.Time      `sql:"updated_at,generated"`
+	Version   int            `sql:"version,version"`
+}
+
+func (p *Page) TableName() string {
+	return "pages"
+}
@@ -0,0 +1,24 @@
+package models
+
+type Patient struct {
+	ID    int64  `sql:"id,primary"`
+	Name  string `sql:"name"`
+	SSN   string `sql:"ssn,encrypted"`
+	Phone string `sql:"phone,encrypted,nullzero"`
+	Notes []byte `sql:"notes,encrypted"`
+}
+
+func (p *Patient) TableName() string {
+	return "patients"
+}
+
+type Visit struct {
+	ID        int64    `sql:"id,primary"`
+	PatientID int64    `sql:"patient_id"`
+	Reason    string   `sql:"reason"`
+	Patient   *Patient `rel:"belongs_to,Patient,patient_id"`
+}
+
+func (v *Visit) TableName() string {
+	return "visits"
+}
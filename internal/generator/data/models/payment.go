@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type Payment struct {
+	ID         string            `sql:"id,primary,type:uuid"`
+	Reference  string            `sql:"reference,size:64"`
+	Code       string            `sql:"code,type:varchar(20)"`
+	Amount     float64           `sql:"amount,precision:12,scale:2"`
+	Fee        float64           `sql:"fee,type:numeric(8,2)"`
+	PaidOn     time.Time         `sql:"paid_on,type:date"`
+	Attributes map[string]string `sql:"attributes,json,notnull"`
+}
+
+func (p *Payment) TableName() string {
+	return "payments"
+}
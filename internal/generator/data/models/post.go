@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type Post struct {
+	ID        int        `sql:"id,primary"`
+	Title     string     `sql:"title"`
+	Body      string     `sql:"body"`
+	DeletedAt *time.Time `sql:"deleted_at,softdelete"`
+}
+
+func (p *Post) TableName() string {
+	return "posts"
+}
+
+type Comment struct {
+	ID     int64  `sql:"id,primary"`
+	PostID int    `sql:"post_id"`
+	Body   string `sql:"body"`
+	Post   *Post  `rel:"belongs_to,Post,post_id"`
+}
+
+func (c *Comment) TableName() string {
+	return "comments"
+}
@@ -0,0 +1,13 @@
+package models
+
+import "github.com/Jibaru/gormless/internal/generator/data/convert"
+
+type Product struct {
+	Code  convert.Code  `sql:"code,primary"`
+	Name  string        `sql:"name"`
+	Price convert.Cents `sql:"price"`
+}
+
+func (p *Product) TableName() string {
+	return "products"
+}
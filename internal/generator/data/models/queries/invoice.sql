@@ -0,0 +1,9 @@
+-- name: FindByNumber :one
+SELECT * FROM billing.invoices WHERE number = $1 AND tenant_id = $2;
+
+-- name: VoidAbove :execrows
+DELETE FROM billing.invoices WHERE tenant_id = $1 AND amount > $2;
+
+-- name: PurgeTenant :exec
+-- unscoped
+DELETE FROM billing.invoices WHERE tenant_id = $1;
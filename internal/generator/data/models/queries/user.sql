@@ -0,0 +1,20 @@
+-- name: ActiveAdults :many
+SELECT * FROM users
+WHERE age >= $1 AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: FindByEmail :one
+SELECT id, name, email, password, age, deleted_at FROM users WHERE email = $1;
+
+-- name: CountByAge :many
+-- type: last_deleted *time.Time
+SELECT age, COUNT(*) AS total, MAX(deleted_at) AS last_deleted
+FROM users
+WHERE name LIKE $1
+GROUP BY age;
+
+-- name: Rename :exec
+UPDATE users SET name = $1 WHERE id = $2;
+
+-- name: PurgeDeleted :execrows
+DELETE FROM users WHERE deleted_at < $1;
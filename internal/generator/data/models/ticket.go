@@ -0,0 +1,34 @@
+package models
+
+type TicketStatus string
+
+const (
+	TicketOpen   TicketStatus = "open"
+	TicketClosed TicketStatus = "closed"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityHigh
+)
+
+type TicketResolution string
+
+const (
+	ResolutionFixed   TicketResolution = "fixed"
+	ResolutionWontFix TicketResolution = "wontfix"
+)
+
+type Ticket struct {
+	ID         int              `sql:"id,primary"`
+	Title      string           `sql:"title"`
+	Status     TicketStatus     `sql:"status,groupby"`
+	Priority   Priority         `sql:"priority"`
+	Resolution TicketResolution `sql:"resolution,nullzero"`
+}
+
+func (t *Ticket) TableName() string {
+	return "tickets"
+}
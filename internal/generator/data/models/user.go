@@ -1,12 +1,14 @@
 package models
 
+import "time"
+
 type User struct {
-	ID        int     `sql:"id,primary"`
-	Name      string  `sql:"name"`
-	Email     *string `sql:"email"`
-	Password  string  `sql:"password"`
-	Age       int     `sql:"age"`
-	DeletedAt *string `sql:"deleted_at"`
+	ID        int        `sql:"id,primary"`
+	Name      string     `sql:"name"`
+	Email     *string    `sql:"email,groupby"`
+	Password  string     `sql:"password"`
+	Age       int        `sql:"age,groupby"`
+	DeletedAt *time.Time `sql:"deleted_at"`
 }
 
 func (u *User) TableName() string {
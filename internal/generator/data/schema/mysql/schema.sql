@@ -0,0 +1,163 @@
+CREATE TABLE `users` (
+    `id` BIGINT PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL,
+    `email` VARCHAR(255),
+    `password` VARCHAR(255) NOT NULL,
+    `age` BIGINT NOT NULL,
+    `deleted_at` DATETIME
+);
+
+CREATE TABLE `posts` (
+    `id` BIGINT PRIMARY KEY,
+    `title` VARCHAR(255) NOT NULL,
+    `body` VARCHAR(255) NOT NULL,
+    `deleted_at` DATETIME
+);
+
+CREATE TABLE `articles` (
+    `id` BIGINT PRIMARY KEY,
+    `title` VARCHAR(255) NOT NULL,
+    `content` VARCHAR(255) NOT NULL,
+    `version` BIGINT NOT NULL
+);
+
+CREATE TABLE `billing`.`invoices` (
+    `id` BIGINT PRIMARY KEY,
+    `tenant_id` BIGINT NOT NULL,
+    `number` VARCHAR(255) NOT NULL,
+    `amount` DOUBLE NOT NULL
+);
+
+CREATE TABLE `events` (
+    `id` BIGINT PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL,
+    `payload` JSON,
+    `tags` JSON
+);
+
+CREATE TABLE `contacts` (
+    `id` BIGINT PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL,
+    `bio` VARCHAR(255),
+    `age` BIGINT,
+    `last_seen` DATETIME,
+    `phone` VARCHAR(255)
+);
+
+CREATE TABLE `products` (
+    `code` VARCHAR(255) PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL,
+    `price` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE `tickets` (
+    `id` BIGINT PRIMARY KEY,
+    `title` VARCHAR(255) NOT NULL,
+    `status` ENUM('open', 'closed') NOT NULL,
+    `priority` BIGINT NOT NULL CHECK (`priority` IN (1, 2)),
+    `resolution` ENUM('fixed', 'wontfix')
+);
+
+CREATE TABLE `documents` (
+    `id` BIGINT PRIMARY KEY,
+    `title` VARCHAR(255) NOT NULL,
+    `labels` VARCHAR(255),
+    `scores` VARCHAR(255)
+);
+
+CREATE TABLE `pages` (
+    `id` BIGINT PRIMARY KEY,
+    `title` VARCHAR(255) NOT NULL,
+    `slug` VARCHAR(255),
+    `excerpt` VARCHAR(255),
+    `updated_at` DATETIME,
+    `version` BIGINT NOT NULL
+);
+
+CREATE TABLE `payments` (
+    `id` CHAR(36) PRIMARY KEY,
+    `reference` VARCHAR(64) NOT NULL,
+    `code` varchar(20) NOT NULL,
+    `amount` DECIMAL(12,2) NOT NULL,
+    `fee` numeric(8,2) NOT NULL,
+    `paid_on` date NOT NULL,
+    `attributes` JSON NOT NULL
+);
+
+CREATE TABLE `patients` (
+    `id` BIGINT PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL,
+    `ssn` BLOB NOT NULL,
+    `phone` BLOB,
+    `notes` BLOB
+);
+
+CREATE TABLE `jobs` (
+    `id` BIGINT PRIMARY KEY,
+    `tenant_id` BIGINT NOT NULL,
+    `payload` VARCHAR(255) NOT NULL,
+    `status` ENUM('pending', 'running', 'done') NOT NULL,
+    `leased_until` DATETIME,
+    `version` BIGINT NOT NULL
+);
+
+CREATE TABLE `orders` (
+    `id` BIGINT PRIMARY KEY,
+    `user_id` BIGINT,
+    `total` DOUBLE NOT NULL
+);
+
+CREATE TABLE `order_items` (
+    `id` BIGINT PRIMARY KEY,
+    `order_id` BIGINT,
+    `sku` VARCHAR(255) NOT NULL,
+    `quantity` BIGINT NOT NULL
+);
+
+CREATE TABLE `groups` (
+    `id` BIGINT PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE `roles` (
+    `id` BIGINT PRIMARY KEY,
+    `name` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE `billing`.`invoice_lines` (
+    `id` BIGINT PRIMARY KEY,
+    `tenant_id` BIGINT NOT NULL,
+    `invoice_id` BIGINT NOT NULL,
+    `description` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE `billing`.`taxes` (
+    `id` BIGINT PRIMARY KEY,
+    `tenant_id` BIGINT NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `rate` DOUBLE NOT NULL
+);
+
+CREATE TABLE `comments` (
+    `id` BIGINT PRIMARY KEY,
+    `post_id` BIGINT NOT NULL,
+    `body` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE `visits` (
+    `id` BIGINT PRIMARY KEY,
+    `patient_id` BIGINT NOT NULL,
+    `reason` VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE `group_roles` (
+    `group_id` BIGINT NOT NULL,
+    `role_id` BIGINT NOT NULL,
+    PRIMARY KEY (`group_id`, `role_id`)
+);
+
+CREATE TABLE `billing`.`invoice_line_taxes` (
+    `invoice_line_id` BIGINT NOT NULL,
+    `tax_id` BIGINT NOT NULL,
+    PRIMARY KEY (`invoice_line_id`, `tax_id`)
+);
@@ -0,0 +1,163 @@
+CREATE TABLE "users" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL,
+    "email" VARCHAR2(255),
+    "password" VARCHAR2(255) NOT NULL,
+    "age" NUMBER(19) NOT NULL,
+    "deleted_at" TIMESTAMP
+);
+
+CREATE TABLE "posts" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "title" VARCHAR2(255) NOT NULL,
+    "body" VARCHAR2(255) NOT NULL,
+    "deleted_at" TIMESTAMP
+);
+
+CREATE TABLE "articles" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "title" VARCHAR2(255) NOT NULL,
+    "content" VARCHAR2(255) NOT NULL,
+    "version" NUMBER(19) NOT NULL
+);
+
+CREATE TABLE "billing"."invoices" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "tenant_id" NUMBER(19) NOT NULL,
+    "number" VARCHAR2(255) NOT NULL,
+    "amount" BINARY_DOUBLE NOT NULL
+);
+
+CREATE TABLE "events" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL,
+    "payload" CLOB,
+    "tags" CLOB
+);
+
+CREATE TABLE "contacts" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL,
+    "bio" VARCHAR2(255),
+    "age" NUMBER(19),
+    "last_seen" TIMESTAMP,
+    "phone" VARCHAR2(255)
+);
+
+CREATE TABLE "products" (
+    "code" VARCHAR2(255) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL,
+    "price" VARCHAR2(255) NOT NULL
+);
+
+CREATE TABLE "tickets" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "title" VARCHAR2(255) NOT NULL,
+    "status" VARCHAR2(255) NOT NULL CHECK ("status" IN ('open', 'closed')),
+    "priority" NUMBER(19) NOT NULL CHECK ("priority" IN (1, 2)),
+    "resolution" VARCHAR2(255) CHECK ("resolution" IN ('fixed', 'wontfix'))
+);
+
+CREATE TABLE "documents" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "title" VARCHAR2(255) NOT NULL,
+    "labels" VARCHAR2(255),
+    "scores" VARCHAR2(255)
+);
+
+CREATE TABLE "pages" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "title" VARCHAR2(255) NOT NULL,
+    "slug" VARCHAR2(255),
+    "excerpt" VARCHAR2(255),
+    "updated_at" TIMESTAMP,
+    "version" NUMBER(19) NOT NULL
+);
+
+CREATE TABLE "payments" (
+    "id" CHAR(36) PRIMARY KEY,
+    "reference" VARCHAR2(64) NOT NULL,
+    "code" varchar(20) NOT NULL,
+    "amount" NUMBER(12,2) NOT NULL,
+    "fee" numeric(8,2) NOT NULL,
+    "paid_on" date NOT NULL,
+    "attributes" CLOB NOT NULL
+);
+
+CREATE TABLE "patients" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL,
+    "ssn" BLOB NOT NULL,
+    "phone" BLOB,
+    "notes" BLOB
+);
+
+CREATE TABLE "jobs" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "tenant_id" NUMBER(19) NOT NULL,
+    "payload" VARCHAR2(255) NOT NULL,
+    "status" VARCHAR2(255) NOT NULL CHECK ("status" IN ('pending', 'running', 'done')),
+    "leased_until" TIMESTAMP,
+    "version" NUMBER(19) NOT NULL
+);
+
+CREATE TABLE "orders" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "user_id" NUMBER(19),
+    "total" BINARY_DOUBLE NOT NULL
+);
+
+CREATE TABLE "order_items" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "order_id" NUMBER(19),
+    "sku" VARCHAR2(255) NOT NULL,
+    "quantity" NUMBER(19) NOT NULL
+);
+
+CREATE TABLE "groups" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL
+);
+
+CREATE TABLE "roles" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "name" VARCHAR2(255) NOT NULL
+);
+
+CREATE TABLE "billing"."invoice_lines" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "tenant_id" NUMBER(19) NOT NULL,
+    "invoice_id" NUMBER(19) NOT NULL,
+    "description" VARCHAR2(255) NOT NULL
+);
+
+CREATE TABLE "billing"."taxes" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "tenant_id" NUMBER(19) NOT NULL,
+    "name" VARCHAR2(255) NOT NULL,
+    "rate" BINARY_DOUBLE NOT NULL
+);
+
+CREATE TABLE "comments" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "post_id" NUMBER(19) NOT NULL,
+    "body" VARCHAR2(255) NOT NULL
+);
+
+CREATE TABLE "visits" (
+    "id" NUMBER(19) PRIMARY KEY,
+    "patient_id" NUMBER(19) NOT NULL,
+    "reason" VARCHAR2(255) NOT NULL
+);
+
+CREATE TABLE "group_roles" (
+    "group_id" NUMBER(19) NOT NULL,
+    "role_id" NUMBER(19) NOT NULL,
+    PRIMARY KEY ("group_id", "role_id")
+);
+
+CREATE TABLE "billing"."invoice_line_taxes" (
+    "invoice_line_id" NUMBER(19) NOT NULL,
+    "tax_id" NUMBER(19) NOT NULL,
+    PRIMARY KEY ("invoice_line_id", "tax_id")
+);
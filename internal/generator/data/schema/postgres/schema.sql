@@ -0,0 +1,169 @@
+CREATE TYPE "ticket_status" AS ENUM ('open', 'closed');
+
+CREATE TYPE "ticket_resolution" AS ENUM ('fixed', 'wontfix');
+
+CREATE TYPE "job_status" AS ENUM ('pending', 'running', 'done');
+
+CREATE TABLE "users" (
+    "id" BIGINT PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "email" TEXT,
+    "password" TEXT NOT NULL,
+    "age" BIGINT NOT NULL,
+    "deleted_at" TIMESTAMP
+);
+
+CREATE TABLE "posts" (
+    "id" BIGINT PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "deleted_at" TIMESTAMP
+);
+
+CREATE TABLE "articles" (
+    "id" BIGINT PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "content" TEXT NOT NULL,
+    "version" BIGINT NOT NULL
+);
+
+CREATE TABLE "billing"."invoices" (
+    "id" BIGINT PRIMARY KEY,
+    "tenant_id" BIGINT NOT NULL,
+    "number" TEXT NOT NULL,
+    "amount" DOUBLE PRECISION NOT NULL
+);
+
+CREATE TABLE "events" (
+    "id" BIGINT PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "payload" JSONB,
+    "tags" JSONB
+);
+
+CREATE TABLE "contacts" (
+    "id" BIGINT PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "bio" TEXT,
+    "age" BIGINT,
+    "last_seen" TIMESTAMP,
+    "phone" TEXT
+);
+
+CREATE TABLE "products" (
+    "code" TEXT PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "price" TEXT NOT NULL
+);
+
+CREATE TABLE "tickets" (
+    "id" BIGINT PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "status" "ticket_status" NOT NULL,
+    "priority" BIGINT NOT NULL CHECK ("priority" IN (1, 2)),
+    "resolution" "ticket_resolution"
+);
+
+CREATE TABLE "documents" (
+    "id" BIGINT PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "labels" TEXT[],
+    "scores" BIGINT[]
+);
+
+CREATE TABLE "pages" (
+    "id" BIGINT PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "slug" TEXT,
+    "excerpt" TEXT,
+    "updated_at" TIMESTAMP,
+    "version" BIGINT NOT NULL
+);
+
+CREATE TABLE "payments" (
+    "id" UUID PRIMARY KEY,
+    "reference" VARCHAR(64) NOT NULL,
+    "code" varchar(20) NOT NULL,
+    "amount" NUMERIC(12,2) NOT NULL,
+    "fee" numeric(8,2) NOT NULL,
+    "paid_on" date NOT NULL,
+    "attributes" JSONB NOT NULL
+);
+
+CREATE TABLE "patients" (
+    "id" BIGINT PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "ssn" BYTEA NOT NULL,
+    "phone" BYTEA,
+    "notes" BYTEA
+);
+
+CREATE TABLE "jobs" (
+    "id" BIGINT PRIMARY KEY,
+    "tenant_id" BIGINT NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" "job_status" NOT NULL,
+    "leased_until" TIMESTAMP,
+    "version" BIGINT NOT NULL
+);
+
+CREATE TABLE "orders" (
+    "id" BIGINT PRIMARY KEY,
+    "user_id" BIGINT,
+    "total" DOUBLE PRECISION NOT NULL
+);
+
+CREATE TABLE "order_items" (
+    "id" BIGINT PRIMARY KEY,
+    "order_id" BIGINT,
+    "sku" TEXT NOT NULL,
+    "quantity" BIGINT NOT NULL
+);
+
+CREATE TABLE "groups" (
+    "id" BIGINT PRIMARY KEY,
+    "name" TEXT NOT NULL
+);
+
+CREATE TABLE "roles" (
+    "id" BIGINT PRIMARY KEY,
+    "name" TEXT NOT NULL
+);
+
+CREATE TABLE "billing"."invoice_lines" (
+    "id" BIGINT PRIMARY KEY,
+    "tenant_id" BIGINT NOT NULL,
+    "invoice_id" BIGINT NOT NULL,
+    "description" TEXT NOT NULL
+);
+
+CREATE TABLE "billing"."taxes" (
+    "id" BIGINT PRIMARY KEY,
+    "tenant_id" BIGINT NOT NULL,
+    "name" TEXT NOT NULL,
+    "rate" DOUBLE PRECISION NOT NULL
+);
+
+CREATE TABLE "comments" (
+    "id" BIGINT PRIMARY KEY,
+    "post_id" BIGINT NOT NULL,
+    "body" TEXT NOT NULL
+);
+
+CREATE TABLE "visits" (
+    "id" BIGINT PRIMARY KEY,
+    "patient_id" BIGINT NOT NULL,
+    "reason" TEXT NOT NULL
+);
+
+CREATE TABLE "group_roles" (
+    "group_id" BIGINT NOT NULL,
+    "role_id" BIGINT NOT NULL,
+    PRIMARY KEY ("group_id", "role_id")
+);
+
+CREATE TABLE "billing"."invoice_line_taxes" (
+    "invoice_line_id" BIGINT NOT NULL,
+    "tax_id" BIGINT NOT NULL,
+    PRIMARY KEY ("invoice_line_id", "tax_id")
+);
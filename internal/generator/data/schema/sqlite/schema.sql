@@ -0,0 +1,163 @@
+CREATE TABLE "users" (
+    "id" INTEGER PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "email" TEXT,
+    "password" TEXT NOT NULL,
+    "age" INTEGER NOT NULL,
+    "deleted_at" DATETIME
+);
+
+CREATE TABLE "posts" (
+    "id" INTEGER PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "deleted_at" DATETIME
+);
+
+CREATE TABLE "articles" (
+    "id" INTEGER PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "content" TEXT NOT NULL,
+    "version" INTEGER NOT NULL
+);
+
+CREATE TABLE "billing"."invoices" (
+    "id" INTEGER PRIMARY KEY,
+    "tenant_id" INTEGER NOT NULL,
+    "number" TEXT NOT NULL,
+    "amount" REAL NOT NULL
+);
+
+CREATE TABLE "events" (
+    "id" INTEGER PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "payload" TEXT,
+    "tags" TEXT
+);
+
+CREATE TABLE "contacts" (
+    "id" INTEGER PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "bio" TEXT,
+    "age" INTEGER,
+    "last_seen" DATETIME,
+    "phone" TEXT
+);
+
+CREATE TABLE "products" (
+    "code" TEXT PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "price" TEXT NOT NULL
+);
+
+CREATE TABLE "tickets" (
+    "id" INTEGER PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "status" TEXT NOT NULL CHECK ("status" IN ('open', 'closed')),
+    "priority" INTEGER NOT NULL CHECK ("priority" IN (1, 2)),
+    "resolution" TEXT CHECK ("resolution" IN ('fixed', 'wontfix'))
+);
+
+CREATE TABLE "documents" (
+    "id" INTEGER PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "labels" TEXT,
+    "scores" TEXT
+);
+
+CREATE TABLE "pages" (
+    "id" INTEGER PRIMARY KEY,
+    "title" TEXT NOT NULL,
+    "slug" TEXT,
+    "excerpt" TEXT,
+    "updated_at" DATETIME,
+    "version" INTEGER NOT NULL
+);
+
+CREATE TABLE "payments" (
+    "id" TEXT PRIMARY KEY,
+    "reference" VARCHAR(64) NOT NULL,
+    "code" varchar(20) NOT NULL,
+    "amount" NUMERIC(12,2) NOT NULL,
+    "fee" numeric(8,2) NOT NULL,
+    "paid_on" date NOT NULL,
+    "attributes" TEXT NOT NULL
+);
+
+CREATE TABLE "patients" (
+    "id" INTEGER PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "ssn" BLOB NOT NULL,
+    "phone" BLOB,
+    "notes" BLOB
+);
+
+CREATE TABLE "jobs" (
+    "id" INTEGER PRIMARY KEY,
+    "tenant_id" INTEGER NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" TEXT NOT NULL CHECK ("status" IN ('pending', 'running', 'done')),
+    "leased_until" DATETIME,
+    "version" INTEGER NOT NULL
+);
+
+CREATE TABLE "orders" (
+    "id" INTEGER PRIMARY KEY,
+    "user_id" INTEGER,
+    "total" REAL NOT NULL
+);
+
+CREATE TABLE "order_items" (
+    "id" INTEGER PRIMARY KEY,
+    "order_id" INTEGER,
+    "sku" TEXT NOT NULL,
+    "quantity" INTEGER NOT NULL
+);
+
+CREATE TABLE "groups" (
+    "id" INTEGER PRIMARY KEY,
+    "name" TEXT NOT NULL
+);
+
+CREATE TABLE "roles" (
+    "id" INTEGER PRIMARY KEY,
+    "name" TEXT NOT NULL
+);
+
+CREATE TABLE "billing"."invoice_lines" (
+    "id" INTEGER PRIMARY KEY,
+    "tenant_id" INTEGER NOT NULL,
+    "invoice_id" INTEGER NOT NULL,
+    "description" TEXT NOT NULL
+);
+
+CREATE TABLE "billing"."taxes" (
+    "id" INTEGER PRIMARY KEY,
+    "tenant_id" INTEGER NOT NULL,
+    "name" TEXT NOT NULL,
+    "rate" REAL NOT NULL
+);
+
+CREATE TABLE "comments" (
+    "id" INTEGER PRIMARY KEY,
+    "post_id" INTEGER NOT NULL,
+    "body" TEXT NOT NULL
+);
+
+CREATE TABLE "visits" (
+    "id" INTEGER PRIMARY KEY,
+    "patient_id" INTEGER NOT NULL,
+    "reason" TEXT NOT NULL
+);
+
+CREATE TABLE "group_roles" (
+    "group_id" INTEGER NOT NULL,
+    "role_id" INTEGER NOT NULL,
+    PRIMARY KEY ("group_id", "role_id")
+);
+
+CREATE TABLE "billing"."invoice_line_taxes" (
+    "invoice_line_id" INTEGER NOT NULL,
+    "tax_id" INTEGER NOT NULL,
+    PRIMARY KEY ("invoice_line_id", "tax_id")
+);
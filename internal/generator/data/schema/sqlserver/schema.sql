@@ -0,0 +1,163 @@
+CREATE TABLE [users] (
+    [id] BIGINT PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL,
+    [email] NVARCHAR(255),
+    [password] NVARCHAR(255) NOT NULL,
+    [age] BIGINT NOT NULL,
+    [deleted_at] DATETIME2
+);
+
+CREATE TABLE [posts] (
+    [id] BIGINT PRIMARY KEY,
+    [title] NVARCHAR(255) NOT NULL,
+    [body] NVARCHAR(255) NOT NULL,
+    [deleted_at] DATETIME2
+);
+
+CREATE TABLE [articles] (
+    [id] BIGINT PRIMARY KEY,
+    [title] NVARCHAR(255) NOT NULL,
+    [content] NVARCHAR(255) NOT NULL,
+    [version] BIGINT NOT NULL
+);
+
+CREATE TABLE [billing].[invoices] (
+    [id] BIGINT PRIMARY KEY,
+    [tenant_id] BIGINT NOT NULL,
+    [number] NVARCHAR(255) NOT NULL,
+    [amount] FLOAT NOT NULL
+);
+
+CREATE TABLE [events] (
+    [id] BIGINT PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL,
+    [payload] NVARCHAR(MAX),
+    [tags] NVARCHAR(MAX)
+);
+
+CREATE TABLE [contacts] (
+    [id] BIGINT PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL,
+    [bio] NVARCHAR(255),
+    [age] BIGINT,
+    [last_seen] DATETIME2,
+    [phone] NVARCHAR(255)
+);
+
+CREATE TABLE [products] (
+    [code] NVARCHAR(255) PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL,
+    [price] NVARCHAR(255) NOT NULL
+);
+
+CREATE TABLE [tickets] (
+    [id] BIGINT PRIMARY KEY,
+    [title] NVARCHAR(255) NOT NULL,
+    [status] NVARCHAR(255) NOT NULL CHECK ([status] IN ('open', 'closed')),
+    [priority] BIGINT NOT NULL CHECK ([priority] IN (1, 2)),
+    [resolution] NVARCHAR(255) CHECK ([resolution] IN ('fixed', 'wontfix'))
+);
+
+CREATE TABLE [documents] (
+    [id] BIGINT PRIMARY KEY,
+    [title] NVARCHAR(255) NOT NULL,
+    [labels] NVARCHAR(255),
+    [scores] NVARCHAR(255)
+);
+
+CREATE TABLE [pages] (
+    [id] BIGINT PRIMARY KEY,
+    [title] NVARCHAR(255) NOT NULL,
+    [slug] NVARCHAR(255),
+    [excerpt] NVARCHAR(255),
+    [updated_at] DATETIME2,
+    [version] BIGINT NOT NULL
+);
+
+CREATE TABLE [payments] (
+    [id] UNIQUEIDENTIFIER PRIMARY KEY,
+    [reference] NVARCHAR(64) NOT NULL,
+    [code] varchar(20) NOT NULL,
+    [amount] DECIMAL(12,2) NOT NULL,
+    [fee] numeric(8,2) NOT NULL,
+    [paid_on] date NOT NULL,
+    [attributes] NVARCHAR(MAX) NOT NULL
+);
+
+CREATE TABLE [patients] (
+    [id] BIGINT PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL,
+    [ssn] VARBINARY(MAX) NOT NULL,
+    [phone] VARBINARY(MAX),
+    [notes] VARBINARY(MAX)
+);
+
+CREATE TABLE [jobs] (
+    [id] BIGINT PRIMARY KEY,
+    [tenant_id] BIGINT NOT NULL,
+    [payload] NVARCHAR(255) NOT NULL,
+    [status] NVARCHAR(255) NOT NULL CHECK ([status] IN ('pending', 'running', 'done')),
+    [leased_until] DATETIME2,
+    [version] BIGINT NOT NULL
+);
+
+CREATE TABLE [orders] (
+    [id] BIGINT PRIMARY KEY,
+    [user_id] BIGINT,
+    [total] FLOAT NOT NULL
+);
+
+CREATE TABLE [order_items] (
+    [id] BIGINT PRIMARY KEY,
+    [order_id] BIGINT,
+    [sku] NVARCHAR(255) NOT NULL,
+    [quantity] BIGINT NOT NULL
+);
+
+CREATE TABLE [groups] (
+    [id] BIGINT PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL
+);
+
+CREATE TABLE [roles] (
+    [id] BIGINT PRIMARY KEY,
+    [name] NVARCHAR(255) NOT NULL
+);
+
+CREATE TABLE [billing].[invoice_lines] (
+    [id] BIGINT PRIMARY KEY,
+    [tenant_id] BIGINT NOT NULL,
+    [invoice_id] BIGINT NOT NULL,
+    [description] NVARCHAR(255) NOT NULL
+);
+
+CREATE TABLE [billing].[taxes] (
+    [id] BIGINT PRIMARY KEY,
+    [tenant_id] BIGINT NOT NULL,
+    [name] NVARCHAR(255) NOT NULL,
+    [rate] FLOAT NOT NULL
+);
+
+CREATE TABLE [comments] (
+    [id] BIGINT PRIMARY KEY,
+    [post_id] BIGINT NOT NULL,
+    [body] NVARCHAR(255) NOT NULL
+);
+
+CREATE TABLE [visits] (
+    [id] BIGINT PRIMARY KEY,
+    [patient_id] BIGINT NOT NULL,
+    [reason] NVARCHAR(255) NOT NULL
+);
+
+CREATE TABLE [group_roles] (
+    [group_id] BIGINT NOT NULL,
+    [role_id] BIGINT NOT NULL,
+    PRIMARY KEY ([group_id], [role_id])
+);
+
+CREATE TABLE [billing].[invoice_line_taxes] (
+    [invoice_line_id] BIGINT NOT NULL,
+    [tax_id] BIGINT NOT NULL,
+    PRIMARY KEY ([invoice_line_id], [tax_id])
+);
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// dialect describes the SQL syntax differences shared generators need to know about.
+type dialect struct {
+	name        string
+	placeholder string
+	openQuote   string
+	closeQuote  string
+	// jsonCast wraps the placeholder of a JSON column, if the database needs it.
+	jsonCast string
+	// typeCast casts a placeholder to the type declared in a column tag, if
+	// the database needs it to convert the bound value, for the types in
+	// castTypes.
+	typeCast string
+	// averageCast wraps the column averaged by AVG, if the database would
+	// otherwise average integers as integers.
+	averageCast string
+	// topOne follows SELECT and limitOne ends a query to read only its first
+	// row, depending on which the database supports.
+	topOne   string
+	limitOne string
+	// topRows and limitRows read at most the number of rows formatted into
+	// them, if the database allows it in a query locking the rows it reads.
+	topRows   string
+	limitRows string
+	// arrays reports native array columns, which slice fields are bound to.
+	arrays bool
+	// readBack is how columns computed by the database are read after a write.
+	readBack readBackStyle
+	// locking is how the rows read by a query are locked.
+	locking lockStyle
+	// textTimes reports a database returning MIN and MAX of a time column
+	// as text, the result having no declared column type to convert it by.
+	textTimes bool
+	// insertAll reports a database without multi-row VALUES lists, which
+	// inserts several rows with INSERT ALL instead.
+	insertAll bool
+	// maxInList is the most values bound in one IN list, leaving room for the
+	// other arguments of the query within the limits of the database: 1000
+	// list items on Oracle, 2100 parameters on SQL Server and 999 variables
+	// on older SQLite versions.
+	maxInList int
+}
+
+// readBackStyle is a way of reading generated columns back after a write.
+type readBackStyle int
+
+const (
+	// readBackSelect runs a follow-up SELECT by primary key.
+	readBackSelect readBackStyle = iota
+	// readBackReturning appends RETURNING and scans the returned row.
+	readBackReturning
+	// readBackOutput adds OUTPUT INSERTED and scans the returned row.
+	readBackOutput
+	// readBackReturningInto appends RETURNING INTO bound to sql.Out arguments.
+	readBackReturningInto
+)
+
+// lockStyle is a way of locking the rows read by a query.
+type lockStyle int
+
+const (
+	// lockUnsupported reports a database without row-level locks.
+	lockUnsupported lockStyle = iota
+	// lockForUpdate ends the query with FOR UPDATE.
+	lockForUpdate
+	// lockTableHint follows the table with WITH (UPDLOCK, ROWLOCK).
+	lockTableHint
+)
+
+var (
+	postgresDialect  = dialect{name: "postgres", placeholder: "$%d", openQuote: `"`, closeQuote: `"`, jsonCast: "%s::jsonb", typeCast: "%s::%s", limitOne: " LIMIT 1", limitRows: " LIMIT %d", arrays: true, readBack: readBackReturning, locking: lockForUpdate, maxInList: 10000}
+	mysqlDialect     = dialect{name: "mysql", placeholder: "?", openQuote: "`", closeQuote: "`", jsonCast: "CAST(%s AS JSON)", limitOne: " LIMIT 1", limitRows: " LIMIT %d", locking: lockForUpdate, maxInList: 10000}
+	sqlserverDialect = dialect{name: "sqlserver", placeholder: "@p%d", openQuote: "[", closeQuote: "]", averageCast: "CAST(%s AS FLOAT)", topOne: "TOP 1 ", topRows: "TOP (%d) ", readBack: readBackOutput, locking: lockTableHint, maxInList: 2000}
+	oracleDialect    = dialect{name: "oracle", placeholder: ":%d", openQuote: `"`, closeQuote: `"`, typeCast: "CAST(%s AS %s)", limitOne: " FETCH FIRST 1 ROWS ONLY", readBack: readBackReturningInto, locking: lockForUpdate, insertAll: true, maxInList: 1000}
+	sqliteDialect    = dialect{name: "sqlite", placeholder: "?", openQuote: `"`, closeQuote: `"`, limitOne: " LIMIT 1", limitRows: " LIMIT %d", readBack: readBackReturning, textTimes: true, maxInList: 500}
+)
+
+// castTypes lists, by dialect, the declared column types a bound string or
+// timestamp is not implicitly converted to. Other types, such as character
+// and numeric ones, convert on their own, while a cast would truncate or
+// round the value to the size or precision of the type.
+var castTypes = map[string]map[string]bool{
+	"postgres": {"uuid": true, "date": true, "time": true, "timetz": true, "timestamp": true, "timestamptz": true, "timestamp with time zone": true, "timestamp without time zone": true, "time with time zone": true, "time without time zone": true, "interval": true, "json": true, "jsonb": true, "inet": true, "cidr": true, "macaddr": true},
+	"oracle":   {"date": true, "timestamp": true, "timestamp with time zone": true, "timestamp with local time zone": true},
+}
+
+// baseType returns the lowercased column type sqlType without its size,
+// precision or other modifiers, as in timestamp for TIMESTAMP(3).
+func baseType(sqlType string) string {
+	for {
+		open := strings.Index(sqlType, "(")
+		end := strings.Index(sqlType, ")")
+		if open < 0 || end < open {
+			break
+		}
+		sqlType = sqlType[:open] + sqlType[end+1:]
+	}
+	return strings.ToLower(strings.Join(strings.Fields(sqlType), " "))
+}
+
+// valueBind returns the expression binding placeholder to the column of field.
+func (d dialect) valueBind(field parser.Field, placeholder string) string {
+	if base := baseType(field.SQLType); castTypes[d.name][base] && d.typeCast != "" {
+		return fmt.Sprintf(d.typeCast, placeholder, base)
+	}
+	if field.IsJSON && d.jsonCast != "" {
+		return fmt.Sprintf(d.jsonCast, placeholder)
+	}
+	return placeholder
+}
+
+// isArray reports whether field is bound to a native array column.
+func (d dialect) isArray(field parser.Field) bool {
+	return d.arrays && strings.HasPrefix(field.Type, "[]") && field.Type != "[]byte" && !field.IsJSON && field.Converter == nil
+}
+
+// quote quotes an identifier, doubling any closing quote it contains.
+func (d dialect) quote(ident string) string {
+	return d.openQuote + strings.ReplaceAll(ident, d.closeQuote, d.closeQuote+d.closeQuote) + d.closeQuote
+}
+
+// quoteExpr returns Go source quoting the identifier held in the Go expression expr.
+func (d dialect) quoteExpr(expr string) string {
+	return fmt.Sprintf("%q + %s + %q", d.openQuote, expr, d.closeQuote)
+}
+
+// table returns the quoted, schema qualified table name of the model.
+func (d dialect) table(model parser.Model) string {
+	if model.Schema == "" {
+		return d.quote(model.TableName)
+	}
+	return d.quote(model.Schema) + "." + d.quote(model.TableName)
+}
+
+// columns returns the quoted column names of fields.
+func (d dialect) columns(fields []parser.Field) []string {
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, d.quote(field.Column))
+	}
+	return columns
+}
+
+// literal returns s as a Go string literal, preferring a raw string unless s
+// contains a backtick.
+func (d dialect) literal(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// bind returns the placeholder for the n-th (1-based) query argument.
+func (d dialect) bind(n int) string {
+	if d.isPositional() {
+		return d.placeholder
+	}
+	return fmt.Sprintf(d.placeholder, n)
+}
+
+// isPositional reports whether placeholders are unnumbered, like "?".
+func (d dialect) isPositional() bool {
+	return !strings.Contains(d.placeholder, "%d")
+}
+
+// bindExpr returns Go source evaluating to the placeholder for the argument
+// number held in the Go expression expr.
+func (d dialect) bindExpr(expr string) string {
+	if d.isPositional() {
+		return fmt.Sprintf("%q", d.placeholder)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", d.placeholder, expr)
+}
+
+// concatBind returns Go source evaluating to the literal prefix followed by
+// the placeholder for the argument number held in the Go expression expr.
+func (d dialect) concatBind(prefix, expr string) string {
+	if d.isPositional() {
+		return fmt.Sprintf("%q", prefix+d.placeholder)
+	}
+	return fmt.Sprintf("%q + %s", prefix, d.bindExpr(expr))
+}
+
+// sprintfBind returns the placeholder to embed in a fmt.Sprintf format for an
+// argument numbered by the Go expression expr, and the Sprintf argument it
+// consumes, if any.
+func (d dialect) sprintfBind(expr string) (string, string) {
+	if d.isPositional() {
+		return d.placeholder, ""
+	}
+	return d.placeholder, ", " + expr
+}
@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+func hasEncryptedField(model parser.Model) bool {
+	for _, field := range model.Fields {
+		if field.IsEncrypted {
+			return true
+		}
+	}
+	return false
+}
+
+// usesEncryptor reports whether the DAO of model holds an Encryptor, for the
+// encrypted fields of model or of the models its relations load.
+func usesEncryptor(model parser.Model) bool {
+	if hasEncryptedField(model) {
+		return true
+	}
+	for _, relation := range model.Relations {
+		if relation.Target != nil && hasEncryptedField(*relation.Target) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateDAOStruct generates the DAO type and its constructor. DAOs of models
+// with encrypted fields, or related to models with encrypted fields, also hold
+// the Encryptor sealing their columns.
+func generateDAOStruct(model parser.Model, daoName string) string {
+	var content strings.Builder
+	encrypted := usesEncryptor(model)
+
+	content.WriteString(fmt.Sprintf("type %s struct {\n", daoName))
+	content.WriteString("\tdb *sql.DB\n")
+	if encrypted {
+		content.WriteString("\tencryptor Encryptor\n")
+	}
+	content.WriteString("}\n\n")
+
+	if encrypted {
+		content.WriteString(fmt.Sprintf("func New%s(db *sql.DB, encryptor Encryptor) *%s {\n", daoName, daoName))
+		content.WriteString(fmt.Sprintf("\treturn &%s{db: db, encryptor: encryptor}\n", daoName))
+	} else {
+		content.WriteString(fmt.Sprintf("func New%s(db *sql.DB) *%s {\n", daoName, daoName))
+		content.WriteString(fmt.Sprintf("\treturn &%s{db: db}\n", daoName))
+	}
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
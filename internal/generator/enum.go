@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+func getEnumFields(model parser.Model) []parser.Field {
+	var fields []parser.Field
+	for _, field := range model.Fields {
+		if field.Enum != nil {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// enumLiterals renders the values of the enum of field as Go constants.
+// nullzero fields also accept the zero value, which they write as NULL.
+func enumLiterals(field parser.Field) []string {
+	values := field.Enum.Values
+	if field.IsNullZero {
+		zero := ""
+		if field.Enum.Integer {
+			zero = "0"
+		}
+		values = append([]string{zero}, values...)
+	}
+
+	seen := map[string]bool{}
+	var literals []string
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		if field.Enum.Integer {
+			literals = append(literals, value)
+		} else {
+			literals = append(literals, strconv.Quote(value))
+		}
+	}
+	return literals
+}
+
+// generateValidMethod generates the Valid method rejecting models whose enum
+// fields hold a value outside the constants declared for their type.
+func generateValidMethod(model parser.Model, daoName string) string {
+	fields := getEnumFields(model)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Valid(m *%s) error {\n", daoName, model.Name))
+	for _, field := range fields {
+		content.WriteString(fmt.Sprintf("\tswitch m.%s {\n", field.Name))
+		content.WriteString(fmt.Sprintf("\tcase %s:\n", strings.Join(enumLiterals(field), ", ")))
+		content.WriteString("\tdefault:\n")
+		content.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"%%w: %s %%v is not a %s\", ErrInvalidEnum, m.%s)\n", field.Name, field.Enum.Type, field.Name))
+		content.WriteString("\t}\n")
+	}
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString(generateValidFieldMethod(model, daoName))
+
+	return content.String()
+}
+
+// generateValidFieldMethod generates the validField method, the Valid check of
+// an entry of the fields map of PartialUpdate and UpdateWhere. Values of enum
+// columns must be of the enum type, or untyped constants of its underlying
+// kind, and among its values.
+func generateValidFieldMethod(model parser.Model, daoName string) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) validField(field string, value interface{}) error {\n", daoName))
+	content.WriteString("\tswitch field {\n")
+	for _, field := range getEnumFields(model) {
+		enumType := qualifyType(model, field.Type)
+		literalType := "string"
+		if field.Enum.Integer {
+			literalType = "int"
+		}
+		invalid := fmt.Sprintf("fmt.Errorf(\"%%w: %s %%v is not a %s\", ErrInvalidEnum, value)", field.Column, field.Enum.Type)
+
+		content.WriteString(fmt.Sprintf("\tcase %q:\n", field.Column))
+		content.WriteString(fmt.Sprintf("\t\tvar v %s\n", enumType))
+		content.WriteString("\t\tswitch value := value.(type) {\n")
+		content.WriteString(fmt.Sprintf("\t\tcase %s:\n", enumType))
+		content.WriteString("\t\t\tv = value\n")
+		content.WriteString(fmt.Sprintf("\t\tcase %s:\n", literalType))
+		content.WriteString(fmt.Sprintf("\t\t\tv = %s(value)\n", enumType))
+		content.WriteString("\t\tdefault:\n")
+		content.WriteString(fmt.Sprintf("\t\t\treturn %s\n", invalid))
+		content.WriteString("\t\t}\n")
+		content.WriteString("\t\tswitch v {\n")
+		content.WriteString(fmt.Sprintf("\t\tcase %s:\n", strings.Join(enumLiterals(field), ", ")))
+		content.WriteString("\t\tdefault:\n")
+		content.WriteString(fmt.Sprintf("\t\t\treturn %s\n", invalid))
+		content.WriteString("\t\t}\n")
+	}
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateValidFieldCall validates the entry of field in a fields map.
+func generateValidFieldCall(model parser.Model, zeroResults, indent string) string {
+	if len(getEnumFields(model)) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(indent + "if err := dao.validField(field, value); err != nil {\n")
+	content.WriteString(fmt.Sprintf("%s\treturn %serr\n", indent, zeroResults))
+	content.WriteString(indent + "}\n")
+
+	return content.String()
+}
+
+// generateValidCall validates m at the start of a write method.
+func generateValidCall(model parser.Model) string {
+	if len(getEnumFields(model)) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString("\tif err := dao.Valid(m); err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateValidManyCall validates every model before a batch write.
+func generateValidManyCall(model parser.Model) string {
+	if len(getEnumFields(model)) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString("\tfor _, model := range models {\n")
+	content.WriteString("\t\tif err := dao.Valid(model); err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// generateExistsMethods generates the Exists and ExistsByPk methods, which read
+// at most one row instead of counting every match.
+func generateExistsMethods(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(generateExistsMethod(model, daoName, d))
+	content.WriteString(generateExistsByPkMethod(model, daoName, d))
+
+	return content.String()
+}
+
+func generateExistsMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Exists(ctx context.Context, where string, args ...interface{}) (bool, error) {\n", daoName))
+	content.WriteString(generateScopeWherePrelude(model, "false, "))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", d.literal(fmt.Sprintf("SELECT %s1 FROM %s%s", d.topOne, d.table(model), whereConditions(conditions)))))
+	content.WriteString(generateWhereAppend("query", conditions))
+	if d.limitOne != "" {
+		content.WriteString(fmt.Sprintf("\tquery += %q\n\n", d.limitOne))
+	}
+
+	content.WriteString(generateExistsScan())
+
+	return content.String()
+}
+
+func generateExistsByPkMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	primaryColumn := d.quote(getPrimaryColumn(model))
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) ExistsByPk(ctx context.Context, pk %s) (bool, error) {\n", daoName, getPrimaryType(model)))
+	content.WriteString(generateTenantPrelude(model, "false, "))
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE %s = %s%s%s", d.table(model), primaryColumn, d.bind(1), andConditions(defaultConditions(model, d)), tenantCondition(model, d, 2))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\targs := []interface{}{%s%s}\n\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
+
+	content.WriteString(generateExistsScan())
+
+	return content.String()
+}
+
+// generateExistsScan ends an Exists method, reporting whether query matched a row.
+func generateExistsScan() string {
+	var content strings.Builder
+
+	content.WriteString("\tvar one int\n")
+	content.WriteString("\terr := dao.queryRowContext(ctx, query, args...).Scan(&one)\n")
+	content.WriteString("\tif errors.Is(err, sql.ErrNoRows) {\n")
+	content.WriteString("\t\treturn false, nil\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn false, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn true, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+func getGeneratedFields(model parser.Model) []parser.Field {
+	var fields []parser.Field
+	for _, field := range model.Fields {
+		if field.IsGenerated {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// outputClause renders the OUTPUT INSERTED clause reading the generated
+// columns of model back, for dialects placing it before VALUES or WHERE.
+func outputClause(model parser.Model, d dialect) string {
+	fields := getGeneratedFields(model)
+	if len(fields) == 0 || d.readBack != readBackOutput {
+		return ""
+	}
+
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, "INSERTED."+d.quote(field.Column))
+	}
+	return "OUTPUT " + strings.Join(columns, ", ")
+}
+
+// returningClause renders the RETURNING clause reading the generated columns
+// of model back, for dialects placing it at the end of the statement. n is the
+// number of arguments bound before it.
+func returningClause(model parser.Model, d dialect, n int) string {
+	fields := getGeneratedFields(model)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	switch d.readBack {
+	case readBackReturning:
+		return "RETURNING " + strings.Join(d.columns(fields), ", ")
+	case readBackReturningInto:
+		binds := make([]string, 0, len(fields))
+		for i := range fields {
+			binds = append(binds, d.bind(n+i+1))
+		}
+		return fmt.Sprintf("RETURNING %s INTO %s", strings.Join(d.columns(fields), ", "), strings.Join(binds, ", "))
+	}
+	return ""
+}
+
+// generateReadBackWrite executes the write in query with args and reads the
+// generated columns of model back into m. Updates of versioned models set
+// versioned, turning a write matching no row into ErrStaleObject and bumping
+// the in-memory version otherwise.
+func generateReadBackWrite(model parser.Model, d dialect, args []string, versioned bool) string {
+	var content strings.Builder
+	fields := getGeneratedFields(model)
+	generated := parser.Model{Fields: fields}
+	versionField, _ := getVersionField(model)
+
+	scanArgs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		scanArgs = append(scanArgs, generateScanArg(d, field, "m"))
+	}
+
+	content.WriteString(generateNullScanDeclarations(generated, "\t"))
+
+	switch d.readBack {
+	case readBackReturning, readBackOutput:
+		content.WriteString("\trow := dao.queryRowContext(ctx, query,\n")
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n")
+		content.WriteString("\terr := row.Scan(\n")
+		for _, arg := range scanArgs {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n")
+		if versioned {
+			content.WriteString("\tif err == sql.ErrNoRows {\n")
+			content.WriteString("\t\treturn ErrStaleObject\n")
+			content.WriteString("\t}\n")
+		}
+		content.WriteString("\tif err != nil {\n")
+		content.WriteString("\t\treturn err\n")
+		content.WriteString("\t}\n\n")
+		if versioned {
+			content.WriteString(fmt.Sprintf("\tm.%s++\n\n", versionField.Name))
+		}
+	case readBackReturningInto:
+		outArgs := append([]string{}, args...)
+		for _, arg := range scanArgs {
+			outArgs = append(outArgs, fmt.Sprintf("sql.Out{Dest: %s}", arg))
+		}
+		content.WriteString(generateWriteExec(outArgs, versioned))
+		if versioned {
+			content.WriteString(generateVersionCheck(versionField, "m", "\t"))
+			content.WriteString("\n")
+		} else {
+			content.WriteString("\tif err != nil {\n")
+			content.WriteString("\t\treturn err\n")
+			content.WriteString("\t}\n\n")
+		}
+	default:
+		content.WriteString(generateWriteExec(args, versioned))
+		if versioned {
+			content.WriteString(generateVersionCheck(versionField, "m", "\t"))
+			content.WriteString("\n")
+		} else {
+			content.WriteString("\tif err != nil {\n")
+			content.WriteString("\t\treturn err\n")
+			content.WriteString("\t}\n\n")
+		}
+
+		query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s", strings.Join(d.columns(fields), ", "), d.table(model), d.quote(getPrimaryColumn(model)), d.bind(1))
+		content.WriteString(fmt.Sprintf("\trow := dao.queryRowContext(ctx, %s, %s)\n", d.literal(query), generatePrimaryKeyArg(model, "m."+model.PrimaryKey)))
+		content.WriteString("\terr = row.Scan(\n")
+		for _, arg := range scanArgs {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n")
+		content.WriteString("\tif err != nil {\n")
+		content.WriteString("\t\treturn err\n")
+		content.WriteString("\t}\n\n")
+	}
+
+	content.WriteString(generateNullScanAssignments(generated, "m", "\t"))
+	content.WriteString("\treturn nil\n")
+
+	return content.String()
+}
+
+func generateWriteExec(args []string, versioned bool) string {
+	var content strings.Builder
+
+	if versioned {
+		content.WriteString("\tresult, err := dao.execContext(ctx, query,\n")
+	} else {
+		content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
+	}
+	for _, arg := range args {
+		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	}
+	content.WriteString("\t)\n")
+
+	return content.String()
+}
+
+// getInsertFields returns the fields of model written by inserts, leaving out
+// the columns computed by the database.
+func getInsertFields(model parser.Model) []parser.Field {
+	fields := make([]parser.Field, 0, len(model.Fields))
+	for _, field := range model.Fields {
+		if !field.IsGenerated {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
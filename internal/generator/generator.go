@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/Jibaru/gormless/internal/naming"
 	"github.com/Jibaru/gormless/internal/parser"
 )
 
@@ -16,7 +17,7 @@ func GenerateDAOInterfaces(models []parser.Model, outputPath string) error {
 	}
 
 	for _, model := range models {
-		fileName := fmt.Sprintf("%s_dao.go", toSnakeCase(model.Name))
+		fileName := fmt.Sprintf("%s_dao.go", naming.ToSnakeCase(model.Name))
 		filePath := filepath.Join(outputPath, fileName)
 
 		if _, err := os.Stat(filePath); err == nil {
@@ -37,6 +38,20 @@ func GenerateDAOInterfaces(models []parser.Model, outputPath string) error {
 		}
 	}
 
+	filePath := filepath.Join(outputPath, helpersFileName)
+
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("file with name %s already exists", filePath)
+	}
+
+	if err := os.WriteFile(filePath, []byte(generateInterfaceHelpersFile()), 0644); err != nil {
+		return fmt.Errorf("failed to write DAO interface helpers file: %v", err)
+	}
+
+	if err := formatGoFile(filePath); err != nil {
+		return fmt.Errorf("failed to format DAO interface helpers file: %v", err)
+	}
+
 	return nil
 }
 
@@ -48,7 +63,7 @@ func GenerateDAOs(models []parser.Model, outputPath, driver string) error {
 	}
 
 	for _, model := range models {
-		fileName := fmt.Sprintf("%s_dao.go", toSnakeCase(model.Name))
+		fileName := fmt.Sprintf("%s_dao.go", naming.ToSnakeCase(model.Name))
 		filePath := filepath.Join(driverPath, fileName)
 
 		if _, err := os.Stat(filePath); err == nil {
@@ -86,6 +101,22 @@ func GenerateDAOs(models []parser.Model, outputPath, driver string) error {
 		}
 	}
 
+	if content := generateHelpersFile(models, driver); content != "" {
+		filePath := filepath.Join(driverPath, helpersFileName)
+
+		if _, err := os.Stat(filePath); err == nil {
+			return fmt.Errorf("file with name %s already exists", filePath)
+		}
+
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write DAO helpers file: %v", err)
+		}
+
+		if err := formatGoFile(filePath); err != nil {
+			return fmt.Errorf("failed to format DAO helpers file: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -100,11 +131,29 @@ func formatGoFile(filePath string) error {
 	return nil
 }
 
+// generateInterfaceHelpersFile generates the declarations shared by the DAO
+// interfaces.
+func generateInterfaceHelpersFile() string {
+	return "package dao\n\n" + generateLockModes()
+}
+
 func generateDAOInterface(model parser.Model) (string, error) {
+	queries, err := analyzeQueries(model)
+	if err != nil {
+		return "", err
+	}
+
 	imports := []string{
 		"context",
 		model.ImportPath,
 	}
+	if aggregatesUsePackage(model, "sql", postgresDialect) || queriesUsePackage(queries, "sql") {
+		imports = append(imports, "database/sql")
+	}
+	if hasQueue(model) || aggregatesUsePackage(model, "time", postgresDialect) || queriesUsePackage(queries, "time") {
+		imports = append(imports, "time")
+	}
+	imports = append(imports, converterImports(model)...)
 
 	var content strings.Builder
 
@@ -116,6 +165,13 @@ func generateDAOInterface(model parser.Model) (string, error) {
 	content.WriteString(")\n\n")
 
 	content.WriteString(fmt.Sprintf("type %s = %s.%s\n\n", model.Name, model.Package, model.Name))
+	content.WriteString(generateJoinRowTypes(model))
+
+	for _, query := range queries {
+		if query.rowType != "" {
+			content.WriteString(generateQueryRowType(model, query))
+		}
+	}
 
 	daoInterfaceName := fmt.Sprintf("%sDAO", model.Name)
 	primaryType := getPrimaryType(model)
@@ -138,6 +194,9 @@ func generateDAOInterface(model parser.Model) (string, error) {
 	content.WriteString(fmt.Sprintf("\t// FindByPk finds a %s by primary key\n", model.Name))
 	content.WriteString(fmt.Sprintf("\tFindByPk(ctx context.Context, pk %s) (*%s, error)\n\n", primaryType, model.Name))
 
+	content.WriteString(fmt.Sprintf("\t// FindByPkForUpdate finds a %s by primary key and locks it until the transaction ends\n", model.Name))
+	content.WriteString(fmt.Sprintf("\tFindByPkForUpdate(ctx context.Context, pk %s, lock LockMode) (*%s, error)\n\n", primaryType, model.Name))
+
 	// Batch operations
 	content.WriteString(fmt.Sprintf("\t// CreateMany creates multiple %s records\n", model.Name))
 	content.WriteString(fmt.Sprintf("\tCreateMany(ctx context.Context, models []*%s) error\n\n", model.Name))
@@ -148,6 +207,12 @@ func generateDAOInterface(model parser.Model) (string, error) {
 	content.WriteString(fmt.Sprintf("\t// DeleteManyByPks deletes multiple %s records by primary keys\n", model.Name))
 	content.WriteString(fmt.Sprintf("\tDeleteManyByPks(ctx context.Context, pks []%s) error\n\n", primaryType))
 
+	content.WriteString(fmt.Sprintf("\t// DeleteWhere deletes the %s records matching the where clause\n", model.Name))
+	content.WriteString("\tDeleteWhere(ctx context.Context, where string, args ...interface{}) (int64, error)\n\n")
+
+	content.WriteString(fmt.Sprintf("\t// UpdateWhere updates the fields of the %s records matching the where clause\n", model.Name))
+	content.WriteString("\tUpdateWhere(ctx context.Context, fields map[string]interface{}, where string, args ...interface{}) (int64, error)\n\n")
+
 	// Query operations
 	content.WriteString(fmt.Sprintf("\t// FindOne finds a single %s with optional where clause and sort expression\n", model.Name))
 	content.WriteString(fmt.Sprintf("\tFindOne(ctx context.Context, where string, sort string, args ...interface{}) (*%s, error)\n\n", model.Name))
@@ -155,12 +220,132 @@ func generateDAOInterface(model parser.Model) (string, error) {
 	content.WriteString(fmt.Sprintf("\t// FindAll finds all %s records with optional where clause and sort expression\n", model.Name))
 	content.WriteString(fmt.Sprintf("\tFindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", model.Name))
 
+	content.WriteString(fmt.Sprintf("\t// FindAllColumns finds all %s records reading only the given columns\n", model.Name))
+	content.WriteString(fmt.Sprintf("\tFindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", model.Name))
+
+	content.WriteString(fmt.Sprintf("\t// FindAllForUpdate finds all %s records and locks them until the transaction ends\n", model.Name))
+	content.WriteString(fmt.Sprintf("\tFindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", model.Name))
+
 	content.WriteString(fmt.Sprintf("\t// FindPaginated finds %s records with pagination, optional where clause and sort expression\n", model.Name))
 	content.WriteString(fmt.Sprintf("\tFindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", model.Name))
 
 	content.WriteString(fmt.Sprintf("\t// Count counts %s records with optional where clause\n", model.Name))
 	content.WriteString("\tCount(ctx context.Context, where string, args ...interface{}) (int64, error)\n\n")
 
+	content.WriteString(fmt.Sprintf("\t// Exists reports whether any %s record matches the optional where clause\n", model.Name))
+	content.WriteString("\tExists(ctx context.Context, where string, args ...interface{}) (bool, error)\n\n")
+
+	content.WriteString(fmt.Sprintf("\t// ExistsByPk reports whether a %s with the primary key exists\n", model.Name))
+	content.WriteString(fmt.Sprintf("\tExistsByPk(ctx context.Context, pk %s) (bool, error)\n\n", primaryType))
+
+	content.WriteString(fmt.Sprintf("\t// CountDistinct counts the distinct values of a column of %s records with optional where clause\n", model.Name))
+	content.WriteString("\tCountDistinct(ctx context.Context, column string, where string, args ...interface{}) (int64, error)\n\n")
+
+	// Aggregate operations
+	for _, field := range model.Fields {
+		kind, baseType := getAggregateKind(field, postgresDialect)
+		if kind == aggregateInteger || kind == aggregateFloat {
+			sumType := "int64"
+			if kind == aggregateFloat {
+				sumType = "float64"
+			}
+			content.WriteString(fmt.Sprintf("\t// Sum%s sums the %s of %s records, returning zero when none match\n", field.Name, field.Name, model.Name))
+			content.WriteString(fmt.Sprintf("\tSum%s(ctx context.Context, where string, args ...interface{}) (%s, error)\n\n", field.Name, sumType))
+
+			content.WriteString(fmt.Sprintf("\t// Avg%s averages the %s of %s records, reporting false when none match\n", field.Name, field.Name, model.Name))
+			content.WriteString(fmt.Sprintf("\tAvg%s(ctx context.Context, where string, args ...interface{}) (float64, bool, error)\n\n", field.Name))
+		}
+		if kind != aggregateNone {
+			content.WriteString(fmt.Sprintf("\t// Min%s finds the smallest %s of %s records, reporting false when none match\n", field.Name, field.Name, model.Name))
+			content.WriteString(fmt.Sprintf("\tMin%s(ctx context.Context, where string, args ...interface{}) (%s, bool, error)\n\n", field.Name, baseType))
+
+			content.WriteString(fmt.Sprintf("\t// Max%s finds the largest %s of %s records, reporting false when none match\n", field.Name, field.Name, model.Name))
+			content.WriteString(fmt.Sprintf("\tMax%s(ctx context.Context, where string, args ...interface{}) (%s, bool, error)\n\n", field.Name, baseType))
+		}
+	}
+
+	for _, field := range model.Fields {
+		if isGroupable(field) {
+			content.WriteString(fmt.Sprintf("\t// GroupBy%s counts %s records per %s\n", field.Name, model.Name, field.Name))
+			content.WriteString(fmt.Sprintf("\tGroupBy%s(ctx context.Context, where string, args ...interface{}) ([]struct {\n\t\tKey %s\n\t\tCount int64\n\t}, error)\n\n", field.Name, qualifyType(model, field.Type)))
+		}
+	}
+
+	// Soft delete operations
+	if _, ok := getSoftDeleteField(model); ok {
+		content.WriteString(fmt.Sprintf("\t// Restore restores a soft deleted %s by primary key\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tRestore(ctx context.Context, pk %s) error\n\n", primaryType))
+
+		content.WriteString(fmt.Sprintf("\t// HardDelete permanently deletes a %s by primary key\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tHardDelete(ctx context.Context, pk %s) error\n\n", primaryType))
+
+		content.WriteString(fmt.Sprintf("\t// FindAllWithDeleted finds all %s records including soft deleted ones\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tFindAllWithDeleted(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", model.Name))
+
+		content.WriteString(fmt.Sprintf("\t// FindAllOnlyDeleted finds only soft deleted %s records\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tFindAllOnlyDeleted(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", model.Name))
+	}
+
+	// Relation loading
+	for _, relation := range model.Relations {
+		if relation.Kind == parser.ManyToMany {
+			relatedType := getPrimaryType(*relation.Target)
+
+			content.WriteString(fmt.Sprintf("\t// Attach%s links a %s to the given %s\n", relation.Name, model.Name, relation.Name))
+			content.WriteString(fmt.Sprintf("\tAttach%s(ctx context.Context, pk %s, relatedPks ...%s) error\n\n", relation.Name, primaryType, relatedType))
+
+			content.WriteString(fmt.Sprintf("\t// Detach%s unlinks a %s from the given %s\n", relation.Name, model.Name, relation.Name))
+			content.WriteString(fmt.Sprintf("\tDetach%s(ctx context.Context, pk %s, relatedPks ...%s) error\n\n", relation.Name, primaryType, relatedType))
+
+			content.WriteString(fmt.Sprintf("\t// Sync%s replaces the %s linked to a %s\n", relation.Name, relation.Name, model.Name))
+			content.WriteString(fmt.Sprintf("\tSync%s(ctx context.Context, pk %s, relatedPks []%s) error\n\n", relation.Name, primaryType, relatedType))
+
+			content.WriteString(fmt.Sprintf("\t// Load%s loads the %s linked to the given %s records\n", relation.Name, relation.Name, model.Name))
+			content.WriteString(fmt.Sprintf("\tLoad%s(ctx context.Context, models []*%s) error\n\n", relation.Name, model.Name))
+			continue
+		}
+
+		content.WriteString(fmt.Sprintf("\t// Preload%s loads the %s of the given %s records with one query\n", relation.Name, relation.Name, model.Name))
+		content.WriteString(fmt.Sprintf("\tPreload%s(ctx context.Context, models []*%s) error\n\n", relation.Name, model.Name))
+
+		content.WriteString(fmt.Sprintf("\t// FindAllWith%s finds all %s records with their %s loaded\n", relation.Name, model.Name, relation.Name))
+		content.WriteString(fmt.Sprintf("\tFindAllWith%s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", relation.Name, model.Name))
+
+		rowType := joinRowType(model, relation)
+		content.WriteString(fmt.Sprintf("\t// Join%s finds %s records joined with their %s in one query\n", relation.Name, model.Name, relation.Name))
+		content.WriteString(fmt.Sprintf("\tJoin%s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", relation.Name, rowType))
+
+		content.WriteString(fmt.Sprintf("\t// LeftJoin%s finds %s records joined with their %s in one query, keeping those without any\n", relation.Name, model.Name, relation.Name))
+		content.WriteString(fmt.Sprintf("\tLeftJoin%s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error)\n\n", relation.Name, rowType))
+	}
+
+	// Enum validation
+	if len(getEnumFields(model)) > 0 {
+		content.WriteString(fmt.Sprintf("\t// Valid reports an error for enum fields of a %s holding an undeclared value\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tValid(m *%s) error\n\n", model.Name))
+	}
+
+	// Queue operations
+	if hasQueue(model) {
+		content.WriteString(fmt.Sprintf("\t// Claim claims up to n pending %s records for leaseDuration\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tClaim(ctx context.Context, n int, leaseDuration time.Duration) ([]*%s, error)\n\n", model.Name))
+
+		content.WriteString(fmt.Sprintf("\t// Ack marks a claimed %s as done\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tAck(ctx context.Context, pk %s) error\n\n", primaryType))
+
+		content.WriteString(fmt.Sprintf("\t// Release returns a claimed %s to the pending ones\n", model.Name))
+		content.WriteString(fmt.Sprintf("\tRelease(ctx context.Context, pk %s) error\n\n", primaryType))
+
+		content.WriteString(fmt.Sprintf("\t// ReapExpired returns the claimed %s records whose lease expired to the pending ones\n", model.Name))
+		content.WriteString("\tReapExpired(ctx context.Context) (int64, error)\n\n")
+	}
+
+	// Named queries
+	for _, query := range queries {
+		content.WriteString(fmt.Sprintf("\t// %s runs the %s query of the %s queries file\n", query.Name, query.Name, naming.ToSnakeCase(model.Name)))
+		content.WriteString(fmt.Sprintf("\t%s\n\n", query.signature(model)))
+	}
+
 	// Transaction support
 	content.WriteString("\t// WithTransaction executes a function within a database transaction\n")
 	content.WriteString("\tWithTransaction(ctx context.Context, fn func(ctx context.Context) error) error\n")
@@ -179,17 +364,60 @@ func getPrimaryType(model parser.Model) string {
 	return "string"
 }
 
-func toSnakeCase(str string) string {
-	var result []rune
-	for i, r := range str {
-		if i > 0 && 'A' <= r && r <= 'Z' {
-			result = append(result, '_')
-		}
-		if 'A' <= r && r <= 'Z' {
-			result = append(result, r+32)
-		} else {
-			result = append(result, r)
+func getSoftDeleteField(model parser.Model) (parser.Field, bool) {
+	for _, field := range model.Fields {
+		if field.IsSoftDelete {
+			return field, true
 		}
 	}
-	return string(result)
+	return parser.Field{}, false
+}
+
+// defaultConditions returns the conditions every read query of the model is implicitly scoped by.
+func defaultConditions(model parser.Model, d dialect) []string {
+	var conditions []string
+	if field, ok := getSoftDeleteField(model); ok {
+		conditions = append(conditions, fmt.Sprintf("%s IS NULL", d.quote(field.Column)))
+	}
+	return conditions
+}
+
+// andConditions renders conditions to follow an existing WHERE predicate.
+func andConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " AND " + strings.Join(conditions, " AND ")
+}
+
+// whereConditions renders conditions as a WHERE clause on the same line as the query.
+func whereConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// generateWhereConditions renders conditions as a WHERE line inside a multiline query literal.
+func generateWhereConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\t\tWHERE %s\n", strings.Join(conditions, " AND "))
+}
+
+// generateWhereAppend appends the caller provided where filter to queryVar,
+// combining it with conditions already present in the query.
+func generateWhereAppend(queryVar string, conditions []string) string {
+	var content strings.Builder
+
+	content.WriteString("\tif where != \"\" {\n")
+	if len(conditions) == 0 {
+		content.WriteString(fmt.Sprintf("\t\t%s += \" WHERE \" + where\n", queryVar))
+	} else {
+		content.WriteString(fmt.Sprintf("\t\t%s += \" AND (\" + where + \")\"\n", queryVar))
+	}
+	content.WriteString("\t}\n\n")
+
+	return content.String()
 }
@@ -2,11 +2,25 @@ package generator_test
 
 import (
 	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Jibaru/gormless/internal/generator"
+	"github.com/Jibaru/gormless/internal/generator/data/formatted/mysql"
+	"github.com/Jibaru/gormless/internal/generator/data/formatted/postgres"
+	"github.com/Jibaru/gormless/internal/generator/data/formatted/sqlite"
+	"github.com/Jibaru/gormless/internal/generator/data/formatted/sqlserver"
+	"github.com/Jibaru/gormless/internal/generator/data/models"
 	"github.com/Jibaru/gormless/internal/parser"
 )
 
@@ -22,39 +36,1357 @@ func TestGenerateDAOs(t *testing.T) {
 
 		for _, driver := range drivers {
 			t.Run("driver: "+driver, func(t *testing.T) {
-				model := parser.Model{
-					Name: "User",
-					Fields: []parser.Field{
-						{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
-						{Name: "Name", Type: "string", Column: "name"},
-						{Name: "Email", Type: "*string", Column: "email"},
-						{Name: "Password", Type: "string", Column: "password"},
-						{Name: "Age", Type: "int", Column: "age"},
-						{Name: "DeletedAt", Type: "*time.Time", Column: "deleted_at"},
-					},
-					TableName:  "users",
-					PrimaryKey: "ID",
-					Package:    "models",
-					ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
-				}
-
 				outputPath, err := os.MkdirTemp("", "test")
 				if err != nil {
 					t.Errorf("unexpected error: %v", err)
 				}
 				defer os.RemoveAll(outputPath)
 
-				err = generator.GenerateDAOs([]parser.Model{model}, outputPath, driver)
+				err = generator.GenerateDAOs(testModels(), outputPath, driver)
 				if err != nil {
 					t.Errorf("unexpected error: %v", err)
 				}
 
-				compareFilesLineByLine(t, fmt.Sprintf("data/formatted/%s/user_dao.go", driver), fmt.Sprintf("%s/%s/user_dao.go", outputPath, driver))
+				compareDirs(t, fmt.Sprintf("data/formatted/%s", driver), fmt.Sprintf("%s/%s", outputPath, driver))
 			})
 		}
 	})
 }
 
+func TestGenerateSchema(t *testing.T) {
+	drivers := []string{
+		"mysql",
+		"postgres",
+		"sqlserver",
+		"oracle",
+		"sqlite",
+	}
+
+	for _, driver := range drivers {
+		t.Run("driver: "+driver, func(t *testing.T) {
+			outputPath := t.TempDir()
+
+			err := generator.GenerateSchema(testModels(), outputPath, driver)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			compareDirs(t, fmt.Sprintf("data/schema/%s", driver), fmt.Sprintf("%s/%s", outputPath, driver))
+		})
+	}
+}
+
+func TestArrayEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "float32", value: []float32{0.1, 2.5}, expected: "{0.1,2.5}"},
+		{name: "float64", value: []float64{0.1, 2.5}, expected: "{0.1,2.5}"},
+		{name: "strings", value: []string{"a", `b"c`}, expected: `{"a","b\"c"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgres.Array(tt.value).(driver.Valuer).Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func testModels() []parser.Model {
+	models := []parser.Model{
+		{
+			Name: "User",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "Name", Type: "string", Column: "name"},
+				{Name: "Email", Type: "*string", Column: "email", IsGroupBy: true},
+				{Name: "Password", Type: "string", Column: "password"},
+				{Name: "Age", Type: "int", Column: "age", IsGroupBy: true},
+				{Name: "DeletedAt", Type: "*time.Time", Column: "deleted_at"},
+			},
+			TableName:  "users",
+			PrimaryKey: "ID",
+			Queries: []parser.Query{
+				{Name: "ActiveAdults", Kind: parser.QueryMany, SQL: "SELECT * FROM users WHERE age >= $1 AND deleted_at IS NULL ORDER BY name"},
+				{Name: "FindByEmail", Kind: parser.QueryOne, SQL: "SELECT id, name, email, password, age, deleted_at FROM users WHERE email = $1"},
+				{Name: "CountByAge", Kind: parser.QueryMany, SQL: "SELECT age, COUNT(*) AS total, MAX(deleted_at) AS last_deleted FROM users WHERE name LIKE $1 GROUP BY age", Types: map[string]string{"last_deleted": "*time.Time"}},
+				{Name: "Rename", Kind: parser.QueryExec, SQL: "UPDATE users SET name = $1 WHERE id = $2"},
+				{Name: "PurgeDeleted", Kind: parser.QueryExecRows, SQL: "DELETE FROM users WHERE deleted_at < $1"},
+			},
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Post",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "Title", Type: "string", Column: "title"},
+				{Name: "Body", Type: "string", Column: "body"},
+				{Name: "DeletedAt", Type: "*time.Time", Column: "deleted_at", IsSoftDelete: true},
+			},
+			TableName:  "posts",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Article",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "Title", Type: "string", Column: "title"},
+				{Name: "Content", Type: "string", Column: "content"},
+				{Name: "Version", Type: "int", Column: "version", IsVersion: true},
+			},
+			TableName:  "articles",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Invoice",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "TenantID", Type: "int64", Column: "tenant_id", IsTenant: true},
+				{Name: "Number", Type: "string", Column: "number"},
+				{Name: "Amount", Type: "float64", Column: "amount"},
+			},
+			TableName:  "invoices",
+			Schema:     "billing",
+			PrimaryKey: "ID",
+			Queries: []parser.Query{
+				{Name: "FindByNumber", Kind: parser.QueryOne, SQL: "SELECT * FROM billing.invoices WHERE number = $1 AND tenant_id = $2"},
+				{Name: "VoidAbove", Kind: parser.QueryExecRows, SQL: "DELETE FROM billing.invoices WHERE tenant_id = $1 AND amount > $2"},
+				{Name: "PurgeTenant", Kind: parser.QueryExec, SQL: "DELETE FROM billing.invoices WHERE tenant_id = $1", Unscoped: true},
+			},
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Event",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "Name", Type: "string", Column: "name"},
+				{Name: "Payload", Type: "map[string]interface{}", Column: "payload", IsJSON: true},
+				{Name: "Tags", Type: "[]string", Column: "tags", IsJSON: true},
+			},
+			TableName:  "events",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Contact",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "Name", Type: "string", Column: "name"},
+				{Name: "Bio", Type: "string", Column: "bio", IsNullable: true},
+				{Name: "Age", Type: "int", Column: "age", IsNullable: true},
+				{Name: "LastSeen", Type: "time.Time", Column: "last_seen", IsNullable: true, IsNullZero: true},
+				{Name: "Phone", Type: "sql.NullString", Column: "phone", IsNullable: true},
+			},
+			TableName:  "contacts",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Product",
+			Fields: []parser.Field{
+				{Name: "Code", Type: "convert.Code", Column: "code", IsPrimary: true, Converter: &parser.Converter{
+					Import: "github.com/Jibaru/gormless/internal/generator/data/convert",
+					Encode: "convert.EncodeCode",
+					Decode: "convert.DecodeCode",
+				}},
+				{Name: "Name", Type: "string", Column: "name"},
+				{Name: "Price", Type: "convert.Cents", Column: "price", Converter: &parser.Converter{
+					Import: "github.com/Jibaru/gormless/internal/generator/data/convert",
+					Encode: "convert.EncodeCents",
+					Decode: "convert.DecodeCents",
+				}},
+			},
+			TableName:  "products",
+			PrimaryKey: "Code",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Ticket",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+				{Name: "Title", Type: "string", Column: "title"},
+				{Name: "Status", Type: "TicketStatus", Column: "status", IsGroupBy: true, Enum: &parser.Enum{Type: "TicketStatus", Values: []string{"open", "closed"}}},
+				{Name: "Priority", Type: "Priority", Column: "priority", Enum: &parser.Enum{Type: "Priority", Values: []string{"1", "2"}, Integer: true}},
+				{Name: "Resolution", Type: "TicketResolution", Column: "resolution", IsNullable: true, IsNullZero: true, Underlying: "string", Enum: &parser.Enum{Type: "TicketResolution", Values: []string{"fixed", "wontfix"}}},
+			},
+			TableName:  "tickets",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Document",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+				{Name: "Title", Type: "string", Column: "title"},
+				{Name: "Labels", Type: "[]string", Column: "labels"},
+				{Name: "Scores", Type: "[]int64", Column: "scores"},
+			},
+			TableName:  "documents",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Page",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+				{Name: "Title", Type: "string", Column: "title"},
+				{Name: "Slug", Type: "string", Column: "slug", IsGenerated: true},
+				{Name: "Excerpt", Type: "sql.NullString", Column: "excerpt", IsNullable: true, IsGenerated: true},
+				{Name: "UpdatedAt", Type: "time.Time", Column: "updated_at", IsGenerated: true},
+				{Name: "Version", Type: "int", Column: "version", IsVersion: true},
+			},
+			TableName:  "pages",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Payment",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "string", Column: "id", IsPrimary: true, SQLType: "uuid"},
+				{Name: "Reference", Type: "string", Column: "reference", Size: 64},
+				{Name: "Code", Type: "string", Column: "code", SQLType: "varchar(20)"},
+				{Name: "Amount", Type: "float64", Column: "amount", Precision: 12, Scale: 2},
+				{Name: "Fee", Type: "float64", Column: "fee", SQLType: "numeric(8,2)"},
+				{Name: "PaidOn", Type: "time.Time", Column: "paid_on", SQLType: "date"},
+				{Name: "Attributes", Type: "map[string]string", Column: "attributes", IsJSON: true, IsNotNull: true},
+			},
+			TableName:  "payments",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Patient",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+				{Name: "Name", Type: "string", Column: "name"},
+				{Name: "SSN", Type: "string", Column: "ssn", IsEncrypted: true},
+				{Name: "Phone", Type: "string", Column: "phone", IsNullable: true, IsNullZero: true, IsEncrypted: true},
+				{Name: "Notes", Type: "[]byte", Column: "notes", IsEncrypted: true},
+			},
+			TableName:  "patients",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		},
+		{
+			Name: "Job",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+				{Name: "TenantID", Type: "int64", Column: "tenant_id", IsTenant: true},
+				{Name: "Payload", Type: "string", Column: "payload"},
+				{Name: "Status", Type: "JobStatus", Column: "status", Enum: &parser.Enum{Type: "JobStatus", Values: []string{"pending", "running", "done"}}},
+				{Name: "LeasedUntil", Type: "*time.Time", Column: "leased_until"},
+				{Name: "Version", Type: "int", Column: "version", IsVersion: true},
+			},
+			TableName:  "jobs",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+			Queue:      &parser.Queue{StatusColumn: "status", LeaseColumn: "leased_until", Pending: "pending", Claimed: "running", Done: "done"},
+		},
+	}
+
+	user := models[0]
+	order := parser.Model{
+		Name: "Order",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "UserID", Type: "*int", Column: "user_id"},
+			{Name: "Total", Type: "float64", Column: "total"},
+		},
+		TableName:  "orders",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+	}
+	orderItem := parser.Model{
+		Name: "OrderItem",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "OrderID", Type: "*int64", Column: "order_id"},
+			{Name: "Sku", Type: "string", Column: "sku"},
+			{Name: "Quantity", Type: "int", Column: "quantity"},
+		},
+		TableName:  "order_items",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+	}
+	order.Relations = []parser.Relation{
+		{Name: "User", Type: "*User", Kind: parser.BelongsTo, Model: "User", Column: "user_id", Target: &user},
+		{Name: "Items", Type: "[]*OrderItem", Kind: parser.HasMany, Model: "OrderItem", Column: "order_id", Target: &orderItem},
+	}
+	orderItem.Relations = []parser.Relation{
+		{Name: "Order", Type: "Order", Kind: parser.BelongsTo, Model: "Order", Column: "order_id", Target: &order},
+	}
+
+	role := parser.Model{
+		Name: "Role",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "Name", Type: "string", Column: "name"},
+		},
+		TableName:  "roles",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+	}
+	group := parser.Model{
+		Name: "Group",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Name", Type: "string", Column: "name"},
+		},
+		TableName:  "groups",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		Relations: []parser.Relation{
+			{Name: "Roles", Type: "[]*Role", Kind: parser.ManyToMany, Model: "Role", Column: "group_id", JoinTable: "group_roles", TargetColumn: "role_id", Target: &role},
+		},
+	}
+
+	invoice, post := models[3], models[1]
+	tax := parser.Model{
+		Name: "Tax",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "TenantID", Type: "int64", Column: "tenant_id", IsTenant: true},
+			{Name: "Name", Type: "string", Column: "name"},
+			{Name: "Rate", Type: "float64", Column: "rate"},
+		},
+		TableName:  "taxes",
+		Schema:     "billing",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+	}
+	invoiceLine := parser.Model{
+		Name: "InvoiceLine",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "TenantID", Type: "int32", Column: "tenant_id", IsTenant: true},
+			{Name: "InvoiceID", Type: "int", Column: "invoice_id"},
+			{Name: "Description", Type: "string", Column: "description"},
+		},
+		TableName:  "invoice_lines",
+		Schema:     "billing",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		Relations: []parser.Relation{
+			{Name: "Invoice", Type: "*Invoice", Kind: parser.BelongsTo, Model: "Invoice", Column: "invoice_id", Target: &invoice},
+			{Name: "Taxes", Type: "[]*Tax", Kind: parser.ManyToMany, Model: "Tax", Column: "invoice_line_id", JoinTable: "invoice_line_taxes", TargetColumn: "tax_id", Target: &tax},
+		},
+	}
+	comment := parser.Model{
+		Name: "Comment",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "PostID", Type: "int", Column: "post_id"},
+			{Name: "Body", Type: "string", Column: "body"},
+		},
+		TableName:  "comments",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		Relations: []parser.Relation{
+			{Name: "Post", Type: "*Post", Kind: parser.BelongsTo, Model: "Post", Column: "post_id", Target: &post},
+		},
+	}
+
+	patient := models[11]
+	visit := parser.Model{
+		Name: "Visit",
+		Fields: []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "PatientID", Type: "int64", Column: "patient_id"},
+			{Name: "Reason", Type: "string", Column: "reason"},
+		},
+		TableName:  "visits",
+		PrimaryKey: "ID",
+		Package:    "models",
+		ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+		Relations: []parser.Relation{
+			{Name: "Patient", Type: "*Patient", Kind: parser.BelongsTo, Model: "Patient", Column: "patient_id", Target: &patient},
+		},
+	}
+
+	return append(models, order, orderItem, group, role, invoiceLine, tax, comment, visit)
+}
+
+func TestEncryptedRelations(t *testing.T) {
+	encryptor, err := postgres.NewAESEncryptor([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ssn, err := encryptor.Encrypt([]byte("123-45-6789"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("preload decrypts the targets", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{int64(7), "Ana", ssn, nil, nil}}
+		dao := postgres.NewVisitDAO(db, encryptor)
+
+		visits := []*models.Visit{{ID: 1, PatientID: 7}}
+		if err := dao.PreloadPatient(context.Background(), visits); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if visits[0].Patient == nil || visits[0].Patient.SSN != "123-45-6789" {
+			t.Errorf("expected the decrypted patient, got %+v", visits[0].Patient)
+		}
+	})
+
+	t.Run("join decrypts the targets", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{int64(1), int64(7), "checkup", int64(7), "Ana", ssn, nil, nil}}
+
+		results, err := postgres.NewVisitDAO(db, encryptor).JoinPatient(context.Background(), "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 1 || results[0].Patient.SSN != "123-45-6789" {
+			t.Errorf("expected the decrypted patient, got %+v", results)
+		}
+	})
+}
+
+func TestJoinMethods(t *testing.T) {
+	t.Run("left join keeps rows without a target", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{
+			{int64(1), nil, 9.5, nil, nil, nil, nil, nil, nil},
+			{int64(2), int64(7), 3.0, int64(7), "Ana", nil, "secret", int64(30), nil},
+		}
+
+		results, err := postgres.NewOrderDAO(db).LeftJoinUser(context.Background(), "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(results) != 2 {
+			t.Fatalf("expected 2 rows, got %d", len(results))
+		}
+		if results[0].Order.ID != 1 || results[0].Order.UserID != nil || results[0].User != nil {
+			t.Errorf("expected an order without user, got %+v", results[0])
+		}
+		if results[1].User == nil || results[1].User.Name != "Ana" || results[1].User.Email != nil {
+			t.Errorf("expected the order of Ana, got %+v", results[1].User)
+		}
+		if !strings.Contains(conn.lastQuery(), `LEFT JOIN "users" "user"`) {
+			t.Errorf("expected a LEFT JOIN, got %s", conn.lastQuery())
+		}
+	})
+
+	t.Run("tenant scopes both sides", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := mysql.NewInvoiceLineDAO(db)
+
+		if _, err := dao.JoinInvoice(context.Background(), "", ""); !errors.Is(err, mysql.ErrMissingTenant) {
+			t.Fatalf("expected ErrMissingTenant, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Fatalf("expected no query without a tenant, got %d", len(conn.statements))
+		}
+
+		ctx := mysql.WithTenant(context.Background(), int64(42))
+		if _, err := dao.JoinInvoice(ctx, "`invoice_lines`.`id` > ?", "", 10); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		query := conn.lastQuery()
+		if !strings.Contains(query, "ON `invoice`.`id` = `invoice_lines`.`invoice_id` AND `invoice`.`tenant_id` = `invoice_lines`.`tenant_id`") {
+			t.Errorf("expected the target to be scoped by the tenant of the model, got %s", query)
+		}
+		if !strings.HasSuffix(query, "WHERE (`invoice_lines`.`id` > ?) AND `invoice_lines`.`tenant_id` = ?") {
+			t.Errorf("expected the model to be scoped by the tenant, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(10), int64(42))
+	})
+
+	t.Run("soft deleted target is excluded in the join condition", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+
+		if _, err := sqlite.NewCommentDAO(db).LeftJoinPost(context.Background(), "", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		query := conn.lastQuery()
+		if !strings.Contains(query, `ON "post"."id" = "comments"."post_id" AND "post"."deleted_at" IS NULL`) {
+			t.Errorf("expected soft deleted posts to be excluded in the ON clause, got %s", query)
+		}
+		if strings.Contains(query, "WHERE") {
+			t.Errorf("expected comments of soft deleted posts to be kept, got %s", query)
+		}
+	})
+}
+
+func TestAggregateMethods(t *testing.T) {
+	t.Run("no matching rows", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{nil}}
+		dao := postgres.NewOrderDAO(db)
+		ctx := context.Background()
+
+		sum, err := dao.SumTotal(ctx, "total > $1", 100)
+		if err != nil || sum != 0 {
+			t.Errorf("expected a zero sum, got %v, %v", sum, err)
+		}
+		if _, ok, err := dao.AvgTotal(ctx, ""); err != nil || ok {
+			t.Errorf("expected no average, got %v, %v", ok, err)
+		}
+		if _, ok, err := dao.MinUserID(ctx, ""); err != nil || ok {
+			t.Errorf("expected no minimum, got %v, %v", ok, err)
+		}
+	})
+
+	t.Run("average of integers", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{30.5}}
+
+		avg, ok, err := sqlserver.NewUserDAO(db).AvgAge(context.Background(), "")
+		if err != nil || !ok || avg != 30.5 {
+			t.Errorf("expected an average of 30.5, got %v, %v, %v", avg, ok, err)
+		}
+		if !strings.HasPrefix(conn.lastQuery(), "SELECT AVG(CAST([age] AS FLOAT))") {
+			t.Errorf("expected the integers to be averaged as floats, got %s", conn.lastQuery())
+		}
+
+		conn.rows = [][]driver.Value{{[]byte("30.5000000000000000")}}
+		avg, ok, err = postgres.NewUserDAO(db).AvgAge(context.Background(), "")
+		if err != nil || !ok || avg != 30.5 {
+			t.Errorf("expected a numeric average of 30.5, got %v, %v, %v", avg, ok, err)
+		}
+	})
+
+	t.Run("group by nullable keys", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{
+			{nil, int64(3)},
+			{"ana@example.com", int64(1)},
+		}
+
+		groups, err := mysql.NewUserDAO(db).GroupByEmail(context.Background(), "age > ?", 18)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(groups) != 2 {
+			t.Fatalf("expected 2 groups, got %d", len(groups))
+		}
+		if groups[0].Key != nil || groups[0].Count != 3 {
+			t.Errorf("expected 3 users without email, got %+v", groups[0])
+		}
+		if groups[1].Key == nil || *groups[1].Key != "ana@example.com" || groups[1].Count != 1 {
+			t.Errorf("expected 1 user with the email of Ana, got %+v", groups[1])
+		}
+		if !strings.HasSuffix(conn.lastQuery(), "WHERE age > ? GROUP BY `email` ORDER BY `email`") {
+			t.Errorf("expected the groups in email order, got %s", conn.lastQuery())
+		}
+		assertArgs(t, conn.lastArgs(), int64(18))
+	})
+
+	t.Run("sqlite times read from text", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := sqlite.NewContactDAO(db)
+
+		conn.rows = [][]driver.Value{{"2024-03-01 10:30:00.5+00:00"}}
+		latest, ok, err := dao.MaxLastSeen(context.Background(), "")
+		if err != nil || !ok {
+			t.Fatalf("expected a maximum, got %v, %v", ok, err)
+		}
+		if expected := time.Date(2024, 3, 1, 10, 30, 0, 500000000, time.UTC); !latest.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, latest)
+		}
+
+		conn.rows = [][]driver.Value{{nil}}
+		if _, ok, err := dao.MinLastSeen(context.Background(), ""); err != nil || ok {
+			t.Errorf("expected no minimum, got %v, %v", ok, err)
+		}
+
+		conn.rows = [][]driver.Value{{"yesterday"}}
+		if _, _, err := dao.MinLastSeen(context.Background(), ""); err == nil {
+			t.Error("expected an error for a text that is not a time")
+		}
+	})
+}
+
+func TestExistsMethods(t *testing.T) {
+	t.Run("soft deleted rows do not exist", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := postgres.NewPostDAO(db)
+
+		found, err := dao.Exists(context.Background(), "title = $1", "draft")
+		if err != nil || found {
+			t.Errorf("expected no post, got %v, %v", found, err)
+		}
+		if query := conn.lastQuery(); query != `SELECT 1 FROM "posts" WHERE "deleted_at" IS NULL AND (title = $1) LIMIT 1` {
+			t.Errorf("expected soft deleted posts to be excluded, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), "draft")
+
+		conn.rows = [][]driver.Value{{int64(1)}}
+		found, err = dao.ExistsByPk(context.Background(), 7)
+		if err != nil || !found {
+			t.Errorf("expected the post to exist, got %v, %v", found, err)
+		}
+		if query := conn.lastQuery(); query != `SELECT 1 FROM "posts" WHERE "id" = $1 AND "deleted_at" IS NULL` {
+			t.Errorf("expected soft deleted posts to be excluded, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(7))
+	})
+
+	t.Run("tenant scopes existence", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := mysql.NewInvoiceDAO(db)
+
+		if _, err := dao.Exists(context.Background(), ""); !errors.Is(err, mysql.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from Exists, got %v", err)
+		}
+		if _, err := dao.ExistsByPk(context.Background(), 7); !errors.Is(err, mysql.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from ExistsByPk, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Fatalf("expected no query without a tenant, got %d", len(conn.statements))
+		}
+
+		ctx := mysql.WithTenant(context.Background(), int64(42))
+		conn.rows = [][]driver.Value{{int64(1)}}
+		if found, err := dao.Exists(ctx, "amount > ?", 100); err != nil || !found {
+			t.Errorf("expected an invoice, got %v, %v", found, err)
+		}
+		if query := conn.lastQuery(); !strings.Contains(query, "`tenant_id` = ?") {
+			t.Errorf("expected the query to be scoped by the tenant, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(100), int64(42))
+
+		if found, err := dao.ExistsByPk(ctx, 7); err != nil || !found {
+			t.Errorf("expected the invoice to exist, got %v, %v", found, err)
+		}
+		if query := conn.lastQuery(); query != "SELECT 1 FROM `billing`.`invoices` WHERE `id` = ? AND `tenant_id` = ?" {
+			t.Errorf("expected the query to be scoped by the tenant, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(7), int64(42))
+	})
+
+	t.Run("count distinct of a nullable column", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{int64(2)}}
+		dao := postgres.NewUserDAO(db)
+
+		count, err := dao.CountDistinct(context.Background(), "email", "age > $1", 18)
+		if err != nil || count != 2 {
+			t.Errorf("expected 2 distinct emails, got %v, %v", count, err)
+		}
+		if query := conn.lastQuery(); query != `SELECT COUNT(DISTINCT "email") FROM "users" WHERE age > $1` {
+			t.Errorf("expected the distinct emails to be counted, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(18))
+
+		statements := len(conn.statements)
+		if _, err := dao.CountDistinct(context.Background(), "email; DROP TABLE users", ""); !errors.Is(err, postgres.ErrUnknownColumn) {
+			t.Errorf("expected ErrUnknownColumn, got %v", err)
+		}
+		if len(conn.statements) != statements {
+			t.Error("expected no query for an unknown column")
+		}
+	})
+}
+
+func TestBulkMethods(t *testing.T) {
+	t.Run("full table guard", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.affected = 5
+		dao := postgres.NewUserDAO(db)
+
+		if _, err := dao.DeleteWhere(context.Background(), ""); !errors.Is(err, postgres.ErrFullTable) {
+			t.Errorf("expected ErrFullTable from DeleteWhere, got %v", err)
+		}
+		if _, err := dao.UpdateWhere(context.Background(), map[string]interface{}{"age": 30}, ""); !errors.Is(err, postgres.ErrFullTable) {
+			t.Errorf("expected ErrFullTable from UpdateWhere, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Fatalf("expected no statement without AllowFullTable, got %d", len(conn.statements))
+		}
+
+		ctx := postgres.AllowFullTable(context.Background())
+		affected, err := dao.DeleteWhere(ctx, "")
+		if err != nil || affected != 5 {
+			t.Errorf("expected 5 deleted users, got %v, %v", affected, err)
+		}
+		if query := conn.lastQuery(); query != `DELETE FROM "users"` {
+			t.Errorf("expected every user to be deleted, got %s", query)
+		}
+	})
+
+	t.Run("soft delete updates the rows", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+
+		if _, err := mysql.NewPostDAO(db).DeleteWhere(context.Background(), "`title` = ?", "draft"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if query := conn.lastQuery(); query != "UPDATE `posts` SET `deleted_at` = ? WHERE `deleted_at` IS NULL AND (`title` = ?)" {
+			t.Errorf("expected the posts to be soft deleted, got %s", query)
+		}
+		args := conn.lastArgs()
+		if len(args) != 2 || args[1] != "draft" {
+			t.Fatalf("expected the deletion time and the title, got %v", args)
+		}
+		if _, ok := args[0].(time.Time); !ok {
+			t.Errorf("expected the deletion time first, got %v", args[0])
+		}
+	})
+
+	t.Run("set and where arguments match their placeholders", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		fields := map[string]interface{}{"age": 30}
+
+		if _, err := mysql.NewUserDAO(db).UpdateWhere(context.Background(), fields, "`name` = ?", "Ana"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if query := conn.lastQuery(); query != "UPDATE `users` SET `age` = ? WHERE `name` = ?" {
+			t.Errorf("unexpected query %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(30), "Ana")
+
+		if _, err := postgres.NewUserDAO(db).UpdateWhere(context.Background(), fields, `"name" = $1`, "Ana"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if query := conn.lastQuery(); query != `UPDATE "users" SET "age" = $2 WHERE "name" = $1` {
+			t.Errorf("expected the set arguments to be numbered after the where arguments, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), "Ana", int64(30))
+
+		if _, err := mysql.NewUserDAO(db).UpdateWhere(context.Background(), map[string]interface{}{"role": "admin"}, "`name` = ?", "Ana"); !errors.Is(err, mysql.ErrUnknownColumn) {
+			t.Errorf("expected ErrUnknownColumn, got %v", err)
+		}
+	})
+}
+
+func TestFindAllColumns(t *testing.T) {
+	t.Run("unknown column", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+
+		_, err := postgres.NewEventDAO(db).FindAllColumns(context.Background(), []string{"name", "secret"}, "", "")
+		if !errors.Is(err, postgres.ErrUnknownColumn) {
+			t.Errorf("expected ErrUnknownColumn, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Errorf("expected no query for an unknown column, got %d", len(conn.statements))
+		}
+	})
+
+	t.Run("no columns selects every column", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{int64(1), "launch", []byte(`{"level":"high"}`), []byte(`["a"]`)}}
+
+		events, err := postgres.NewEventDAO(db).FindAllColumns(context.Background(), nil, `"id" = $1`, "", 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(events) != 1 || events[0].Name != "launch" || len(events[0].Tags) != 1 {
+			t.Errorf("expected the whole event, got %+v", events)
+		}
+		query := strings.Join(strings.Fields(conn.lastQuery()), " ")
+		if query != `SELECT "id", "name", "payload", "tags" FROM "events" WHERE "id" = $1` {
+			t.Errorf("expected FindAll to run, got %s", query)
+		}
+	})
+
+	t.Run("projected columns are decoded", func(t *testing.T) {
+		encryptor, err := postgres.NewAESEncryptor([]byte("0123456789abcdef"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ssn, err := encryptor.Encrypt([]byte("123-45-6789"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{ssn, "Ana"}}
+
+		patients, err := postgres.NewPatientDAO(db, encryptor).FindAllColumns(context.Background(), []string{"ssn", "name"}, "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(patients) != 1 || patients[0].SSN != "123-45-6789" || patients[0].Name != "Ana" {
+			t.Errorf("expected the decrypted ssn of Ana, got %+v", patients)
+		}
+		if query := conn.lastQuery(); query != `SELECT "ssn", "name" FROM "patients"` {
+			t.Errorf("expected only the projected columns, got %s", query)
+		}
+
+		conn.rows = [][]driver.Value{{[]byte(`{"level":"high"}`)}}
+		events, err := postgres.NewEventDAO(db).FindAllColumns(context.Background(), []string{"payload"}, "", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 1 || events[0].Payload["level"] != "high" || events[0].Name != "" {
+			t.Errorf("expected only the decoded payload, got %+v", events)
+		}
+	})
+}
+
+func TestLockMethods(t *testing.T) {
+	t.Run("no transaction", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := postgres.NewUserDAO(db)
+
+		if _, err := dao.FindByPkForUpdate(context.Background(), 1, postgres.LockWait); !errors.Is(err, postgres.ErrNoTransaction) {
+			t.Errorf("expected ErrNoTransaction from FindByPkForUpdate, got %v", err)
+		}
+		if _, err := dao.FindAllForUpdate(context.Background(), postgres.LockSkipLocked, "", ""); !errors.Is(err, postgres.ErrNoTransaction) {
+			t.Errorf("expected ErrNoTransaction from FindAllForUpdate, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Errorf("expected no query without a transaction, got %d", len(conn.statements))
+		}
+	})
+
+	t.Run("unknown lock mode", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := mysql.NewUserDAO(db)
+
+		err := dao.WithTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := dao.FindAllForUpdate(ctx, mysql.LockMode(7), "", "")
+			return err
+		})
+		if !errors.Is(err, mysql.ErrUnknownLockMode) {
+			t.Errorf("expected ErrUnknownLockMode, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Errorf("expected no query for an unknown lock mode, got %d", len(conn.statements))
+		}
+	})
+
+	t.Run("lock inside a transaction", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := postgres.NewUserDAO(db)
+
+		err := dao.WithTransaction(context.Background(), func(ctx context.Context) error {
+			_, err := dao.FindAllForUpdate(ctx, postgres.LockNoWait, "age > $1", "", 18)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasSuffix(conn.lastQuery(), "WHERE age > $1 FOR UPDATE NOWAIT") {
+			t.Errorf("expected the rows to be locked without waiting, got %s", conn.lastQuery())
+		}
+	})
+
+	t.Run("sqlite has no row locks", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := sqlite.NewUserDAO(db)
+
+		err := dao.WithTransaction(context.Background(), func(ctx context.Context) error {
+			if _, err := dao.FindByPkForUpdate(ctx, 1, sqlite.LockWait); !errors.Is(err, sqlite.ErrLockUnsupported) {
+				t.Errorf("expected ErrLockUnsupported from FindByPkForUpdate, got %v", err)
+			}
+			_, err := dao.FindAllForUpdate(ctx, sqlite.LockSkipLocked, "", "")
+			return err
+		})
+		if !errors.Is(err, sqlite.ErrLockUnsupported) {
+			t.Errorf("expected ErrLockUnsupported from FindAllForUpdate, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Errorf("expected no query on sqlite, got %d", len(conn.statements))
+		}
+	})
+
+	t.Run("DAOs implement the interfaces", func(t *testing.T) {
+		outputPath := t.TempDir()
+
+		if err := generator.GenerateDAOInterfaces(testModels(), outputPath); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := generator.GenerateDAOs(testModels(), outputPath, "postgres"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, path := range []string{filepath.Join(outputPath, "dao_helpers.go"), filepath.Join(outputPath, "postgres", "dao_helpers.go")} {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(string(content), "type LockMode = int") {
+				t.Errorf("expected %s to declare LockMode as an int", path)
+			}
+		}
+	})
+}
+
+func TestNamedQueries(t *testing.T) {
+	t.Run("tenant bound from the context", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := postgres.NewInvoiceDAO(db)
+
+		if _, err := dao.FindByNumber(context.Background(), "INV-1"); !errors.Is(err, postgres.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from FindByNumber, got %v", err)
+		}
+		if _, err := dao.VoidAbove(context.Background(), 100); !errors.Is(err, postgres.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from VoidAbove, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Fatalf("expected no query without a tenant, got %d", len(conn.statements))
+		}
+
+		ctx := postgres.WithTenant(context.Background(), int64(42))
+		conn.affected = 2
+		voided, err := dao.VoidAbove(ctx, 100)
+		if err != nil || voided != 2 {
+			t.Errorf("expected 2 voided invoices, got %v, %v", voided, err)
+		}
+		assertArgs(t, conn.lastArgs(), int64(42), 100.0)
+
+		conn.rows = [][]driver.Value{{int64(1), int64(42), "INV-1", 9.5}}
+		invoice, err := dao.FindByNumber(ctx, "INV-1")
+		if err != nil || invoice.Number != "INV-1" {
+			t.Errorf("expected the invoice INV-1, got %+v, %v", invoice, err)
+		}
+		assertArgs(t, conn.lastArgs(), "INV-1", int64(42))
+	})
+
+	t.Run("unscoped query", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+
+		if err := postgres.NewInvoiceDAO(db).PurgeTenant(context.Background(), 42); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertArgs(t, conn.lastArgs(), int64(42))
+	})
+
+	t.Run("tenant model query without the tenant", func(t *testing.T) {
+		models := testModels()
+		for i := range models {
+			if models[i].Name == "Invoice" {
+				models[i].Queries = []parser.Query{{Name: "Expensive", Kind: parser.QueryMany, SQL: "SELECT * FROM billing.invoices WHERE amount > $1"}}
+			}
+		}
+
+		err := generator.GenerateDAOs(models, t.TempDir(), "postgres")
+		if err == nil {
+			t.Fatal("expected an error for a query not bound to the tenant")
+		}
+		if !strings.Contains(err.Error(), "tenant_id") || !strings.Contains(err.Error(), "unscoped") {
+			t.Errorf("expected the error to name the tenant column and the opt-in, got: %v", err)
+		}
+	})
+}
+
+func TestTypeCasts(t *testing.T) {
+	db, conn := openFakeDB(t)
+	conn.affected = 1
+
+	payment := &models.Payment{ID: "6f1c1c0e-7b0a-4c59-9f43-2f0c2b8c1d9e", Reference: "ref", Code: "code", Fee: 1.25, PaidOn: time.Now()}
+	if err := postgres.NewPaymentDAO(db).Create(context.Background(), payment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query := conn.lastQuery()
+	if !strings.Contains(query, "VALUES ($1::uuid, $2, $3, $4, $5, $6::date, $7::jsonb)") {
+		t.Errorf("expected only the uuid, date and json columns cast, got %s", query)
+	}
+}
+
+func TestQueueMethods(t *testing.T) {
+	t.Run("claim keeps only the jobs it updated", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.results = [][][]driver.Value{
+			{
+				{int64(1), int64(7), "a", "pending", nil, int64(0)},
+				{int64(2), int64(7), "b", "pending", nil, int64(0)},
+			},
+			// The second job was claimed concurrently.
+			{{int64(1)}},
+		}
+
+		jobs, err := sqlite.NewJobDAO(db).Claim(sqlite.WithTenant(context.Background(), 7), 2, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(jobs) != 1 || jobs[0].ID != 1 || jobs[0].Status != "running" || jobs[0].LeasedUntil == nil {
+			t.Errorf("expected only the first job claimed, got %+v", jobs)
+		}
+		if query := conn.lastQuery(); query != `UPDATE "jobs" SET "status" = ?, "leased_until" = ?, "version" = "version" + 1 WHERE "status" = ? AND "id" IN (?, ?) RETURNING "id"` {
+			t.Errorf("expected the update to claim only pending jobs, got %s", query)
+		}
+		if args := conn.lastArgs(); len(args) != 5 || args[0] != "running" || args[2] != "pending" {
+			t.Errorf("expected the claimed and pending statuses bound, got %v", args)
+		}
+	})
+
+	t.Run("claim updates in batches", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		for i := 0; i < 2001; i++ {
+			conn.rows = append(conn.rows, []driver.Value{int64(i + 1), int64(7), "job", "pending", nil, int64(0)})
+		}
+
+		jobs, err := sqlserver.NewJobDAO(db).Claim(sqlserver.WithTenant(context.Background(), 7), 2001, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(jobs) != 2001 {
+			t.Errorf("expected 2001 claimed jobs, got %d", len(jobs))
+		}
+		if len(conn.statements) != 3 {
+			t.Fatalf("expected a select and two updates, got %d statements", len(conn.statements))
+		}
+		if args := conn.statements[1].args; len(args) != 2003 {
+			t.Errorf("expected the first update to bind 2000 keys, got %d arguments", len(args))
+		}
+		assertArgs(t, conn.statements[2].args[3:], int64(2001))
+		if query := conn.lastQuery(); !strings.HasSuffix(query, `WHERE [status] = @p3 AND [id] IN (@p4)`) {
+			t.Errorf("expected the last key in its own update, got %s", query)
+		}
+	})
+
+	t.Run("ack of a job not claimed", func(t *testing.T) {
+		db, _ := openFakeDB(t)
+
+		err := mysql.NewJobDAO(db).Ack(mysql.WithTenant(context.Background(), 7), 5)
+		if !errors.Is(err, mysql.ErrNotClaimed) {
+			t.Fatalf("expected ErrNotClaimed, got %v", err)
+		}
+		if err.Error() != "not claimed: Job 5" {
+			t.Errorf("expected the error to name the job, got %q", err)
+		}
+	})
+}
+
+func TestTenantScoping(t *testing.T) {
+	t.Run("tenant converted to the field type", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{int64(3)}}
+		ctx := mysql.WithTenant(context.Background(), 42)
+
+		if _, err := mysql.NewInvoiceDAO(db).Count(ctx, ""); err != nil {
+			t.Fatalf("unexpected error for an int64 tenant field: %v", err)
+		}
+		assertArgs(t, conn.lastArgs(), int64(42))
+
+		if _, err := mysql.NewInvoiceLineDAO(db).Count(ctx, ""); err != nil {
+			t.Fatalf("unexpected error for an int32 tenant field: %v", err)
+		}
+		assertArgs(t, conn.lastArgs(), int64(42))
+	})
+
+	t.Run("tenant not convertible to the field type", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := mysql.NewInvoiceLineDAO(db)
+
+		for _, id := range []interface{}{"42", int64(1) << 40, uint64(1) << 63, -1.5} {
+			if _, err := dao.Count(mysql.WithTenant(context.Background(), id), ""); !errors.Is(err, mysql.ErrMissingTenant) {
+				t.Errorf("expected ErrMissingTenant for the tenant %v, got %v", id, err)
+			}
+		}
+		if len(conn.statements) != 0 {
+			t.Errorf("expected no query, got %d", len(conn.statements))
+		}
+	})
+
+	t.Run("links without a tenant", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := postgres.NewInvoiceLineDAO(db)
+		ctx := context.Background()
+
+		if err := dao.AttachTaxes(ctx, 1, 2); !errors.Is(err, postgres.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from AttachTaxes, got %v", err)
+		}
+		if err := dao.DetachTaxes(ctx, 1, 2); !errors.Is(err, postgres.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from DetachTaxes, got %v", err)
+		}
+		if err := dao.SyncTaxes(ctx, 1, []int64{2}); !errors.Is(err, postgres.ErrMissingTenant) {
+			t.Errorf("expected ErrMissingTenant from SyncTaxes, got %v", err)
+		}
+		if len(conn.statements) != 0 {
+			t.Errorf("expected no query, got %d", len(conn.statements))
+		}
+	})
+
+	t.Run("links across tenants", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		dao := postgres.NewInvoiceLineDAO(db)
+		ctx := postgres.WithTenant(context.Background(), 42)
+
+		// The line is not one of the tenant.
+		conn.rows = [][]driver.Value{{int64(0)}}
+		if err := dao.AttachTaxes(ctx, 1, 2); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for a line of another tenant, got %v", err)
+		}
+		if err := dao.SyncTaxes(ctx, 1, nil); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows from SyncTaxes for a line of another tenant, got %v", err)
+		}
+		assertArgs(t, conn.lastArgs(), int64(1), int64(42))
+
+		// Only one of the two taxes is of the tenant.
+		conn.rows = [][]driver.Value{{int64(1)}}
+		if err := dao.DetachTaxes(ctx, 1, 2, 3, 3); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for a tax of another tenant, got %v", err)
+		}
+		if query := conn.lastQuery(); query != `SELECT COUNT(*) FROM "billing"."taxes" WHERE "tenant_id" = $1 AND "id" IN ($2, $3)` {
+			t.Errorf("expected the taxes to be counted within the tenant, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(42), int64(2), int64(3))
+
+		for _, statement := range conn.statements {
+			if !strings.HasPrefix(strings.TrimSpace(statement.query), "SELECT") {
+				t.Errorf("expected no write across tenants, got %s", statement.query)
+			}
+		}
+	})
+
+	t.Run("links within the tenant", func(t *testing.T) {
+		db, conn := openFakeDB(t)
+		conn.rows = [][]driver.Value{{int64(2)}}
+		conn.affected = 2
+
+		if err := postgres.NewInvoiceLineDAO(db).AttachTaxes(postgres.WithTenant(context.Background(), 42), 1, 2, 3); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if query := conn.lastQuery(); query != `INSERT INTO "billing"."invoice_line_taxes" ("invoice_line_id", "tax_id") VALUES ($1, $2), ($3, $4)` {
+			t.Errorf("expected the links to be inserted, got %s", query)
+		}
+		assertArgs(t, conn.lastArgs(), int64(1), int64(2), int64(1), int64(3))
+	})
+
+	t.Run("join binding the tenant of the target", func(t *testing.T) {
+		invoice := testModels()[3]
+		note := parser.Model{
+			Name: "Note",
+			Fields: []parser.Field{
+				{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+				{Name: "InvoiceID", Type: "int64", Column: "invoice_id"},
+			},
+			TableName:  "notes",
+			PrimaryKey: "ID",
+			Package:    "models",
+			ImportPath: "github.com/Jibaru/gormless/internal/generator/data/models",
+			Relations: []parser.Relation{
+				{Name: "Invoice", Type: "*Invoice", Kind: parser.BelongsTo, Model: "Invoice", Column: "invoice_id", Target: &invoice},
+			},
+		}
+
+		content, err := generator.GeneratePostgresDAO(note)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(content, "if !tenantValue(ctx, &tenantID) {") || strings.Contains(content, "ctx.Value(tenantKey{})") {
+			t.Error("expected the join to convert the tenant of the context like the tenant models do")
+		}
+	})
+
+	t.Run("typed WithTenant for a shared tenant type", func(t *testing.T) {
+		var models []parser.Model
+		for _, model := range testModels() {
+			if model.Name == "Invoice" || model.Name == "Job" {
+				models = append(models, model)
+			}
+		}
+
+		outputPath := t.TempDir()
+		if err := generator.GenerateDAOs(models, outputPath, "postgres"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(outputPath, "postgres", "dao_helpers.go"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(content), "func WithTenant(ctx context.Context, id int64) context.Context") {
+			t.Error("expected WithTenant to take the int64 tenant of the models")
+		}
+	})
+}
+
+// openFakeDB opens a database answering every query with the rows of the
+// returned connection and recording the statements run on it.
+func openFakeDB(t *testing.T) (*sql.DB, *fakeConn) {
+	t.Helper()
+
+	conn := &fakeConn{}
+	db := sql.OpenDB(fakeConnector{conn})
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return db, conn
+}
+
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+}
+
+// fakeConn records the statements run on it. Queries return the first of
+// results, taken off in turn, then rows, and statements report affected,
+// which stay the same until changed.
+type fakeConn struct {
+	statements []fakeStatement
+	results    [][][]driver.Value
+	rows       [][]driver.Value
+	affected   int64
+}
+
+func (c *fakeConn) lastQuery() string {
+	if len(c.statements) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(c.statements[len(c.statements)-1].query)
+}
+
+func (c *fakeConn) lastArgs() []driver.Value {
+	if len(c.statements) == 0 {
+		return nil
+	}
+	return c.statements[len(c.statements)-1].args
+}
+
+func (c *fakeConn) record(query string, args []driver.NamedValue) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.statements = append(c.statements, fakeStatement{query: query, args: values})
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fake connections do not prepare statements")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.record(query, args)
+	if len(c.results) > 0 {
+		rows := c.results[0]
+		c.results = c.results[1:]
+		return &fakeRows{rows: rows}, nil
+	}
+	return &fakeRows{rows: c.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.record(query, args)
+	return driver.RowsAffected(c.affected), nil
+}
+
+type fakeConnector struct {
+	conn *fakeConn
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error {
+	return nil
+}
+
+func (fakeTx) Rollback() error {
+	return nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	columns := make([]string, len(r.rows[0]))
+	for i := range columns {
+		columns[i] = fmt.Sprintf("column%d", i)
+	}
+	return columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func assertArgs(t *testing.T, got []driver.Value, expected ...driver.Value) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected arguments %v, got %v", expected, got)
+	}
+}
+
+// compareDirs compares every file of the expected directory with the file of
+// the same name in the got directory, failing if either side has extra files.
+func compareDirs(t *testing.T, expectedDir, gotDir string) {
+	t.Helper()
+
+	expectedEntries, err := os.ReadDir(expectedDir)
+	if err != nil {
+		t.Fatalf("failed to read expected dir %q: %v", expectedDir, err)
+	}
+
+	gotEntries, err := os.ReadDir(gotDir)
+	if err != nil {
+		t.Fatalf("failed to read got dir %q: %v", gotDir, err)
+	}
+
+	if len(expectedEntries) != len(gotEntries) {
+		t.Fatalf("expected %d files in %q, got %d", len(expectedEntries), gotDir, len(gotEntries))
+	}
+
+	for _, entry := range expectedEntries {
+		compareFilesLineByLine(t, filepath.Join(expectedDir, entry.Name()), filepath.Join(gotDir, entry.Name()))
+	}
+}
+
 func compareFilesLineByLine(t *testing.T, expectedPath, gotPath string) {
 	t.Helper()
 
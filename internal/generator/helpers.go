@@ -0,0 +1,744 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// helpersFileName is the file holding declarations shared by every DAO of a driver package.
+const helpersFileName = "dao_helpers.go"
+
+// generateHelpersFile generates the package level declarations shared by the
+// DAOs and those required by the given models.
+func generateHelpersFile(models []parser.Model, packageName string) string {
+	var versioned, tenanted, textTimes, jsonColumns, nullZero, nullableValues, converted, enums, arrays, encrypted, joins, relations, queues bool
+
+	d, _ := dialectByName(packageName)
+	tenantTypes := map[string]bool{}
+
+	for _, model := range models {
+		if _, ok := getVersionField(model); ok {
+			versioned = true
+		}
+		if field, ok := getTenantField(model); ok {
+			tenanted = true
+			tenantTypes[field.Type] = true
+		}
+		if d.textTimes && hasTimeAggregate(model, d) {
+			textTimes = true
+		}
+		if hasJSONField(model) {
+			jsonColumns = true
+		}
+		if hasNullZeroField(model) {
+			nullZero = true
+		}
+		if hasNullableNamedField(model) {
+			nullableValues = true
+		}
+		if hasConvertedField(model) {
+			converted = true
+		}
+		if len(getEnumFields(model)) > 0 {
+			enums = true
+		}
+		if hasArrayField(model, d) {
+			arrays = true
+		}
+		if usesEncryptor(model) {
+			encrypted = true
+		}
+		if hasJoinRelation(model) {
+			joins = true
+		}
+		if len(model.Relations) > 0 {
+			relations = true
+		}
+		if hasQueue(model) {
+			queues = true
+		}
+	}
+
+	imports := map[string]bool{"context": true, "errors": true}
+	declarations := []string{generateColumnHelpers(), generateFullTableHelpers(), generateLockHelpers(d)}
+
+	if versioned {
+		imports["errors"] = true
+		declarations = append(declarations, generateStaleObjectHelpers())
+	}
+
+	if tenanted {
+		imports["context"] = true
+		imports["errors"] = true
+		imports["math"] = true
+		imports["reflect"] = true
+		declarations = append(declarations, generateTenantHelpers(tenantTypes))
+	}
+
+	if textTimes {
+		imports["database/sql"] = true
+		imports["fmt"] = true
+		imports["time"] = true
+		declarations = append(declarations, generateTextTimeHelpers())
+	}
+
+	if jsonColumns {
+		imports["database/sql/driver"] = true
+		imports["encoding/json"] = true
+		imports["fmt"] = true
+		declarations = append(declarations, generateJSONColumnHelpers())
+	}
+
+	if nullZero {
+		imports["reflect"] = true
+		declarations = append(declarations, generateNullZeroHelpers())
+	}
+
+	if converted || joins || nullableValues {
+		declarations = append(declarations, generateScannerFuncHelpers())
+	}
+
+	if joins || nullableValues {
+		imports["database/sql"] = true
+		declarations = append(declarations, generateNullableValueHelpers())
+	}
+
+	if converted {
+		imports["database/sql"] = true
+		imports["database/sql/driver"] = true
+		declarations = append(declarations, generateConverterHelpers())
+	}
+
+	if joins {
+		imports["database/sql"] = true
+		declarations = append(declarations, generateJoinHelpers())
+	}
+
+	if relations || queues {
+		declarations = append(declarations, generateBatchHelpers(d))
+	}
+
+	if queues {
+		declarations = append(declarations, generateQueueHelpers())
+	}
+
+	if enums {
+		imports["errors"] = true
+		declarations = append(declarations, generateEnumHelpers())
+	}
+
+	if arrays {
+		imports["database/sql/driver"] = true
+		imports["encoding"] = true
+		imports["fmt"] = true
+		imports["reflect"] = true
+		imports["strconv"] = true
+		imports["strings"] = true
+		declarations = append(declarations, generateArrayHelpers())
+	}
+
+	if encrypted {
+		imports["crypto/aes"] = true
+		imports["crypto/cipher"] = true
+		imports["crypto/rand"] = true
+		imports["database/sql/driver"] = true
+		imports["errors"] = true
+		imports["fmt"] = true
+		imports["io"] = true
+		declarations = append(declarations, generateEncryptionHelpers())
+	}
+
+	sortedImports := make([]string, 0, len(imports))
+	for imp := range imports {
+		sortedImports = append(sortedImports, imp)
+	}
+	sort.Strings(sortedImports)
+
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("package %s\n\n", packageName))
+	content.WriteString("import (\n")
+	for _, imp := range sortedImports {
+		content.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
+	}
+	content.WriteString(")\n\n")
+
+	content.WriteString(strings.Join(declarations, "\n"))
+
+	return content.String()
+}
+
+func generateColumnHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// ErrUnknownColumn is returned when a method is given a column name that is\n")
+	content.WriteString("// not a column of the model of the DAO.\n")
+	content.WriteString("var ErrUnknownColumn = errors.New(\"unknown column\")\n")
+
+	return content.String()
+}
+
+func generateFullTableHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// ErrFullTable is returned when DeleteWhere or UpdateWhere is called with an\n")
+	content.WriteString("// empty where clause and a context not created by AllowFullTable.\n")
+	content.WriteString("var ErrFullTable = errors.New(\"refusing to write every row without AllowFullTable\")\n\n")
+
+	content.WriteString("type fullTableKey struct{}\n\n")
+
+	content.WriteString("// AllowFullTable returns a copy of ctx letting DeleteWhere and UpdateWhere\n")
+	content.WriteString("// run with an empty where clause, writing every row of the table.\n")
+	content.WriteString("func AllowFullTable(ctx context.Context) context.Context {\n")
+	content.WriteString("\treturn context.WithValue(ctx, fullTableKey{}, true)\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func fullTableAllowed(ctx context.Context) bool {\n")
+	content.WriteString("\tallowed, _ := ctx.Value(fullTableKey{}).(bool)\n")
+	content.WriteString("\treturn allowed\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateStaleObjectHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// ErrStaleObject is returned when an update of a versioned record matches no\n")
+	content.WriteString("// row because the record was modified since it was read.\n")
+	content.WriteString("var ErrStaleObject = errors.New(\"stale object: record was modified concurrently\")\n")
+
+	return content.String()
+}
+
+// predeclaredTypes are the Go types WithTenant can name in any package.
+var predeclaredTypes = map[string]bool{
+	"string": true, "int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+}
+
+// generateTenantHelpers declares WithTenant, taking the tenant type of the
+// models when they share a predeclared one, and the conversion of the tenant
+// of a context to the tenant field of a model.
+func generateTenantHelpers(tenantTypes map[string]bool) string {
+	var content strings.Builder
+
+	idType := "interface{}"
+	if len(tenantTypes) == 1 {
+		for tenantType := range tenantTypes {
+			if predeclaredTypes[tenantType] {
+				idType = tenantType
+			}
+		}
+	}
+
+	content.WriteString("// ErrMissingTenant is returned when a tenant scoped DAO is used with a\n")
+	content.WriteString("// context carrying no tenant convertible to the tenant field of its model.\n")
+	content.WriteString("var ErrMissingTenant = errors.New(\"missing tenant in context\")\n\n")
+
+	content.WriteString("type tenantKey struct{}\n\n")
+
+	content.WriteString("// WithTenant returns a copy of ctx scoping tenant aware DAOs to the given\n")
+	if idType == "interface{}" {
+		content.WriteString("// tenant. id is converted to the type of the tenant field of each model,\n")
+		content.WriteString("// between integer types or from a type of the same kind.\n")
+	} else {
+		content.WriteString("// tenant.\n")
+	}
+	content.WriteString(fmt.Sprintf("func WithTenant(ctx context.Context, id %s) context.Context {\n", idType))
+	content.WriteString("\treturn context.WithValue(ctx, tenantKey{}, id)\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// tenantValue stores the tenant of ctx in target, a pointer to a tenant\n")
+	content.WriteString("// field, converting between integer types as long as the value fits, and\n")
+	content.WriteString("// reports whether ctx carries a tenant of a type convertible to it.\n")
+	content.WriteString("func tenantValue(ctx context.Context, target interface{}) bool {\n")
+	content.WriteString("\tvalue := reflect.ValueOf(ctx.Value(tenantKey{}))\n")
+	content.WriteString("\tdest := reflect.ValueOf(target).Elem()\n")
+	content.WriteString("\tif !value.IsValid() {\n")
+	content.WriteString("\t\treturn false\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tswitch {\n")
+	content.WriteString("\tcase value.Type() == dest.Type():\n")
+	content.WriteString("\t\tdest.Set(value)\n")
+	content.WriteString("\tcase value.CanInt() && dest.CanInt():\n")
+	content.WriteString("\t\tif dest.OverflowInt(value.Int()) {\n")
+	content.WriteString("\t\t\treturn false\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tdest.SetInt(value.Int())\n")
+	content.WriteString("\tcase value.CanInt() && dest.CanUint():\n")
+	content.WriteString("\t\tif value.Int() < 0 || dest.OverflowUint(uint64(value.Int())) {\n")
+	content.WriteString("\t\t\treturn false\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tdest.SetUint(uint64(value.Int()))\n")
+	content.WriteString("\tcase value.CanUint() && dest.CanUint():\n")
+	content.WriteString("\t\tif dest.OverflowUint(value.Uint()) {\n")
+	content.WriteString("\t\t\treturn false\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tdest.SetUint(value.Uint())\n")
+	content.WriteString("\tcase value.CanUint() && dest.CanInt():\n")
+	content.WriteString("\t\tif value.Uint() > math.MaxInt64 || dest.OverflowInt(int64(value.Uint())) {\n")
+	content.WriteString("\t\t\treturn false\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tdest.SetInt(int64(value.Uint()))\n")
+	content.WriteString("\tcase value.Kind() == dest.Kind() && value.Type().ConvertibleTo(dest.Type()):\n")
+	content.WriteString("\t\tdest.Set(value.Convert(dest.Type()))\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn false\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn true\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateEnumHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// ErrInvalidEnum is returned when a model is written with an enum field\n")
+	content.WriteString("// holding a value outside the constants declared for its type.\n")
+	content.WriteString("var ErrInvalidEnum = errors.New(\"invalid enum value\")\n")
+
+	return content.String()
+}
+
+func generateJSONColumnHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// jsonColumn marshals v into a JSON column on write and unmarshals the column\n")
+	content.WriteString("// into v, which must then be a pointer, on scan.\n")
+	content.WriteString("type jsonColumn struct {\n")
+	content.WriteString("\tv interface{}\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (c jsonColumn) Value() (driver.Value, error) {\n")
+	content.WriteString("\tdata, err := json.Marshal(c.v)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn string(data), nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (c jsonColumn) Scan(src interface{}) error {\n")
+	content.WriteString("\tswitch data := src.(type) {\n")
+	content.WriteString("\tcase nil:\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\tcase []byte:\n")
+	content.WriteString("\t\treturn json.Unmarshal(data, c.v)\n")
+	content.WriteString("\tcase string:\n")
+	content.WriteString("\t\treturn json.Unmarshal([]byte(data), c.v)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn fmt.Errorf(\"cannot scan %T into a JSON column\", src)\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateNullZeroHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// nullIfZero returns nil, written as NULL, for the zero value of v.\n")
+	content.WriteString("func nullIfZero(v interface{}) interface{} {\n")
+	content.WriteString("\tif z, ok := v.(interface{ IsZero() bool }); ok {\n")
+	content.WriteString("\t\tif z.IsZero() {\n")
+	content.WriteString("\t\t\treturn nil\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\treturn v\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif v == nil || reflect.ValueOf(v).IsZero() {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn v\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateScannerFuncHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("type scannerFunc func(src interface{}) error\n\n")
+	content.WriteString("func (f scannerFunc) Scan(src interface{}) error {\n")
+	content.WriteString("\treturn f(src)\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateConverterHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("type valuerFunc func() (driver.Value, error)\n\n")
+	content.WriteString("func (f valuerFunc) Value() (driver.Value, error) {\n")
+	content.WriteString("\treturn f()\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// convertedValue encodes value with encode when it has the type encode\n")
+	content.WriteString("// converts, and returns it unchanged otherwise.\n")
+	content.WriteString("func convertedValue[T any](value interface{}, encode func(T) (driver.Value, error)) interface{} {\n")
+	content.WriteString("\tv, ok := value.(T)\n")
+	content.WriteString("\tif !ok {\n")
+	content.WriteString("\t\treturn value\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn valuerFunc(func() (driver.Value, error) {\n")
+	content.WriteString("\t\treturn encode(v)\n")
+	content.WriteString("\t})\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// convertedScanner scans a column into dest through decode.\n")
+	content.WriteString("func convertedScanner[T any](dest *T, decode func(interface{}) (T, error)) sql.Scanner {\n")
+	content.WriteString("\treturn scannerFunc(func(src interface{}) error {\n")
+	content.WriteString("\t\tv, err := decode(src)\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\t*dest = v\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t})\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateNullableValueHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// nullableValue scans a column into dest, leaving the zero value for NULL.\n")
+	content.WriteString("func nullableValue[T any](dest *T) sql.Scanner {\n")
+	content.WriteString("\treturn scannerFunc(func(src interface{}) error {\n")
+	content.WriteString("\t\tvar value sql.Null[T]\n")
+	content.WriteString("\t\tif err := value.Scan(src); err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\t*dest = value.V\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t})\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateJoinHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// joinedColumn scans a column of the optional side of a LEFT JOIN through\n")
+	content.WriteString("// scanner, skipping NULL and recording in found whether the column held a value.\n")
+	content.WriteString("func joinedColumn(found *bool, scanner sql.Scanner) sql.Scanner {\n")
+	content.WriteString("\treturn scannerFunc(func(src interface{}) error {\n")
+	content.WriteString("\t\tif src == nil {\n")
+	content.WriteString("\t\t\treturn nil\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\t*found = true\n")
+	content.WriteString("\t\treturn scanner.Scan(src)\n")
+	content.WriteString("\t})\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateArrayHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// pgArray encodes a slice into a postgres array literal on write and decodes\n")
+	content.WriteString("// an array literal into the slice v points to on scan.\n")
+	content.WriteString("type pgArray struct {\n")
+	content.WriteString("\tv interface{}\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (a pgArray) Value() (driver.Value, error) {\n")
+	content.WriteString("\trv := reflect.ValueOf(a.v)\n")
+	content.WriteString("\tif rv.Kind() != reflect.Slice {\n")
+	content.WriteString("\t\treturn nil, fmt.Errorf(\"cannot encode %T as an array\", a.v)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif rv.IsNil() {\n")
+	content.WriteString("\t\treturn nil, nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tvar literal strings.Builder\n")
+	content.WriteString("\tliteral.WriteByte('{')\n")
+	content.WriteString("\tfor i := 0; i < rv.Len(); i++ {\n")
+	content.WriteString("\t\tif i > 0 {\n")
+	content.WriteString("\t\t\tliteral.WriteByte(',')\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\telem, err := pgArrayElement(rv.Index(i))\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn nil, err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tliteral.WriteString(elem)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tliteral.WriteByte('}')\n")
+	content.WriteString("\treturn literal.String(), nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (a pgArray) Scan(src interface{}) error {\n")
+	content.WriteString("\trv := reflect.ValueOf(a.v)\n")
+	content.WriteString("\tif rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {\n")
+	content.WriteString("\t\treturn fmt.Errorf(\"cannot decode an array into %T\", a.v)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tslice := rv.Elem()\n\n")
+
+	content.WriteString("\tvar literal string\n")
+	content.WriteString("\tswitch data := src.(type) {\n")
+	content.WriteString("\tcase nil:\n")
+	content.WriteString("\t\tslice.Set(reflect.Zero(slice.Type()))\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\tcase []byte:\n")
+	content.WriteString("\t\tliteral = string(data)\n")
+	content.WriteString("\tcase string:\n")
+	content.WriteString("\t\tliteral = data\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn fmt.Errorf(\"cannot scan %T into an array\", src)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\telems, err := pgArrayParse(literal)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tresult := reflect.MakeSlice(slice.Type(), len(elems), len(elems))\n")
+	content.WriteString("\tfor i, elem := range elems {\n")
+	content.WriteString("\t\tif elem == nil {\n")
+	content.WriteString("\t\t\tcontinue\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tif err := pgArraySet(result.Index(i), *elem); err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tslice.Set(result)\n")
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func pgArrayElement(v reflect.Value) (string, error) {\n")
+	content.WriteString("\tif m, ok := v.Interface().(encoding.TextMarshaler); ok {\n")
+	content.WriteString("\t\ttext, err := m.MarshalText()\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn \"\", err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\treturn pgArrayQuote(string(text)), nil\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tswitch v.Kind() {\n")
+	content.WriteString("\tcase reflect.String:\n")
+	content.WriteString("\t\treturn pgArrayQuote(v.String()), nil\n")
+	content.WriteString("\tcase reflect.Bool:\n")
+	content.WriteString("\t\tif v.Bool() {\n")
+	content.WriteString("\t\t\treturn \"t\", nil\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\treturn \"f\", nil\n")
+	content.WriteString("\tcase reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:\n")
+	content.WriteString("\t\treturn strconv.FormatInt(v.Int(), 10), nil\n")
+	content.WriteString("\tcase reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:\n")
+	content.WriteString("\t\treturn strconv.FormatUint(v.Uint(), 10), nil\n")
+	content.WriteString("\tcase reflect.Float32, reflect.Float64:\n")
+	content.WriteString("\t\treturn strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif s, ok := v.Interface().(fmt.Stringer); ok {\n")
+	content.WriteString("\t\treturn pgArrayQuote(s.String()), nil\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn \"\", fmt.Errorf(\"cannot encode %s as an array element\", v.Type())\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func pgArrayQuote(s string) string {\n")
+	content.WriteString("\treturn `\"` + strings.NewReplacer(`\\`, `\\\\`, `\"`, `\\\"`).Replace(s) + `\"`\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// pgArrayParse splits a one-dimensional array literal into its elements,\n")
+	content.WriteString("// which are nil for NULL.\n")
+	content.WriteString("func pgArrayParse(literal string) ([]*string, error) {\n")
+	content.WriteString("\tif len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {\n")
+	content.WriteString("\t\treturn nil, fmt.Errorf(\"invalid array literal %q\", literal)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tbody := literal[1 : len(literal)-1]\n\n")
+
+	content.WriteString("\tvar elems []*string\n")
+	content.WriteString("\tif body == \"\" {\n")
+	content.WriteString("\t\treturn elems, nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tfor i := 0; i <= len(body); i++ {\n")
+	content.WriteString("\t\tvar elem strings.Builder\n")
+	content.WriteString("\t\tquoted := i < len(body) && body[i] == '\"'\n")
+	content.WriteString("\t\tif quoted {\n")
+	content.WriteString("\t\t\tfor i++; i < len(body) && body[i] != '\"'; i++ {\n")
+	content.WriteString("\t\t\t\tif body[i] == '\\\\' && i+1 < len(body) {\n")
+	content.WriteString("\t\t\t\t\ti++\n")
+	content.WriteString("\t\t\t\t}\n")
+	content.WriteString("\t\t\t\telem.WriteByte(body[i])\n")
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t\tif i == len(body) {\n")
+	content.WriteString("\t\t\t\treturn nil, fmt.Errorf(\"invalid array literal %q\", literal)\n")
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t\ti++\n")
+	content.WriteString("\t\t} else {\n")
+	content.WriteString("\t\t\tfor ; i < len(body) && body[i] != ','; i++ {\n")
+	content.WriteString("\t\t\t\telem.WriteByte(body[i])\n")
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tif i < len(body) && body[i] != ',' {\n")
+	content.WriteString("\t\t\treturn nil, fmt.Errorf(\"invalid array literal %q\", literal)\n")
+	content.WriteString("\t\t}\n\n")
+
+	content.WriteString("\t\tvalue := elem.String()\n")
+	content.WriteString("\t\tif !quoted && strings.EqualFold(value, \"NULL\") {\n")
+	content.WriteString("\t\t\telems = append(elems, nil)\n")
+	content.WriteString("\t\t} else {\n")
+	content.WriteString("\t\t\telems = append(elems, &value)\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn elems, nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func pgArraySet(v reflect.Value, s string) error {\n")
+	content.WriteString("\tif u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {\n")
+	content.WriteString("\t\treturn u.UnmarshalText([]byte(s))\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tswitch v.Kind() {\n")
+	content.WriteString("\tcase reflect.String:\n")
+	content.WriteString("\t\tv.SetString(s)\n")
+	content.WriteString("\tcase reflect.Bool:\n")
+	content.WriteString("\t\tv.SetBool(s == \"t\" || s == \"true\")\n")
+	content.WriteString("\tcase reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:\n")
+	content.WriteString("\t\tn, err := strconv.ParseInt(s, 10, v.Type().Bits())\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tv.SetInt(n)\n")
+	content.WriteString("\tcase reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:\n")
+	content.WriteString("\t\tn, err := strconv.ParseUint(s, 10, v.Type().Bits())\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tv.SetUint(n)\n")
+	content.WriteString("\tcase reflect.Float32, reflect.Float64:\n")
+	content.WriteString("\t\tn, err := strconv.ParseFloat(s, v.Type().Bits())\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tv.SetFloat(n)\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn fmt.Errorf(\"cannot decode an array element into %s\", v.Type())\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// Array binds a slice to a postgres array placeholder, for use with\n")
+	content.WriteString("// ArrayContains and ArrayOverlaps.\n")
+	content.WriteString("func Array(v interface{}) interface{} {\n")
+	content.WriteString("\treturn pgArray{v}\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// ArrayContains returns a condition matching rows whose array column holds\n")
+	content.WriteString("// every element of the array bound to the n-th placeholder.\n")
+	content.WriteString("func ArrayContains(column string, n int) string {\n")
+	content.WriteString("\treturn fmt.Sprintf(`\"%s\" @> $%d`, strings.ReplaceAll(column, `\"`, `\"\"`), n)\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// ArrayOverlaps returns a condition matching rows whose array column holds\n")
+	content.WriteString("// any element of the array bound to the n-th placeholder.\n")
+	content.WriteString("func ArrayOverlaps(column string, n int) string {\n")
+	content.WriteString("\treturn fmt.Sprintf(`\"%s\" && $%d`, strings.ReplaceAll(column, `\"`, `\"\"`), n)\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+func generateEncryptionHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// Encryptor encrypts the values of encrypted columns before they are written\n")
+	content.WriteString("// and decrypts them when they are scanned.\n")
+	content.WriteString("type Encryptor interface {\n")
+	content.WriteString("\tEncrypt(plaintext []byte) ([]byte, error)\n")
+	content.WriteString("\tDecrypt(ciphertext []byte) ([]byte, error)\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// NewAESEncryptor returns an Encryptor sealing values with AES-GCM under key,\n")
+	content.WriteString("// which must be 16, 24 or 32 bytes long. Each value is stored as a random\n")
+	content.WriteString("// nonce followed by the sealed value.\n")
+	content.WriteString("func NewAESEncryptor(key []byte) (Encryptor, error) {\n")
+	content.WriteString("\tblock, err := aes.NewCipher(key)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\taead, err := cipher.NewGCM(block)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn aesEncryptor{aead: aead}, nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("type aesEncryptor struct {\n")
+	content.WriteString("\taead cipher.AEAD\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (e aesEncryptor) Encrypt(plaintext []byte) ([]byte, error) {\n")
+	content.WriteString("\tnonce := make([]byte, e.aead.NonceSize())\n")
+	content.WriteString("\tif _, err := io.ReadFull(rand.Reader, nonce); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn e.aead.Seal(nonce, nonce, plaintext, nil), nil\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (e aesEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {\n")
+	content.WriteString("\tsize := e.aead.NonceSize()\n")
+	content.WriteString("\tif len(ciphertext) < size {\n")
+	content.WriteString("\t\treturn nil, errors.New(\"encrypted value is too short\")\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn e.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("// encryptedColumn encrypts v, a string or []byte, on write and decrypts the\n")
+	content.WriteString("// column into v, which must then be a *string or *[]byte, on scan. A nil v\n")
+	content.WriteString("// is written as NULL.\n")
+	content.WriteString("type encryptedColumn struct {\n")
+	content.WriteString("\tencryptor Encryptor\n")
+	content.WriteString("\tv         interface{}\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (c encryptedColumn) Value() (driver.Value, error) {\n")
+	content.WriteString("\tswitch v := c.v.(type) {\n")
+	content.WriteString("\tcase nil:\n")
+	content.WriteString("\t\treturn nil, nil\n")
+	content.WriteString("\tcase string:\n")
+	content.WriteString("\t\treturn c.encryptor.Encrypt([]byte(v))\n")
+	content.WriteString("\tcase []byte:\n")
+	content.WriteString("\t\tif v == nil {\n")
+	content.WriteString("\t\t\treturn nil, nil\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\treturn c.encryptor.Encrypt(v)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn nil, fmt.Errorf(\"cannot encrypt %T\", c.v)\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString("func (c encryptedColumn) Scan(src interface{}) error {\n")
+	content.WriteString("\tvar ciphertext []byte\n")
+	content.WriteString("\tswitch data := src.(type) {\n")
+	content.WriteString("\tcase nil:\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\tcase []byte:\n")
+	content.WriteString("\t\tciphertext = data\n")
+	content.WriteString("\tcase string:\n")
+	content.WriteString("\t\tciphertext = []byte(data)\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn fmt.Errorf(\"cannot scan %T into an encrypted column\", src)\n")
+	content.WriteString("\t}\n\n")
+	content.WriteString("\tplaintext, err := c.encryptor.Decrypt(ciphertext)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+	content.WriteString("\tswitch dest := c.v.(type) {\n")
+	content.WriteString("\tcase *string:\n")
+	content.WriteString("\t\t*dest = string(plaintext)\n")
+	content.WriteString("\tcase *[]byte:\n")
+	content.WriteString("\t\t*dest = plaintext\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn fmt.Errorf(\"cannot decrypt into %T\", c.v)\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n")
+
+	return content.String()
+}
@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/naming"
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// hasJoinRelation reports whether model has relations joined in a single query.
+func hasJoinRelation(model parser.Model) bool {
+	for _, relation := range model.Relations {
+		if relation.Kind == parser.BelongsTo || relation.Kind == parser.HasMany {
+			return true
+		}
+	}
+	return false
+}
+
+// joinRowType returns the name of the rows returned by the join methods of relation.
+func joinRowType(model parser.Model, relation parser.Relation) string {
+	return fmt.Sprintf("%sWith%s", model.Name, relation.Name)
+}
+
+// joinAliases returns the table aliases of model and of the target of
+// relation in the join queries of relation.
+func joinAliases(model parser.Model, relation parser.Relation) (string, string, error) {
+	alias := model.TableName
+	relatedAlias := naming.ToSnakeCase(relation.Name)
+	if alias == relatedAlias {
+		return "", "", fmt.Errorf("the %s relation of the %s model has the alias %q of the %s table", relation.Name, model.Name, relatedAlias, model.TableName)
+	}
+	return alias, relatedAlias, nil
+}
+
+// generateJoinRowTypes declares the rows returned by the join methods of
+// model. They alias unnamed structs so the DAO interfaces and every driver
+// package share the same types.
+func generateJoinRowTypes(model parser.Model) string {
+	var content strings.Builder
+
+	for _, relation := range model.Relations {
+		if relation.Kind != parser.BelongsTo && relation.Kind != parser.HasMany {
+			continue
+		}
+
+		rowType := joinRowType(model, relation)
+		if relation.Kind == parser.BelongsTo {
+			content.WriteString(fmt.Sprintf("// %s is a row of %s joined with its %s.\n", rowType, model.Name, relation.Name))
+		} else {
+			content.WriteString(fmt.Sprintf("// %s is a row of %s joined with one of its %s.\n", rowType, model.Name, relation.Name))
+		}
+		content.WriteString(fmt.Sprintf("type %s = struct {\n", rowType))
+		content.WriteString(fmt.Sprintf("\t%s %s\n", model.Name, model.Name))
+		content.WriteString(fmt.Sprintf("\t%s *%s\n", relation.Name, relation.Target.Name))
+		content.WriteString("}\n\n")
+	}
+
+	return content.String()
+}
+
+// generateJoinMethods generates the Join and LeftJoin methods of relation.
+func generateJoinMethods(model parser.Model, relation parser.Relation, daoName string, d dialect) (string, error) {
+	innerJoin, err := generateJoinMethod(model, relation, daoName, "Join"+relation.Name, "INNER JOIN", d)
+	if err != nil {
+		return "", err
+	}
+	leftJoin, err := generateJoinMethod(model, relation, daoName, "LeftJoin"+relation.Name, "LEFT JOIN", d)
+	if err != nil {
+		return "", err
+	}
+	return innerJoin + leftJoin, nil
+}
+
+// generateJoinMethod generates the method reading model and the target of
+// relation in one query, with join being INNER JOIN or LEFT JOIN. Columns are
+// aliased after their table so both sides keep apart columns sharing a name.
+func generateJoinMethod(model parser.Model, relation parser.Relation, daoName, methodName, join string, d dialect) (string, error) {
+	var content strings.Builder
+	target := *relation.Target
+	optional := join == "LEFT JOIN"
+
+	alias, relatedAlias, err := joinAliases(model, relation)
+	if err != nil {
+		return "", err
+	}
+
+	var columns []string
+	var scanArgs []string
+	for _, field := range model.Fields {
+		columns = append(columns, fmt.Sprintf("%s.%s AS %s", d.quote(alias), d.quote(field.Column), d.quote(alias+"__"+field.Column)))
+		scanArgs = append(scanArgs, generateScanArg(d, field, "m"))
+	}
+	for _, field := range target.Fields {
+		columns = append(columns, fmt.Sprintf("%s.%s AS %s", d.quote(relatedAlias), d.quote(field.Column), d.quote(relatedAlias+"__"+field.Column)))
+		scanArgs = append(scanArgs, generateJoinedScanArg(d, field, "related", optional))
+	}
+
+	var on string
+	if relation.Kind == parser.BelongsTo {
+		on = fmt.Sprintf("%s.%s = %s.%s", d.quote(relatedAlias), d.quote(getPrimaryColumn(target)), d.quote(alias), d.quote(relation.Column))
+	} else {
+		on = fmt.Sprintf("%s.%s = %s.%s", d.quote(relatedAlias), d.quote(relation.Column), d.quote(alias), d.quote(getPrimaryColumn(model)))
+	}
+	if field, ok := getSoftDeleteField(target); ok {
+		on += fmt.Sprintf(" AND %s.%s IS NULL", d.quote(relatedAlias), d.quote(field.Column))
+	}
+
+	var conditions []string
+	if field, ok := getSoftDeleteField(model); ok {
+		conditions = append(conditions, fmt.Sprintf("%s.%s IS NULL", d.quote(alias), d.quote(field.Column)))
+	}
+
+	rowType := joinRowType(model, relation)
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, methodName, rowType))
+
+	// The tenant of the context scopes the model, and the target through the
+	// tenant column of the model or, when the model has none, a bound tenant.
+	tenantField, tenanted := getTenantField(model)
+	relatedTenantField, relatedTenanted := getTenantField(target)
+	boundTenant := relatedTenanted && !tenanted
+	switch {
+	case tenanted:
+		content.WriteString(generateTenantPrelude(model, "nil, "))
+		content.WriteString("\targs = append(args, tenantID)\n")
+		condition := fmt.Sprintf("%s.%s = %s", d.quote(alias), d.quote(tenantField.Column), d.placeholder)
+		if d.isPositional() {
+			content.WriteString(fmt.Sprintf("\tcondition := %q\n", condition))
+		} else {
+			content.WriteString(fmt.Sprintf("\tcondition := fmt.Sprintf(%q, len(args))\n", condition))
+		}
+		content.WriteString("\tif where != \"\" {\n")
+		content.WriteString("\t\tcondition = \"(\" + where + \") AND \" + condition\n")
+		content.WriteString("\t}\n")
+		content.WriteString("\twhere = condition\n\n")
+		if relatedTenanted {
+			on += fmt.Sprintf(" AND %s.%s = %s.%s", d.quote(relatedAlias), d.quote(relatedTenantField.Column), d.quote(alias), d.quote(tenantField.Column))
+		}
+	case boundTenant:
+		content.WriteString(fmt.Sprintf("\tvar tenantID %s\n", relatedTenantField.Type))
+		content.WriteString("\tif !tenantValue(ctx, &tenantID) {\n")
+		content.WriteString("\t\treturn nil, ErrMissingTenant\n")
+		content.WriteString("\t}\n")
+		if d.isPositional() {
+			// The join condition binds the tenant before any placeholder of where.
+			content.WriteString("\targs = append([]interface{}{tenantID}, args...)\n\n")
+		} else {
+			content.WriteString("\targs = append(args, tenantID)\n\n")
+		}
+		on += fmt.Sprintf(" AND %s.%s = %s", d.quote(relatedAlias), d.quote(relatedTenantField.Column), d.placeholder)
+	}
+
+	query := fmt.Sprintf("\n\t\tSELECT %s\n\t\tFROM %s %s\n\t\t%s %s %s ON %s\n%s\t",
+		strings.Join(columns, ", "),
+		d.table(model), d.quote(alias),
+		join, d.table(target), d.quote(relatedAlias), on,
+		generateWhereConditions(conditions))
+	if boundTenant && !d.isPositional() {
+		content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, len(args))\n\n", d.literal(query)))
+	} else {
+		content.WriteString(fmt.Sprintf("\tquery := %s\n\n", d.literal(query)))
+	}
+
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	content.WriteString("\tif sort != \"\" {\n")
+	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\trows, err := dao.queryContext(ctx, query, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tdefer rows.Close()\n\n")
+
+	content.WriteString(fmt.Sprintf("\tvar results []*%s\n", rowType))
+	content.WriteString("\tfor rows.Next() {\n")
+	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(fmt.Sprintf("\t\tvar related %s\n", target.Name))
+	if optional {
+		content.WriteString("\t\tvar found bool\n")
+	}
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
+	content.WriteString("\t\terr := rows.Scan(\n")
+	for _, arg := range scanArgs {
+		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
+	}
+	content.WriteString("\t\t)\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn nil, err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tresult := &%s{%s: m}\n", rowType, model.Name))
+	if optional {
+		content.WriteString("\t\tif found {\n")
+		content.WriteString(fmt.Sprintf("\t\t\tresult.%s = &related\n", relation.Name))
+		content.WriteString("\t\t}\n")
+	} else {
+		content.WriteString(fmt.Sprintf("\t\tresult.%s = &related\n", relation.Name))
+	}
+	content.WriteString("\t\tresults = append(results, result)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif err := rows.Err(); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn results, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String(), nil
+}
+
+// generateJoinedScanArg renders the Scan destination reading field of the
+// joined model into target. Nullable plain fields are read through
+// nullableValue, and on the optional side of a LEFT JOIN every column is read
+// through joinedColumn, recording in found whether the row matched.
+func generateJoinedScanArg(d dialect, field parser.Field, target string, optional bool) string {
+	var arg string
+	switch {
+	case field.IsEncrypted || field.Converter != nil || d.isArray(field) || field.IsJSON:
+		arg = generateScanArg(d, field, target)
+	case field.IsNullable || optional:
+		arg = fmt.Sprintf("nullableValue(&%s.%s)", target, field.Name)
+	default:
+		arg = fmt.Sprintf("&%s.%s", target, field.Name)
+	}
+	if optional {
+		return fmt.Sprintf("joinedColumn(&found, %s)", arg)
+	}
+	return arg
+}
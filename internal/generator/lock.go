@@ -0,0 +1,246 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// generateLockMethods generates the FindByPkForUpdate and FindAllForUpdate
+// methods, which lock the rows they read until the transaction of the
+// context ends.
+func generateLockMethods(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+
+	if d.locking == lockUnsupported {
+		content.WriteString(fmt.Sprintf("func (dao *%s) FindByPkForUpdate(ctx context.Context, pk %s, lock LockMode) (*%s, error) {\n", daoName, getPrimaryType(model), model.Name))
+		content.WriteString("\treturn nil, ErrLockUnsupported\n")
+		content.WriteString("}\n\n")
+
+		content.WriteString(fmt.Sprintf("func (dao *%s) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+		content.WriteString("\treturn nil, ErrLockUnsupported\n")
+		content.WriteString("}\n\n")
+
+		return content.String()
+	}
+
+	content.WriteString(generateFindByPkForUpdateMethod(model, daoName, d))
+	content.WriteString(generateFindAllForUpdateMethod(model, daoName, d))
+
+	return content.String()
+}
+
+// generateLockModeCheck rejects a lock mode other than the declared ones,
+// which the database would otherwise lock with as if it were LockWait.
+func generateLockModeCheck() string {
+	var content strings.Builder
+
+	content.WriteString("\tswitch lock {\n")
+	content.WriteString("\tcase LockWait, LockNoWait, LockSkipLocked:\n")
+	content.WriteString("\tdefault:\n")
+	content.WriteString("\t\treturn nil, fmt.Errorf(\"%w: %d\", ErrUnknownLockMode, lock)\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateTransactionCheck refuses to lock rows outside of a transaction,
+// where the locks would be released as soon as the query ends.
+func generateTransactionCheck() string {
+	var content strings.Builder
+
+	content.WriteString("\tif dao.getTx(ctx) == nil {\n")
+	content.WriteString("\t\treturn nil, ErrNoTransaction\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// lockedQuery returns the Go expression of query locking the rows it reads,
+// given the position of the table in query.
+func lockedQuery(d dialect, before, after string) string {
+	if d.locking == lockTableHint {
+		return fmt.Sprintf("%s + lockHint(lock) + %s", d.literal(before), d.literal(after))
+	}
+	return d.literal(before + after)
+}
+
+func generateFindByPkForUpdateMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	var columns []string
+	var scanArgs []string
+
+	for _, field := range model.Fields {
+		columns = append(columns, d.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(d, field, "m"))
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) FindByPkForUpdate(ctx context.Context, pk %s, lock LockMode) (*%s, error) {\n", daoName, getPrimaryType(model), model.Name))
+	content.WriteString(generateLockModeCheck())
+	content.WriteString(generateTransactionCheck())
+	content.WriteString(generateTenantPrelude(model, "nil, "))
+	before := fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), d.table(model))
+	after := fmt.Sprintf(" WHERE %s = %s%s%s", d.quote(getPrimaryColumn(model)), d.bind(1), andConditions(defaultConditions(model, d)), tenantCondition(model, d, 2))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", lockedQuery(d, before, after)))
+	if d.locking == lockForUpdate {
+		content.WriteString("\tquery += lockClause(lock)\n")
+	}
+	content.WriteString(fmt.Sprintf("\trow := dao.queryRowContext(ctx, query, %s%s)\n\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
+
+	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
+	content.WriteString("\terr := row.Scan(\n")
+	for _, arg := range scanArgs {
+		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	}
+	content.WriteString("\t)\n\n")
+
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
+	content.WriteString("\treturn &m, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+func generateFindAllForUpdateMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+	var columns []string
+	var scanArgs []string
+
+	for _, field := range model.Fields {
+		columns = append(columns, d.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(d, field, "m"))
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) FindAllForUpdate(ctx context.Context, lock LockMode, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString(generateLockModeCheck())
+	content.WriteString(generateTransactionCheck())
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
+	before := fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), d.table(model))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", lockedQuery(d, before, whereConditions(conditions))))
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	content.WriteString("\tif sort != \"\" {\n")
+	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
+	content.WriteString("\t}\n")
+	if d.locking == lockForUpdate {
+		content.WriteString("\tquery += lockClause(lock)\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString("\trows, err := dao.queryContext(ctx, query, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tdefer rows.Close()\n\n")
+
+	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
+	content.WriteString("\tfor rows.Next() {\n")
+	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
+	content.WriteString("\t\terr := rows.Scan(\n")
+	for _, arg := range scanArgs {
+		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
+	}
+	content.WriteString("\t\t)\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn nil, err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
+	content.WriteString("\t\tmodels = append(models, &m)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif err := rows.Err(); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn models, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateLockHelpers generates the lock modes and the errors of the lock
+// methods, and the function rendering a lock mode in the SQL of d.
+func generateLockHelpers(d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(generateLockModes())
+
+	content.WriteString("// ErrNoTransaction is returned when rows are locked with a context not\n")
+	content.WriteString("// carrying a transaction started by WithTransaction.\n")
+	content.WriteString("var ErrNoTransaction = errors.New(\"locking rows requires a transaction\")\n\n")
+
+	switch d.locking {
+	case lockForUpdate:
+		content.WriteString(generateUnknownLockModeError())
+		content.WriteString("func lockClause(lock LockMode) string {\n")
+		content.WriteString("\tswitch lock {\n")
+		content.WriteString("\tcase LockNoWait:\n")
+		content.WriteString("\t\treturn \" FOR UPDATE NOWAIT\"\n")
+		content.WriteString("\tcase LockSkipLocked:\n")
+		content.WriteString("\t\treturn \" FOR UPDATE SKIP LOCKED\"\n")
+		content.WriteString("\tdefault:\n")
+		content.WriteString("\t\treturn \" FOR UPDATE\"\n")
+		content.WriteString("\t}\n")
+		content.WriteString("}\n")
+	case lockTableHint:
+		content.WriteString(generateUnknownLockModeError())
+		content.WriteString("func lockHint(lock LockMode) string {\n")
+		content.WriteString("\tswitch lock {\n")
+		content.WriteString("\tcase LockNoWait:\n")
+		content.WriteString("\t\treturn \" WITH (UPDLOCK, ROWLOCK, NOWAIT)\"\n")
+		content.WriteString("\tcase LockSkipLocked:\n")
+		content.WriteString("\t\treturn \" WITH (UPDLOCK, ROWLOCK, READPAST)\"\n")
+		content.WriteString("\tdefault:\n")
+		content.WriteString("\t\treturn \" WITH (UPDLOCK, ROWLOCK)\"\n")
+		content.WriteString("\t}\n")
+		content.WriteString("}\n")
+	default:
+		content.WriteString("// ErrLockUnsupported is returned by the lock methods of databases without\n")
+		content.WriteString("// row-level locks.\n")
+		content.WriteString(fmt.Sprintf("var ErrLockUnsupported = errors.New(\"%s does not support row-level locks\")\n", d.name))
+	}
+
+	return content.String()
+}
+
+// generateLockModes declares the lock modes, in the package of the DAO
+// interfaces and in each driver package alike.
+func generateLockModes() string {
+	var content strings.Builder
+
+	content.WriteString("// LockMode is how FindByPkForUpdate and FindAllForUpdate behave when a row\n")
+	content.WriteString("// they read is already locked by another transaction. It is an int, as in the\n")
+	content.WriteString("// package of the DAO interfaces and every driver package, so that the DAOs of\n")
+	content.WriteString("// any driver implement the interfaces. The lock methods reject values other\n")
+	content.WriteString("// than the modes below.\n")
+	content.WriteString("type LockMode = int\n\n")
+
+	content.WriteString("const (\n")
+	content.WriteString("\t// LockWait waits for the other transaction to release the row.\n")
+	content.WriteString("\tLockWait LockMode = iota\n")
+	content.WriteString("\t// LockNoWait fails instead of waiting.\n")
+	content.WriteString("\tLockNoWait\n")
+	content.WriteString("\t// LockSkipLocked leaves the locked rows out of the results.\n")
+	content.WriteString("\tLockSkipLocked\n")
+	content.WriteString(")\n\n")
+
+	return content.String()
+}
+
+func generateUnknownLockModeError() string {
+	var content strings.Builder
+
+	content.WriteString("// ErrUnknownLockMode is returned when rows are locked with a LockMode other\n")
+	content.WriteString("// than LockWait, LockNoWait and LockSkipLocked.\n")
+	content.WriteString("var ErrUnknownLockMode = errors.New(\"unknown lock mode\")\n\n")
+
+	return content.String()
+}
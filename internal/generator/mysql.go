@@ -2,20 +2,32 @@ package generator
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Jibaru/gormless/internal/parser"
 )
 
 func GenerateMySQLDAO(model parser.Model) (string, error) {
+	queries, err := analyzeQueries(model)
+	if err != nil {
+		return "", err
+	}
+
 	imports := []string{
 		"context",
 		"database/sql",
+		"errors",
 		"fmt",
 		"strings",
 		model.ImportPath,
 	}
 
+	if _, ok := getSoftDeleteField(model); ok || hasQueue(model) || aggregatesUsePackage(model, "time", mysqlDialect) || queriesUsePackage(queries, "time") {
+		imports = append(imports, "time")
+	}
+	imports = append(imports, converterImports(model)...)
+
 	var content strings.Builder
 
 	content.WriteString(fmt.Sprintf("package %s\n\n", "mysql"))
@@ -26,18 +38,15 @@ func GenerateMySQLDAO(model parser.Model) (string, error) {
 	content.WriteString(")\n\n")
 
 	content.WriteString(fmt.Sprintf("type %s = %s.%s\n\n", model.Name, model.Package, model.Name))
+	content.WriteString(generateJoinRowTypes(model))
 
 	daoName := fmt.Sprintf("%sDAO", model.Name)
 
-	content.WriteString(fmt.Sprintf("type %s struct {\n", daoName))
-	content.WriteString("\tdb *sql.DB\n")
-	content.WriteString("}\n\n")
-
-	content.WriteString(fmt.Sprintf("func New%s(db *sql.DB) *%s {\n", daoName, daoName))
-	content.WriteString(fmt.Sprintf("\treturn &%s{db: db}\n", daoName))
-	content.WriteString("}\n\n")
+	content.WriteString(generateDAOStruct(model, daoName))
 
 	content.WriteString(generateMySQLHelperMethods(daoName))
+	content.WriteString(generateTenantMethods(model, daoName, mysqlDialect))
+	content.WriteString(generateValidMethod(model, daoName))
 	content.WriteString(generateMySQLCreateMethod(model, daoName))
 	content.WriteString(generateMySQLUpdateMethod(model, daoName))
 	content.WriteString(generateMySQLPartialUpdateMethod(model, daoName))
@@ -46,10 +55,23 @@ func GenerateMySQLDAO(model parser.Model) (string, error) {
 	content.WriteString(generateMySQLCreateManyMethod(model, daoName))
 	content.WriteString(generateMySQLUpdateManyMethod(model, daoName))
 	content.WriteString(generateMySQLDeleteManyByIDsMethod(model, daoName))
+	content.WriteString(generateBulkMethods(model, daoName, mysqlDialect))
 	content.WriteString(generateMySQLFindOneMethod(model, daoName))
-	content.WriteString(generateMySQLFindAllMethod(model, daoName))
+	content.WriteString(generateMySQLFindAllMethod(model, daoName, "FindAll", defaultConditions(model, mysqlDialect)))
+	content.WriteString(generateFindAllColumnsMethod(model, daoName, mysqlDialect))
+	content.WriteString(generateLockMethods(model, daoName, mysqlDialect))
+	content.WriteString(generateQueueMethods(model, daoName, mysqlDialect))
 	content.WriteString(generateMySQLFindPaginatedMethod(model, daoName))
 	content.WriteString(generateMySQLCountMethod(model, daoName))
+	content.WriteString(generateExistsMethods(model, daoName, mysqlDialect))
+	content.WriteString(generateAggregateMethods(model, daoName, mysqlDialect))
+	content.WriteString(generateSoftDeleteMethods(model, daoName, mysqlDialect, generateMySQLFindAllMethod))
+	relationMethods, err := generateRelationMethods(model, daoName, mysqlDialect)
+	if err != nil {
+		return "", err
+	}
+	content.WriteString(relationMethods)
+	content.WriteString(generateQueryMethods(model, queries, daoName, mysqlDialect))
 	content.WriteString(generateMySQLWithTransactionMethod(daoName))
 
 	return content.String(), nil
@@ -95,26 +117,35 @@ func generateMySQLCreateMethod(model parser.Model, daoName string) string {
 	var placeholders []string
 	var args []string
 
-	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		placeholders = append(placeholders, "?")
-		args = append(args, fmt.Sprintf("m.%s", field.Name))
+	for _, field := range getInsertFields(model) {
+		columns = append(columns, mysqlDialect.quote(field.Column))
+		placeholders = append(placeholders, mysqlDialect.valueBind(field, "?"))
+		args = append(args, generateValueArg(mysqlDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Create(ctx context.Context, m *%s) error {\n", daoName, model.Name))
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", model.TableName, strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tVALUES (%s)\n", strings.Join(placeholders, ", ")))
-	content.WriteString("\t`\n\n")
-
-	content.WriteString("\t_, err := dao.execContext(\n")
-	content.WriteString("\t\tctx,\n")
-	content.WriteString("\t\tquery,\n")
-	for _, arg := range args {
-		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	content.WriteString(generateValidCall(model))
+	content.WriteString(generateTenantPrelude(model, ""))
+	if assignment := generateTenantAssignment(model, "m", "\t"); assignment != "" {
+		content.WriteString(assignment + "\n")
+	}
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", generateMySQLQueryLiteral(
+		fmt.Sprintf("INSERT INTO %s (%s)", mysqlDialect.table(model), strings.Join(columns, ", ")),
+		fmt.Sprintf("VALUES (%s)", strings.Join(placeholders, ", ")),
+	)))
+
+	if len(getGeneratedFields(model)) > 0 {
+		content.WriteString(generateReadBackWrite(model, mysqlDialect, args, false))
+	} else {
+		content.WriteString("\t_, err := dao.execContext(\n")
+		content.WriteString("\t\tctx,\n")
+		content.WriteString("\t\tquery,\n")
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n\n")
+		content.WriteString("\treturn err\n")
 	}
-	content.WriteString("\t)\n\n")
-	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
@@ -131,34 +162,74 @@ func generateMySQLUpdateMethod(model parser.Model, daoName string) string {
 			primaryKeyField = field.Name
 			continue
 		}
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", field.Column))
-		args = append(args, fmt.Sprintf("m.%s", field.Name))
+		if field.IsTenant {
+			continue
+		}
+		if field.IsGenerated {
+			continue
+		}
+		if field.IsVersion {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", mysqlDialect.quote(field.Column), mysqlDialect.quote(field.Column)))
+			continue
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", mysqlDialect.quote(field.Column), mysqlDialect.valueBind(field, mysqlDialect.bind(len(args)+1))))
+		args = append(args, generateValueArg(mysqlDialect, field, "m"))
 	}
 
-	args = append(args, fmt.Sprintf("m.%s", primaryKeyField))
-	whereClause := fmt.Sprintf("WHERE %s = ?", getPrimaryColumn(model))
+	args = append(args, generatePrimaryKeyArg(model, "m."+primaryKeyField))
+	whereClause := fmt.Sprintf("WHERE %s = %s", mysqlDialect.quote(getPrimaryColumn(model)), mysqlDialect.bind(len(args)))
 
-	content.WriteString(fmt.Sprintf("func (dao *%s) Update(ctx context.Context, m *%s) error {\n", daoName, model.Name))
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", model.TableName))
-	content.WriteString(fmt.Sprintf("\t\tSET %s\n", strings.Join(setClauses, ",\n\t\t\t")))
-	content.WriteString(fmt.Sprintf("\t\t%s\n", whereClause))
-	content.WriteString("\t`\n\n")
+	if _, ok := getTenantField(model); ok {
+		args = append(args, "tenantID")
+		whereClause += tenantCondition(model, mysqlDialect, len(args))
+	}
 
-	content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
-	for _, arg := range args {
-		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	versionField, versioned := getVersionField(model)
+	if versioned {
+		args = append(args, fmt.Sprintf("m.%s", versionField.Name))
+		whereClause += fmt.Sprintf(" AND %s = %s", mysqlDialect.quote(versionField.Column), mysqlDialect.bind(len(args)))
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Update(ctx context.Context, m *%s) error {\n", daoName, model.Name))
+	content.WriteString(generateValidCall(model))
+	content.WriteString(generateTenantPrelude(model, ""))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", generateMySQLQueryLiteral(
+		fmt.Sprintf("UPDATE %s", mysqlDialect.table(model)),
+		fmt.Sprintf("SET %s", strings.Join(setClauses, ", ")),
+		whereClause,
+	)))
+
+	if len(getGeneratedFields(model)) > 0 {
+		content.WriteString(generateReadBackWrite(model, mysqlDialect, args, versioned))
+	} else {
+		if versioned {
+			content.WriteString("\tresult, err := dao.execContext(ctx, query,\n")
+		} else {
+			content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
+		}
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n")
+		if versioned {
+			content.WriteString(generateVersionCheck(versionField, "m", "\t"))
+			content.WriteString("\treturn nil\n")
+		} else {
+			content.WriteString("\treturn err\n")
+		}
 	}
-	content.WriteString("\t)\n")
-	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
 }
 
 func generateMySQLPartialUpdateMethod(model parser.Model, daoName string) string {
+	if isUpdateGuarded(model) {
+		return generateGuardedPartialUpdateMethod(model, daoName, mysqlDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := mysqlDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) PartialUpdate(ctx context.Context, pk %s, fields map[string]interface{}) error {\n", daoName, primaryType))
@@ -170,13 +241,16 @@ func generateMySQLPartialUpdateMethod(model parser.Model, daoName string) string
 	content.WriteString("\targs := make([]interface{}, 0, len(fields)+1)\n\n")
 
 	content.WriteString("\tfor field, value := range fields {\n")
-	content.WriteString("\t\tsetClauses = append(setClauses, field + \" = ?\")\n")
+	content.WriteString(generateColumnCheck(model, "field", "", "\t\t"))
+	content.WriteString(generateValidFieldCall(model, "", "\t\t"))
+	content.WriteString(generateFieldValueConversion(mysqlDialect, model, "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tsetClauses = append(setClauses, fmt.Sprintf(%q, field))\n", mysqlDialect.quote("%s")+" = ?"))
 	content.WriteString("\t\targs = append(args, value)\n")
 	content.WriteString("\t}\n\n")
 
-	content.WriteString("\targs = append(args, pk)\n\n")
+	content.WriteString(fmt.Sprintf("\targs = append(args, %s)\n\n", generatePrimaryKeyArg(model, "pk")))
 
-	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(\"UPDATE %s SET %%s WHERE %s = ?\", strings.Join(setClauses, \", \"))\n\n", model.TableName, primaryColumn))
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%q, strings.Join(setClauses, \", \"))\n\n", fmt.Sprintf("UPDATE %s SET %%s WHERE %s = ?", mysqlDialect.table(model), primaryColumn)))
 
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
@@ -186,13 +260,18 @@ func generateMySQLPartialUpdateMethod(model parser.Model, daoName string) string
 }
 
 func generateMySQLDeleteByIDMethod(model parser.Model, daoName string) string {
+	if _, ok := getSoftDeleteField(model); ok {
+		return generateSoftDeleteByIDMethod(model, daoName, mysqlDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := mysqlDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteByPk(ctx context.Context, pk %s) error {\n", daoName, primaryType))
-	content.WriteString(fmt.Sprintf("\tquery := `DELETE FROM %s WHERE %s = ?`\n", model.TableName, primaryColumn))
-	content.WriteString("\t_, err := dao.execContext(ctx, query, pk)\n")
+	content.WriteString(generateTenantPrelude(model, ""))
+	content.WriteString(fmt.Sprintf("\tquery := %q\n", fmt.Sprintf("DELETE FROM %s WHERE %s = ?%s", mysqlDialect.table(model), primaryColumn, tenantCondition(model, mysqlDialect, 2))))
+	content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query, %s%s)\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
 	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
@@ -203,23 +282,25 @@ func generateMySQLFindByIDMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
 	var columns []string
 	var scanArgs []string
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := mysqlDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, mysqlDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(mysqlDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindByPk(ctx context.Context, pk %s) (*%s, error) {\n", daoName, primaryType, model.Name))
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
-	content.WriteString(fmt.Sprintf("\t\tWHERE %s = ?\n", primaryColumn))
-	content.WriteString("\t`\n")
-	content.WriteString("\trow := dao.queryRowContext(ctx, query, pk)\n\n")
+	content.WriteString(generateTenantPrelude(model, "nil, "))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", generateMySQLQueryLiteral(
+		fmt.Sprintf("SELECT %s", strings.Join(columns, ", ")),
+		fmt.Sprintf("FROM %s", mysqlDialect.table(model)),
+		fmt.Sprintf("WHERE %s = ?%s%s", primaryColumn, andConditions(defaultConditions(model, mysqlDialect)), tenantCondition(model, mysqlDialect, 2)),
+	)))
+	content.WriteString(fmt.Sprintf("\trow := dao.queryRowContext(ctx, query, %s%s)\n\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
 
 	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
 	content.WriteString("\terr := row.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
@@ -230,6 +311,7 @@ func generateMySQLFindByIDMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\treturn nil, err\n")
 	content.WriteString("\t}\n\n")
 
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
 	content.WriteString("\treturn &m, nil\n")
 	content.WriteString("}\n\n")
 
@@ -238,37 +320,42 @@ func generateMySQLFindByIDMethod(model parser.Model, daoName string) string {
 
 func generateMySQLCreateManyMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	insertFields := getInsertFields(model)
 	var columns []string
+	var placeholders []string
 
-	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
+	for _, field := range insertFields {
+		columns = append(columns, mysqlDialect.quote(field.Column))
+		placeholders = append(placeholders, mysqlDialect.valueBind(field, "?"))
 	}
 
-	fieldCount := len(model.Fields)
-	placeholders := strings.Repeat("?,", fieldCount-1) + "?"
+	fieldCount := len(insertFields)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) CreateMany(ctx context.Context, models []*%s) error {\n", daoName, model.Name))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(models) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
+	content.WriteString(generateValidManyCall(model))
 
 	content.WriteString("\tplaceholders := make([]string, len(models))\n")
 	content.WriteString(fmt.Sprintf("\targs := make([]interface{}, 0, len(models)*%d)\n\n", fieldCount))
 
 	content.WriteString("\tfor i, model := range models {\n")
-	content.WriteString(fmt.Sprintf("\t\tplaceholders[i] = \"(%s)\"\n\n", placeholders))
+	content.WriteString(generateTenantAssignment(model, "model", "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tplaceholders[i] = \"(%s)\"\n\n", strings.Join(placeholders, ",")))
 
 	content.WriteString("\t\targs = append(args,\n")
-	for _, field := range model.Fields {
-		content.WriteString(fmt.Sprintf("\t\t\tmodel.%s,\n", field.Name))
+	for _, field := range insertFields {
+		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", generateValueArg(mysqlDialect, field, "model")))
 	}
 	content.WriteString("\t\t)\n")
 	content.WriteString("\t}\n\n")
 
-	content.WriteString("\tquery := fmt.Sprintf(`\n")
-	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", model.TableName, strings.Join(columns, ", ")))
-	content.WriteString("\t\tVALUES %s\n")
-	content.WriteString("\t`, strings.Join(placeholders, \", \"))\n\n")
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, strings.Join(placeholders, \", \"))\n\n", generateMySQLQueryLiteral(
+		fmt.Sprintf("INSERT INTO %s (%s)", mysqlDialect.table(model), strings.Join(columns, ", ")),
+		"VALUES %s",
+	)))
 
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
@@ -288,33 +375,64 @@ func generateMySQLUpdateManyMethod(model parser.Model, daoName string) string {
 			primaryKeyField = field.Name
 			continue
 		}
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", field.Column))
-		args = append(args, fmt.Sprintf("model.%s", field.Name))
+		if field.IsTenant {
+			continue
+		}
+		if field.IsGenerated {
+			continue
+		}
+		if field.IsVersion {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", mysqlDialect.quote(field.Column), mysqlDialect.quote(field.Column)))
+			continue
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", mysqlDialect.quote(field.Column), mysqlDialect.valueBind(field, mysqlDialect.bind(len(args)+1))))
+		args = append(args, generateValueArg(mysqlDialect, field, "model"))
 	}
 
-	args = append(args, fmt.Sprintf("model.%s", primaryKeyField))
-	whereClause := fmt.Sprintf("WHERE %s = ?", getPrimaryColumn(model))
+	args = append(args, generatePrimaryKeyArg(model, "model."+primaryKeyField))
+	whereClause := fmt.Sprintf("WHERE %s = %s", mysqlDialect.quote(getPrimaryColumn(model)), mysqlDialect.bind(len(args)))
+
+	if _, ok := getTenantField(model); ok {
+		args = append(args, "tenantID")
+		whereClause += tenantCondition(model, mysqlDialect, len(args))
+	}
+
+	versionField, versioned := getVersionField(model)
+	if versioned {
+		args = append(args, fmt.Sprintf("model.%s", versionField.Name))
+		whereClause += fmt.Sprintf(" AND %s = %s", mysqlDialect.quote(versionField.Column), mysqlDialect.bind(len(args)))
+	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) UpdateMany(ctx context.Context, models []*%s) error {\n", daoName, model.Name))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(models) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
+	content.WriteString(generateValidManyCall(model))
 
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", model.TableName))
-	content.WriteString(fmt.Sprintf("\t\tSET %s\n", strings.Join(setClauses, ",\n\t\t\t")))
-	content.WriteString(fmt.Sprintf("\t\t%s\n", whereClause))
-	content.WriteString("\t`\n\n")
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", generateMySQLQueryLiteral(
+		fmt.Sprintf("UPDATE %s", mysqlDialect.table(model)),
+		fmt.Sprintf("SET %s", strings.Join(setClauses, ", ")),
+		whereClause,
+	)))
 
 	content.WriteString("\tfor _, model := range models {\n")
-	content.WriteString("\t\t_, err := dao.execContext(ctx, query,\n")
+	if versioned {
+		content.WriteString("\t\tresult, err := dao.execContext(ctx, query,\n")
+	} else {
+		content.WriteString("\t\t_, err := dao.execContext(ctx, query,\n")
+	}
 	for _, arg := range args {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
 	}
 	content.WriteString("\t\t)\n")
-	content.WriteString("\t\tif err != nil {\n")
-	content.WriteString("\t\t\treturn err\n")
-	content.WriteString("\t\t}\n")
+	if versioned {
+		content.WriteString(generateVersionCheck(versionField, "model", "\t\t"))
+	} else {
+		content.WriteString("\t\tif err != nil {\n")
+		content.WriteString("\t\t\treturn err\n")
+		content.WriteString("\t\t}\n")
+	}
 	content.WriteString("\t}\n\n")
 
 	content.WriteString("\treturn nil\n")
@@ -324,11 +442,16 @@ func generateMySQLUpdateManyMethod(model parser.Model, daoName string) string {
 }
 
 func generateMySQLDeleteManyByIDsMethod(model parser.Model, daoName string) string {
+	if _, ok := getSoftDeleteField(model); ok {
+		return generateSoftDeleteManyByIDsMethod(model, daoName, mysqlDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := mysqlDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteManyByPks(ctx context.Context, pks []%s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(pks) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
@@ -336,10 +459,14 @@ func generateMySQLDeleteManyByIDsMethod(model parser.Model, daoName string) stri
 	content.WriteString("\tplaceholders := strings.Repeat(\"?,\", len(pks)-1) + \"?\"\n")
 	content.WriteString("\targs := make([]interface{}, len(pks))\n")
 	content.WriteString("\tfor i, pk := range pks {\n")
-	content.WriteString("\t\targs[i] = pk\n")
+	content.WriteString(fmt.Sprintf("\t\targs[i] = %s\n", generatePrimaryKeyArg(model, "pk")))
 	content.WriteString("\t}\n\n")
 
-	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(\"DELETE FROM %s WHERE %s IN (%%s)\", placeholders)\n", model.TableName, primaryColumn))
+	tenantCond, tenantSprintfArg := tenantDynamicCondition(model, mysqlDialect)
+	if tenantCond != "" {
+		content.WriteString("\targs = append(args, tenantID)\n")
+	}
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%q, placeholders%s)\n", fmt.Sprintf("DELETE FROM %s WHERE %s IN (%%s)%s", mysqlDialect.table(model), primaryColumn, tenantCond), tenantSprintfArg))
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
@@ -349,23 +476,27 @@ func generateMySQLDeleteManyByIDsMethod(model parser.Model, daoName string) stri
 
 func generateMySQLFindOneMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, mysqlDialect)
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, mysqlDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(mysqlDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*%s, error) {\n", daoName, model.Name))
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
-	content.WriteString("\t`\n\n")
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
+	queryLines := []string{
+		fmt.Sprintf("SELECT %s", strings.Join(columns, ", ")),
+		fmt.Sprintf("FROM %s", mysqlDialect.table(model)),
+	}
+	if len(conditions) > 0 {
+		queryLines = append(queryLines, "WHERE "+strings.Join(conditions, " AND "))
+	}
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", generateMySQLQueryLiteral(queryLines...)))
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -374,6 +505,7 @@ func generateMySQLFindOneMethod(model parser.Model, daoName string) string {
 	content.WriteString("\trow := dao.queryRowContext(ctx, query, args...)\n\n")
 
 	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
 	content.WriteString("\terr := row.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
@@ -384,31 +516,35 @@ func generateMySQLFindOneMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\treturn nil, err\n")
 	content.WriteString("\t}\n\n")
 
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
 	content.WriteString("\treturn &m, nil\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
 }
 
-func generateMySQLFindAllMethod(model parser.Model, daoName string) string {
+func generateMySQLFindAllMethod(model parser.Model, daoName, methodName string, conditions []string) string {
 	var content strings.Builder
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, mysqlDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(mysqlDialect, field, "m"))
 	}
 
-	content.WriteString(fmt.Sprintf("func (dao *%s) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
-	content.WriteString("\t`\n\n")
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, methodName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
+	queryLines := []string{
+		fmt.Sprintf("SELECT %s", strings.Join(columns, ", ")),
+		fmt.Sprintf("FROM %s", mysqlDialect.table(model)),
+	}
+	if len(conditions) > 0 {
+		queryLines = append(queryLines, "WHERE "+strings.Join(conditions, " AND "))
+	}
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", generateMySQLQueryLiteral(queryLines...)))
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -423,6 +559,7 @@ func generateMySQLFindAllMethod(model parser.Model, daoName string) string {
 	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
 	content.WriteString("\tfor rows.Next() {\n")
 	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
 	content.WriteString("\t\terr := rows.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
@@ -431,6 +568,7 @@ func generateMySQLFindAllMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\tif err != nil {\n")
 	content.WriteString("\t\t\treturn nil, err\n")
 	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
 	content.WriteString("\t\tmodels = append(models, &m)\n")
 	content.WriteString("\t}\n\n")
 
@@ -446,23 +584,27 @@ func generateMySQLFindAllMethod(model parser.Model, daoName string) string {
 
 func generateMySQLFindPaginatedMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, mysqlDialect)
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, mysqlDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(mysqlDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
-	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
-	content.WriteString("\t`\n\n")
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
+	queryLines := []string{
+		fmt.Sprintf("SELECT %s", strings.Join(columns, ", ")),
+		fmt.Sprintf("FROM %s", mysqlDialect.table(model)),
+	}
+	if len(conditions) > 0 {
+		queryLines = append(queryLines, "WHERE "+strings.Join(conditions, " AND "))
+	}
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", generateMySQLQueryLiteral(queryLines...)))
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -479,6 +621,7 @@ func generateMySQLFindPaginatedMethod(model parser.Model, daoName string) string
 	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
 	content.WriteString("\tfor rows.Next() {\n")
 	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
 	content.WriteString("\t\terr := rows.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
@@ -487,6 +630,7 @@ func generateMySQLFindPaginatedMethod(model parser.Model, daoName string) string
 	content.WriteString("\t\tif err != nil {\n")
 	content.WriteString("\t\t\treturn nil, err\n")
 	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
 	content.WriteString("\t\tmodels = append(models, &m)\n")
 	content.WriteString("\t}\n\n")
 
@@ -502,13 +646,12 @@ func generateMySQLFindPaginatedMethod(model parser.Model, daoName string) string
 
 func generateMySQLCountMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, mysqlDialect)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {\n", daoName))
-	content.WriteString(fmt.Sprintf("\tquery := \"SELECT COUNT(*) FROM %s\"\n\n", model.TableName))
-
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateScopeWherePrelude(model, "0, "))
+	content.WriteString(fmt.Sprintf("\tquery := %q\n\n", fmt.Sprintf("SELECT COUNT(*) FROM %s%s", mysqlDialect.table(model), whereConditions(conditions))))
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\trow := dao.queryRowContext(ctx, query, args...)\n\n")
 
@@ -552,3 +695,17 @@ func generateMySQLWithTransactionMethod(daoName string) string {
 
 	return content.String()
 }
+
+// generateMySQLQueryLiteral renders a multiline query as concatenated
+// interpreted string literals, since the backticks quoting MySQL identifiers
+// cannot appear in a raw string literal.
+func generateMySQLQueryLiteral(lines ...string) string {
+	quoted := make([]string, len(lines))
+	for i, line := range lines {
+		if i < len(lines)-1 {
+			line += " "
+		}
+		quoted[i] = strconv.Quote(line)
+	}
+	return strings.Join(quoted, " +\n\t\t")
+}
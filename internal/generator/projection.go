@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// generateFindAllColumnsMethod generates FindAllColumns, which reads only the
+// columns chosen by the caller and leaves the other fields of the models zero.
+func generateFindAllColumnsMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	conditions := defaultConditions(model, d)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) FindAllColumns(ctx context.Context, columns []string, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString("\tif len(columns) == 0 {\n")
+	content.WriteString("\t\treturn dao.FindAll(ctx, where, sort, args...)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tselected := make([]string, len(columns))\n")
+	content.WriteString("\tfor i, column := range columns {\n")
+	content.WriteString(generateColumnCheck(model, "column", "nil, ", "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tselected[i] = %q + column + %q\n", d.openQuote, d.closeQuote))
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
+	query := fmt.Sprintf("SELECT %%s FROM %s%s", d.table(model), whereConditions(conditions))
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, strings.Join(selected, \", \"))\n\n", d.literal(query)))
+	content.WriteString(generateWhereAppend("query", conditions))
+
+	content.WriteString("\tif sort != \"\" {\n")
+	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\trows, err := dao.queryContext(ctx, query, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tdefer rows.Close()\n\n")
+
+	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
+	content.WriteString("\tfor rows.Next() {\n")
+	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
+	content.WriteString("\t\tdest := make([]interface{}, len(columns))\n")
+	content.WriteString("\t\tfor i, column := range columns {\n")
+	content.WriteString("\t\t\tswitch column {\n")
+	for _, field := range model.Fields {
+		content.WriteString(fmt.Sprintf("\t\t\tcase %q:\n", field.Column))
+		content.WriteString(fmt.Sprintf("\t\t\t\tdest[i] = %s\n", generateScanArg(d, field, "m")))
+	}
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tif err := rows.Scan(dest...); err != nil {\n")
+	content.WriteString("\t\t\treturn nil, err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
+	content.WriteString("\t\tmodels = append(models, &m)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif err := rows.Err(); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn models, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
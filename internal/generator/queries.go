@@ -0,0 +1,658 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Jibaru/gormless/internal/naming"
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// queryPlaceholders are the placeholder styles a named query can be written
+// with, by priority. Whichever is used, the SQL is rewritten to the
+// placeholders of the selected driver.
+var queryPlaceholders = []*regexp.Regexp{
+	regexp.MustCompile(`\$(\d+)`),
+	regexp.MustCompile(`@p(\d+)`),
+	regexp.MustCompile(`:(\d+)`),
+	regexp.MustCompile(`\?`),
+}
+
+const sqlIdentifier = "(?:[A-Za-z_][A-Za-z0-9_$]*|\"[^\"]*\"|`[^`]*`|\\[[^\\]]*\\])"
+
+var (
+	columnRefPattern   = regexp.MustCompile(`^(?:(` + sqlIdentifier + `)\.)?(` + sqlIdentifier + `)$`)
+	starPattern        = regexp.MustCompile(`^(?:(` + sqlIdentifier + `)\.)?\*$`)
+	comparisonPattern  = regexp.MustCompile(`(?i)((?:` + sqlIdentifier + `\.)?` + sqlIdentifier + `)\s*(?:=|<>|!=|<=|>=|<|>|(?:NOT\s+)?I?LIKE)\s*$`)
+	rowCountPattern    = regexp.MustCompile(`(?i)\b(LIMIT|OFFSET|TOP\s*\(|FETCH\s+(?:FIRST|NEXT))\s*$`)
+	aliasPattern       = regexp.MustCompile(`(?is)^(.*\S)\s+AS\s+(` + sqlIdentifier + `)$`)
+	selectPrefix       = regexp.MustCompile(`(?i)^\s*(?:(?:DISTINCT|ALL)\s+)?(?:TOP\s*(?:\([^)]*\)|\d+)\s+)?`)
+	countPattern       = regexp.MustCompile(`(?i)^COUNT\s*\(`)
+	reservedQueryNames = map[string]bool{"ctx": true, "dao": true, "query": true, "rows": true, "row": true, "err": true, "result": true, "m": true, "models": true, "tenantID": true, "ok": true}
+)
+
+// queryParam is an argument of a named query method.
+type queryParam struct {
+	name   string
+	goType string
+	// field is the column the argument is compared to, if known.
+	field *parser.Field
+	// tenant reports an argument compared to the tenant column, bound to the
+	// tenant of the context instead of being taken by the method.
+	tenant bool
+}
+
+// placeholderUse is a placeholder of the SQL of a named query, binding the
+// param-th argument.
+type placeholderUse struct {
+	start, end int
+	param      int
+}
+
+// starUse is a * of the select list of a named query, expanded to the columns
+// of its model, qualified by qualifier if any.
+type starUse struct {
+	start, end int
+	qualifier  string
+}
+
+// analyzedQuery is a named query along with what its method is made of.
+type analyzedQuery struct {
+	parser.Query
+	params       []queryParam
+	placeholders []placeholderUse
+	stars        []starUse
+	// columns are the fields scanned from each selected column, named after
+	// the fields of the model or of the row type.
+	columns []parser.Field
+	// rowType is the struct returned by :one and :many queries selecting more
+	// than columns of the model, or empty when they return models.
+	rowType string
+}
+
+// maskSQL returns sql with the contents of its quoted strings and identifiers
+// replaced by x and its comments by spaces, so that its structure can be
+// searched at the same offsets.
+func maskSQL(sql string) string {
+	masked := []byte(sql)
+	for i := 0; i < len(sql); i++ {
+		switch {
+		case sql[i] == '\'' || sql[i] == '"' || sql[i] == '`':
+			j := i + 1
+			for j < len(sql) && sql[j] != sql[i] {
+				masked[j] = 'x'
+				j++
+			}
+			i = j
+		case strings.HasPrefix(sql[i:], "--"):
+			for ; i < len(sql) && sql[i] != '\n'; i++ {
+				masked[i] = ' '
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				end = len(sql)
+			} else {
+				end += i + 4
+			}
+			for ; i < end; i++ {
+				masked[i] = ' '
+			}
+			i--
+		}
+	}
+	return string(masked)
+}
+
+// unquoteIdentifier strips the quotes of a SQL identifier.
+func unquoteIdentifier(identifier string) string {
+	if len(identifier) >= 2 {
+		switch identifier[0] {
+		case '"', '`':
+			return identifier[1 : len(identifier)-1]
+		case '[':
+			return identifier[1 : len(identifier)-1]
+		}
+	}
+	return identifier
+}
+
+// exportedName returns name in PascalCase, as the name of a struct field.
+func exportedName(name string) string {
+	camel := naming.ToCamelCase(name)
+	if camel == "" {
+		return ""
+	}
+	runes := []rune(camel)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// analyzeQuery works out the arguments and the results of query.
+func analyzeQuery(model parser.Model, query parser.Query) (analyzedQuery, error) {
+	analyzed := analyzedQuery{Query: query}
+	masked := maskSQL(query.SQL)
+
+	if err := analyzed.findParams(model, masked); err != nil {
+		return analyzedQuery{}, fmt.Errorf("invalid %s query: %v", query.Name, err)
+	}
+	if tenant, ok := getTenantField(model); ok && !query.Unscoped && !analyzed.isScoped() {
+		return analyzedQuery{}, fmt.Errorf("invalid %s query: the queries of the %s model must compare %s to a placeholder, bound to the tenant of the context, unless declared -- unscoped", query.Name, model.Name, tenant.Column)
+	}
+	if query.Kind == parser.QueryOne || query.Kind == parser.QueryMany {
+		if err := analyzed.findColumns(model, masked); err != nil {
+			return analyzedQuery{}, fmt.Errorf("invalid %s query: %v", query.Name, err)
+		}
+	}
+
+	return analyzed, nil
+}
+
+// findParams finds the placeholders of the query, in the first style it uses,
+// and infers the name and type of each argument from the column it is
+// compared to.
+func (q *analyzedQuery) findParams(model parser.Model, masked string) error {
+	for _, style := range queryPlaceholders {
+		for _, match := range style.FindAllStringSubmatchIndex(masked, -1) {
+			if match[0] > 0 && strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_:$@]", rune(masked[match[0]-1])) {
+				continue
+			}
+			param := len(q.placeholders)
+			if len(match) > 2 {
+				n, _ := strconv.Atoi(masked[match[2]:match[3]])
+				if n == 0 {
+					return fmt.Errorf("placeholders are numbered from 1")
+				}
+				param = n - 1
+			}
+			q.placeholders = append(q.placeholders, placeholderUse{start: match[0], end: match[1], param: param})
+		}
+		if len(q.placeholders) > 0 {
+			break
+		}
+	}
+
+	count := 0
+	for _, use := range q.placeholders {
+		count = max(count, use.param+1)
+	}
+	q.params = make([]queryParam, count)
+
+	for _, use := range q.placeholders {
+		if q.params[use.param].goType != "" {
+			continue
+		}
+		prefix := masked[:use.start]
+		if match := comparisonPattern.FindStringSubmatchIndex(prefix); match != nil {
+			ref := columnRefPattern.FindStringSubmatch(q.SQL[match[2]:match[3]])
+			if ref != nil {
+				if field, ok := findField(model, unquoteIdentifier(ref[2])); ok {
+					q.params[use.param] = queryParam{name: naming.ToCamelCase(field.Name), goType: qualifyType(model, field.Type), field: &field, tenant: field.IsTenant && !q.Unscoped}
+					continue
+				}
+			}
+		}
+		if match := rowCountPattern.FindStringSubmatch(prefix); match != nil {
+			name := "limit"
+			if strings.EqualFold(match[1], "OFFSET") {
+				name = "offset"
+			}
+			q.params[use.param] = queryParam{name: name, goType: "int"}
+		}
+	}
+
+	names := map[string]bool{}
+	for i := range q.params {
+		param := &q.params[i]
+		if param.tenant {
+			param.name = "tenantID"
+			continue
+		}
+		if param.goType == "" {
+			if !q.usesParam(i) {
+				return fmt.Errorf("placeholder %d is never used", i+1)
+			}
+			param.name = fmt.Sprintf("arg%d", i+1)
+			param.goType = "interface{}"
+		}
+		if token.IsKeyword(param.name) || reservedQueryNames[param.name] {
+			param.name += "Arg"
+		}
+		name := param.name
+		for n := 2; names[name]; n++ {
+			name = fmt.Sprintf("%s%d", param.name, n)
+		}
+		param.name = name
+		names[name] = true
+	}
+
+	return nil
+}
+
+// isScoped reports whether the query binds the tenant of the context.
+func (q *analyzedQuery) isScoped() bool {
+	for _, param := range q.params {
+		if param.tenant {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *analyzedQuery) usesParam(param int) bool {
+	for _, use := range q.placeholders {
+		if use.param == param {
+			return true
+		}
+	}
+	return false
+}
+
+func findField(model parser.Model, column string) (parser.Field, bool) {
+	for _, field := range model.Fields {
+		if field.Column == column {
+			return field, true
+		}
+	}
+	return parser.Field{}, false
+}
+
+// findColumns reads the select list of the query, or its RETURNING clause,
+// and decides whether it returns models or rows of a struct of its own.
+func (q *analyzedQuery) findColumns(model parser.Model, masked string) error {
+	start, end := -1, len(masked)
+	depth := 0
+	for i := 0; i < len(masked); i++ {
+		switch masked[i] {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		}
+		if depth != 0 || (i > 0 && isWordByte(masked[i-1])) {
+			continue
+		}
+		switch {
+		case start < 0 && hasKeyword(masked[i:], "SELECT"):
+			start = i + len("SELECT")
+		case start < 0 && hasKeyword(masked[i:], "RETURNING"):
+			start = i + len("RETURNING")
+		case start >= 0 && hasKeyword(masked[i:], "FROM"):
+			end = i
+		}
+		if end < len(masked) {
+			break
+		}
+	}
+	if start < 0 {
+		return fmt.Errorf("a :%s query must select or return columns", q.Kind)
+	}
+	start += len(selectPrefix.FindString(masked[start:end]))
+
+	var items [][2]int
+	depth = 0
+	itemStart := start
+	for i := start; i < end; i++ {
+		switch masked[i] {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, [2]int{itemStart, i})
+				itemStart = i + 1
+			}
+		}
+	}
+	items = append(items, [2]int{itemStart, end})
+
+	returnsModel := true
+	selected := map[string]bool{}
+	var rowFields []parser.Field
+
+	for _, item := range items {
+		text := q.SQL[item[0]:item[1]]
+		itemStart := item[0] + len(text) - len(strings.TrimLeft(text, " \t\r\n"))
+		text = strings.TrimSpace(text)
+
+		expr, alias := text, ""
+		if match := aliasPattern.FindStringSubmatchIndex(maskSQL(text)); match != nil {
+			expr, alias = text[match[2]:match[3]], unquoteIdentifier(text[match[4]:match[5]])
+		}
+
+		if star := starPattern.FindStringSubmatch(expr); star != nil {
+			if alias != "" {
+				return fmt.Errorf("%s cannot have an alias", expr)
+			}
+			q.stars = append(q.stars, starUse{start: itemStart, end: itemStart + len(expr), qualifier: star[1]})
+			for _, field := range model.Fields {
+				returnsModel = returnsModel && !selected[field.Column]
+				selected[field.Column] = true
+				q.columns = append(q.columns, field)
+				rowFields = append(rowFields, field)
+			}
+			continue
+		}
+
+		name := alias
+		if ref := columnRefPattern.FindStringSubmatch(expr); ref != nil {
+			column := unquoteIdentifier(ref[2])
+			if field, ok := findField(model, column); ok {
+				returnsModel = returnsModel && !selected[column] && (alias == "" || alias == column)
+				selected[column] = true
+				q.columns = append(q.columns, field)
+				if alias != "" {
+					field.Name = exportedName(alias)
+				}
+				rowFields = append(rowFields, field)
+				continue
+			}
+			if name == "" {
+				name = column
+			}
+		}
+
+		returnsModel = false
+		if name == "" {
+			return fmt.Errorf("the selected expression %s needs an AS alias", expr)
+		}
+		goType, ok := q.Types[name]
+		if !ok && countPattern.MatchString(expr) {
+			goType, ok = "int64", true
+		}
+		if !ok {
+			return fmt.Errorf("the type of %s is unknown; declare it with a -- type: %s <GoType> line", name, name)
+		}
+		field := parser.Field{Name: exportedName(name), Type: goType, Column: name}
+		q.columns = append(q.columns, field)
+		rowFields = append(rowFields, field)
+	}
+
+	if returnsModel {
+		return nil
+	}
+
+	fieldNames := map[string]bool{}
+	for _, field := range rowFields {
+		if !token.IsIdentifier(field.Name) || !token.IsExported(field.Name) {
+			return fmt.Errorf("the column %s does not make a valid field name", field.Column)
+		}
+		if fieldNames[field.Name] {
+			return fmt.Errorf("more than one selected column makes the %s field", field.Name)
+		}
+		fieldNames[field.Name] = true
+	}
+	q.rowType = q.Name + "Row"
+	q.columns = rowFields
+
+	return nil
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '$' || b == '@' || b == '.' || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+// hasKeyword reports whether s starts with keyword as a whole word.
+func hasKeyword(s, keyword string) bool {
+	return len(s) >= len(keyword) && strings.EqualFold(s[:len(keyword)], keyword) && (len(s) == len(keyword) || !isWordByte(s[len(keyword)]))
+}
+
+// resultType returns the Go type of what a :one or :many query reads.
+func (q analyzedQuery) resultType(model parser.Model) string {
+	if q.rowType != "" {
+		return q.rowType
+	}
+	return model.Name
+}
+
+// signature renders the method of the query, from its name to its results.
+func (q analyzedQuery) signature(model parser.Model) string {
+	params := []string{"ctx context.Context"}
+	for _, param := range q.params {
+		if !param.tenant {
+			params = append(params, fmt.Sprintf("%s %s", param.name, param.goType))
+		}
+	}
+
+	var results string
+	switch q.Kind {
+	case parser.QueryOne:
+		results = fmt.Sprintf("(*%s, error)", q.resultType(model))
+	case parser.QueryMany:
+		results = fmt.Sprintf("([]*%s, error)", q.resultType(model))
+	case parser.QueryExec:
+		results = "error"
+	case parser.QueryExecRows:
+		results = "(int64, error)"
+	}
+
+	return fmt.Sprintf("%s(%s) %s", q.Name, strings.Join(params, ", "), results)
+}
+
+// zeroResults renders the results returned along with an error by the
+// method of the query.
+func (q analyzedQuery) zeroResults() string {
+	switch q.Kind {
+	case parser.QueryOne, parser.QueryMany:
+		return "nil, "
+	case parser.QueryExecRows:
+		return "0, "
+	}
+	return ""
+}
+
+// sql renders the SQL of the query with the placeholders of d and its stars
+// expanded to the columns of model.
+func (q analyzedQuery) sql(model parser.Model, d dialect) string {
+	type edit struct {
+		start, end int
+		text       string
+	}
+
+	var edits []edit
+	for i, use := range q.placeholders {
+		n := use.param + 1
+		if d.isPositional() {
+			n = i + 1
+		}
+		edits = append(edits, edit{use.start, use.end, d.bind(n)})
+	}
+	for _, star := range q.stars {
+		columns := make([]string, len(model.Fields))
+		for i, field := range model.Fields {
+			columns[i] = d.quote(field.Column)
+			if star.qualifier != "" {
+				columns[i] = star.qualifier + "." + columns[i]
+			}
+		}
+		edits = append(edits, edit{star.start, star.end, strings.Join(columns, ", ")})
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+
+	sql := q.SQL
+	for _, e := range edits {
+		sql = sql[:e.start] + e.text + sql[e.end:]
+	}
+	return sql
+}
+
+// args renders the arguments binding the placeholders of the query on d,
+// repeated for each use on databases with positional placeholders.
+func (q analyzedQuery) args(d dialect) string {
+	var args []string
+	if d.isPositional() {
+		for _, use := range q.placeholders {
+			args = append(args, generateQueryParamArg(d, q.params[use.param]))
+		}
+	} else {
+		for _, param := range q.params {
+			args = append(args, generateQueryParamArg(d, param))
+		}
+	}
+
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
+
+// generateQueryParamArg renders the argument binding param, converted like
+// the values of the column it is compared to.
+func generateQueryParamArg(d dialect, param queryParam) string {
+	switch {
+	case param.field == nil, param.tenant:
+		return param.name
+	case param.field.Converter != nil:
+		return fmt.Sprintf("convertedValue(%s, %s)", param.name, param.field.Converter.Encode)
+	case d.isArray(*param.field):
+		return fmt.Sprintf("pgArray{%s}", param.name)
+	case param.field.IsJSON:
+		return fmt.Sprintf("jsonColumn{%s}", param.name)
+	}
+	return param.name
+}
+
+// analyzeQueries analyzes the named queries of model.
+func analyzeQueries(model parser.Model) ([]analyzedQuery, error) {
+	var queries []analyzedQuery
+	for _, query := range model.Queries {
+		analyzed, err := analyzeQuery(model, query)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, analyzed)
+	}
+	return queries, nil
+}
+
+// queriesUsePackage reports whether the arguments or the row types of the
+// analyzed queries refer to the package pkg, like "time".
+func queriesUsePackage(queries []analyzedQuery, pkg string) bool {
+	var types []string
+	for _, query := range queries {
+		for _, param := range query.params {
+			if !param.tenant {
+				types = append(types, param.goType)
+			}
+		}
+		if query.rowType != "" {
+			for _, field := range query.columns {
+				types = append(types, field.Type)
+			}
+		}
+	}
+
+	for _, goType := range types {
+		for _, part := range strings.FieldsFunc(goType, func(r rune) bool { return r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' }) {
+			if strings.HasPrefix(part, pkg+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateQueryRowType declares the row type of query, as an alias of an
+// unnamed struct so that the interface package declares the same type.
+func generateQueryRowType(model parser.Model, query analyzedQuery) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("type %s = struct {\n", query.rowType))
+	for _, field := range query.columns {
+		content.WriteString(fmt.Sprintf("\t%s %s\n", field.Name, qualifyType(model, field.Type)))
+	}
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateQueryMethods generates a method running each analyzed query of
+// model, along with the row types they return.
+func generateQueryMethods(model parser.Model, queries []analyzedQuery, daoName string, d dialect) string {
+	var content strings.Builder
+	for _, query := range queries {
+		if query.rowType != "" {
+			content.WriteString(generateQueryRowType(model, query))
+		}
+		content.WriteString(generateQueryMethod(model, daoName, d, query))
+	}
+	return content.String()
+}
+
+func generateQueryMethod(model parser.Model, daoName string, d dialect, query analyzedQuery) string {
+	var content strings.Builder
+	scanned := parser.Model{Fields: query.columns}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s {\n", daoName, query.signature(model)))
+	if query.isScoped() {
+		content.WriteString(generateTenantPrelude(model, query.zeroResults()))
+	}
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query.sql(model, d))))
+
+	switch query.Kind {
+	case parser.QueryExec:
+		content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query%s)\n", query.args(d)))
+		content.WriteString("\treturn err\n")
+
+	case parser.QueryExecRows:
+		content.WriteString(fmt.Sprintf("\tresult, err := dao.execContext(ctx, query%s)\n", query.args(d)))
+		content.WriteString("\tif err != nil {\n")
+		content.WriteString("\t\treturn 0, err\n")
+		content.WriteString("\t}\n\n")
+		content.WriteString("\treturn result.RowsAffected()\n")
+
+	case parser.QueryOne:
+		content.WriteString(fmt.Sprintf("\trow := dao.queryRowContext(ctx, query%s)\n\n", query.args(d)))
+		content.WriteString(fmt.Sprintf("\tvar m %s\n", query.resultType(model)))
+		content.WriteString(generateNullScanDeclarations(scanned, "\t"))
+		content.WriteString("\terr := row.Scan(\n")
+		for _, field := range query.columns {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", generateScanArg(d, field, "m")))
+		}
+		content.WriteString("\t)\n\n")
+		content.WriteString("\tif err != nil {\n")
+		content.WriteString("\t\treturn nil, err\n")
+		content.WriteString("\t}\n\n")
+		content.WriteString(generateNullScanAssignments(scanned, "m", "\t"))
+		content.WriteString("\treturn &m, nil\n")
+
+	case parser.QueryMany:
+		content.WriteString(fmt.Sprintf("\trows, err := dao.queryContext(ctx, query%s)\n", query.args(d)))
+		content.WriteString("\tif err != nil {\n")
+		content.WriteString("\t\treturn nil, err\n")
+		content.WriteString("\t}\n")
+		content.WriteString("\tdefer rows.Close()\n\n")
+
+		content.WriteString(fmt.Sprintf("\tvar models []*%s\n", query.resultType(model)))
+		content.WriteString("\tfor rows.Next() {\n")
+		content.WriteString(fmt.Sprintf("\t\tvar m %s\n", query.resultType(model)))
+		content.WriteString(generateNullScanDeclarations(scanned, "\t\t"))
+		content.WriteString("\t\terr := rows.Scan(\n")
+		for _, field := range query.columns {
+			content.WriteString(fmt.Sprintf("\t\t\t%s,\n", generateScanArg(d, field, "m")))
+		}
+		content.WriteString("\t\t)\n")
+		content.WriteString("\t\tif err != nil {\n")
+		content.WriteString("\t\t\treturn nil, err\n")
+		content.WriteString("\t\t}\n")
+		content.WriteString(generateNullScanAssignments(scanned, "m", "\t\t"))
+		content.WriteString("\t\tmodels = append(models, &m)\n")
+		content.WriteString("\t}\n\n")
+
+		content.WriteString("\tif err := rows.Err(); err != nil {\n")
+		content.WriteString("\t\treturn nil, err\n")
+		content.WriteString("\t}\n\n")
+
+		content.WriteString("\treturn models, nil\n")
+	}
+
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
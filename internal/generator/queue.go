@@ -0,0 +1,276 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// getQueueFields returns the status and lease fields of a queue model.
+func getQueueFields(model parser.Model) (status, lease parser.Field) {
+	for _, field := range model.Fields {
+		switch field.Column {
+		case model.Queue.StatusColumn:
+			status = field
+		case model.Queue.LeaseColumn:
+			lease = field
+		}
+	}
+	return status, lease
+}
+
+func hasQueue(model parser.Model) bool {
+	return model.Queue != nil
+}
+
+// generateQueueMethods generates the Claim, Ack, Release and ReapExpired
+// methods of models declared with a //gormless:queue directive.
+func generateQueueMethods(model parser.Model, daoName string, d dialect) string {
+	if !hasQueue(model) {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(generateClaimMethod(model, daoName, d))
+	content.WriteString(generateQueueTransitionMethod(model, daoName, d, "Ack", model.Queue.Done))
+	content.WriteString(generateQueueTransitionMethod(model, daoName, d, "Release", model.Queue.Pending))
+	content.WriteString(generateReapExpiredMethod(model, daoName, d))
+
+	return content.String()
+}
+
+// versionIncrement renders the SET clause incrementing the version of
+// versioned models, so that a stale Update of a job fails.
+func versionIncrement(model parser.Model, d dialect) string {
+	field, ok := getVersionField(model)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(", %s = %s + 1", d.quote(field.Column), d.quote(field.Column))
+}
+
+// generateClaimMethod claims up to n pending jobs, skipping those locked by
+// concurrent claims, in a transaction of its own unless ctx carries one.
+func generateClaimMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	status, lease := getQueueFields(model)
+	var columns []string
+	var scanArgs []string
+
+	for _, field := range model.Fields {
+		columns = append(columns, d.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(d, field, "m"))
+	}
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Claim(ctx context.Context, n int, leaseDuration time.Duration) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString("\tif n <= 0 {\n")
+	content.WriteString("\t\treturn nil, nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif dao.getTx(ctx) == nil {\n")
+	content.WriteString(fmt.Sprintf("\t\tvar claimed []*%s\n", model.Name))
+	content.WriteString("\t\terr := dao.WithTransaction(ctx, func(ctx context.Context) error {\n")
+	content.WriteString("\t\t\tvar err error\n")
+	content.WriteString("\t\t\tclaimed, err = dao.Claim(ctx, n, leaseDuration)\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t})\n")
+	content.WriteString("\t\treturn claimed, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateTenantPrelude(model, "nil, "))
+
+	selectClause := fmt.Sprintf("SELECT %s%s FROM %s", d.topRows, strings.Join(columns, ", "), d.table(model))
+	whereClause := fmt.Sprintf(" WHERE %s = %s%s%s ORDER BY %s%s", d.quote(status.Column), d.valueBind(status, d.bind(1)), andConditions(defaultConditions(model, d)), tenantCondition(model, d, 2), d.quote(getPrimaryColumn(model)), d.limitRows)
+	var query string
+	if d.locking == lockTableHint {
+		// The lock hint follows the table, so the query is split around it.
+		selectExpr := d.literal(selectClause)
+		if d.topRows != "" {
+			selectExpr = fmt.Sprintf("fmt.Sprintf(%s, n)", selectExpr)
+		}
+		query = fmt.Sprintf("%s + lockHint(LockSkipLocked) + %s", selectExpr, d.literal(whereClause))
+	} else {
+		query = d.literal(selectClause + whereClause)
+		if d.topRows != "" || d.limitRows != "" {
+			query = fmt.Sprintf("fmt.Sprintf(%s, n)", query)
+		}
+		if d.locking == lockForUpdate {
+			query += " + lockClause(LockSkipLocked)"
+		}
+	}
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", query))
+	content.WriteString(fmt.Sprintf("\trows, err := dao.queryContext(ctx, query, %q%s)\n", model.Queue.Pending, tenantArg(model)))
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tdefer rows.Close()\n\n")
+
+	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
+	content.WriteString("\tfor len(models) < n && rows.Next() {\n")
+	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
+	content.WriteString("\t\terr := rows.Scan(\n")
+	for _, arg := range scanArgs {
+		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
+	}
+	content.WriteString("\t\t)\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn nil, err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
+	content.WriteString("\t\tmodels = append(models, &m)\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif err := rows.Err(); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif err := rows.Close(); err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif len(models) == 0 {\n")
+	content.WriteString("\t\treturn nil, nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tleasedUntil := time.Now().Add(leaseDuration)\n")
+	content.WriteString("\tkeys := make([]interface{}, len(models))\n")
+	content.WriteString("\tfor i, m := range models {\n")
+	content.WriteString(fmt.Sprintf("\t\tkeys[i] = %s\n", generatePrimaryKeyArg(model, "m."+model.PrimaryKey)))
+	content.WriteString("\t}\n\n")
+
+	// Without row locks, a concurrent claim may take a job between the SELECT
+	// and the UPDATE, which then skips it for not being pending anymore, so the
+	// UPDATE returns the keys of the jobs it claimed.
+	returning := d.locking == lockUnsupported
+	primaryType := getPrimaryType(model)
+	if returning {
+		content.WriteString(fmt.Sprintf("\tclaimed := make(map[%s]bool, len(models))\n", primaryType))
+	}
+	content.WriteString(generateBatchLoop("keys", "inListBatchSize", 3, d))
+	update := fmt.Sprintf("UPDATE %s SET %s = %s, %s = %s%s WHERE %s = %s AND %s IN (%%s)", d.table(model), d.quote(status.Column), d.valueBind(status, d.bind(1)), d.quote(lease.Column), d.valueBind(lease, d.bind(2)), versionIncrement(model, d), d.quote(status.Column), d.valueBind(status, d.bind(3)), d.quote(getPrimaryColumn(model)))
+	if returning {
+		update += " RETURNING " + d.quote(getPrimaryColumn(model))
+	}
+	content.WriteString(fmt.Sprintf("\t\tquery := fmt.Sprintf(%s, strings.Join(placeholders, \", \"))\n", d.literal(update)))
+	content.WriteString(fmt.Sprintf("\t\targs := append([]interface{}{%q, leasedUntil, %q}, batch...)\n", model.Queue.Claimed, model.Queue.Pending))
+	if returning {
+		content.WriteString("\t\tkeyRows, err := dao.queryContext(ctx, query, args...)\n")
+		content.WriteString("\t\tif err != nil {\n")
+		content.WriteString("\t\t\treturn nil, err\n")
+		content.WriteString("\t\t}\n")
+		content.WriteString("\t\tfor keyRows.Next() {\n")
+		content.WriteString(fmt.Sprintf("\t\t\tvar key %s\n", primaryType))
+		content.WriteString(fmt.Sprintf("\t\t\tif err := keyRows.Scan(%s); err != nil {\n", generatePrimaryKeyScanArg(model, "key")))
+		content.WriteString("\t\t\t\tkeyRows.Close()\n")
+		content.WriteString("\t\t\t\treturn nil, err\n")
+		content.WriteString("\t\t\t}\n")
+		content.WriteString("\t\t\tclaimed[key] = true\n")
+		content.WriteString("\t\t}\n")
+		content.WriteString("\t\terr = keyRows.Err()\n")
+		content.WriteString("\t\tkeyRows.Close()\n")
+		content.WriteString("\t\tif err != nil {\n")
+		content.WriteString("\t\t\treturn nil, err\n")
+		content.WriteString("\t\t}\n")
+	} else {
+		content.WriteString("\t\tif _, err := dao.execContext(ctx, query, args...); err != nil {\n")
+		content.WriteString("\t\t\treturn nil, err\n")
+		content.WriteString("\t\t}\n")
+	}
+	content.WriteString("\t}\n\n")
+
+	if returning {
+		content.WriteString(fmt.Sprintf("\tclaimedModels := make([]*%s, 0, len(claimed))\n", model.Name))
+	}
+	content.WriteString("\tfor _, m := range models {\n")
+	if returning {
+		content.WriteString(fmt.Sprintf("\t\tif !claimed[m.%s] {\n", model.PrimaryKey))
+		content.WriteString("\t\t\tcontinue\n")
+		content.WriteString("\t\t}\n")
+	}
+	content.WriteString(fmt.Sprintf("\t\tm.%s = %q\n", status.Name, model.Queue.Claimed))
+	if lease.Type == "*time.Time" {
+		content.WriteString("\t\tleasedUntil := leasedUntil\n")
+		content.WriteString(fmt.Sprintf("\t\tm.%s = &leasedUntil\n", lease.Name))
+	} else {
+		content.WriteString(fmt.Sprintf("\t\tm.%s = leasedUntil\n", lease.Name))
+	}
+	if field, ok := getVersionField(model); ok {
+		content.WriteString(fmt.Sprintf("\t\tm.%s++\n", field.Name))
+	}
+	if returning {
+		content.WriteString("\t\tclaimedModels = append(claimedModels, m)\n")
+	}
+	content.WriteString("\t}\n\n")
+
+	if returning {
+		content.WriteString("\treturn claimedModels, nil\n")
+	} else {
+		content.WriteString("\treturn models, nil\n")
+	}
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateQueueTransitionMethod moves a claimed job to state, failing with
+// ErrNotClaimed, wrapped with the model and primary key, when the job is not
+// claimed.
+func generateQueueTransitionMethod(model parser.Model, daoName string, d dialect, methodName, state string) string {
+	var content strings.Builder
+	status, _ := getQueueFields(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s(ctx context.Context, pk %s) error {\n", daoName, methodName, getPrimaryType(model)))
+	content.WriteString(generateTenantPrelude(model, ""))
+	query := fmt.Sprintf("UPDATE %s SET %s = %s%s WHERE %s = %s AND %s = %s%s%s", d.table(model), d.quote(status.Column), d.valueBind(status, d.bind(1)), versionIncrement(model, d), d.quote(getPrimaryColumn(model)), d.bind(2), d.quote(status.Column), d.valueBind(status, d.bind(3)), andConditions(defaultConditions(model, d)), tenantCondition(model, d, 4))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\tresult, err := dao.execContext(ctx, query, %q, %s, %q%s)\n", state, generatePrimaryKeyArg(model, "pk"), model.Queue.Claimed, tenantArg(model)))
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\taffected, err := result.RowsAffected()\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif affected == 0 {\n")
+	content.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"%%w: %s %%v\", ErrNotClaimed, pk)\n", model.Name))
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateReapExpiredMethod returns the claimed jobs whose lease expired to
+// the pending state, so that they can be claimed again.
+func generateReapExpiredMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	status, lease := getQueueFields(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) ReapExpired(ctx context.Context) (int64, error) {\n", daoName))
+	content.WriteString(generateTenantPrelude(model, "0, "))
+	query := fmt.Sprintf("UPDATE %s SET %s = %s%s WHERE %s = %s AND %s < %s%s%s", d.table(model), d.quote(status.Column), d.valueBind(status, d.bind(1)), versionIncrement(model, d), d.quote(status.Column), d.valueBind(status, d.bind(2)), d.quote(lease.Column), d.valueBind(lease, d.bind(3)), andConditions(defaultConditions(model, d)), tenantCondition(model, d, 4))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\tresult, err := dao.execContext(ctx, query, %q, %q, time.Now()%s)\n", model.Queue.Pending, model.Queue.Claimed, tenantArg(model)))
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn 0, err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn result.RowsAffected()\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+func generateQueueHelpers() string {
+	var content strings.Builder
+
+	content.WriteString("// ErrNotClaimed is returned by Ack and Release for a row of a queue model that\n")
+	content.WriteString("// is not claimed, either because it does not exist or because its lease expired\n")
+	content.WriteString("// and was reaped. The error names the model and the primary key of the row.\n")
+	content.WriteString("var ErrNotClaimed = errors.New(\"not claimed\")\n")
+
+	return content.String()
+}
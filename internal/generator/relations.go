@@ -0,0 +1,600 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+func getFieldByColumn(model parser.Model, column string) parser.Field {
+	for _, field := range model.Fields {
+		if field.Column == column {
+			return field
+		}
+	}
+	return parser.Field{}
+}
+
+// relatedDAO returns Go source building the DAO of the target of relation
+// from the DAO of model, sharing its database and encryptor.
+func relatedDAO(model parser.Model, relation parser.Relation) (string, error) {
+	if relation.Target == nil {
+		return "", fmt.Errorf("the %s relation of the %s model is not resolved", relation.Name, model.Name)
+	}
+
+	daoName := fmt.Sprintf("%sDAO", relation.Target.Name)
+	if !hasEncryptedField(*relation.Target) {
+		return fmt.Sprintf("&%s{db: dao.db}", daoName), nil
+	}
+	return fmt.Sprintf("&%s{db: dao.db, encryptor: dao.encryptor}", daoName), nil
+}
+
+// generateRelationMethods generates the Preload and FindAllWith methods of
+// the belongs_to and has_many relations of model, loading each relation with
+// one query, their Join and LeftJoin methods, and the methods linking and
+// loading many_to_many relations.
+func generateRelationMethods(model parser.Model, daoName string, d dialect) (string, error) {
+	var content strings.Builder
+
+	for _, relation := range model.Relations {
+		related, err := relatedDAO(model, relation)
+		if err != nil {
+			return "", err
+		}
+
+		switch relation.Kind {
+		case parser.BelongsTo, parser.HasMany:
+			if relation.Kind == parser.BelongsTo {
+				content.WriteString(generatePreloadBelongsToMethod(model, relation, daoName, related, d))
+			} else {
+				content.WriteString(generatePreloadHasManyMethod(model, relation, daoName, related, d))
+			}
+			content.WriteString(generateFindAllWithMethod(model, relation, daoName))
+
+			joinMethods, err := generateJoinMethods(model, relation, daoName, d)
+			if err != nil {
+				return "", err
+			}
+			content.WriteString(joinMethods)
+		case parser.ManyToMany:
+			content.WriteString(generateLinkTenantCheck(model, relation, daoName, d))
+			content.WriteString(generateAttachMethod(model, relation, daoName, d))
+			content.WriteString(generateDetachMethod(model, relation, daoName, d))
+			content.WriteString(generateSyncMethod(model, relation, daoName, d))
+			content.WriteString(generateLoadMethod(model, relation, daoName, related, d))
+		default:
+			return "", fmt.Errorf("unknown relation %q on the %s field of the %s model", relation.Kind, relation.Name, model.Name)
+		}
+	}
+
+	return content.String(), nil
+}
+
+// foreignKey returns the Go expression reading the foreign key field key of
+// the variable name, dereferenced when key is a pointer, along with the
+// condition under which a pointer key holds no key.
+func foreignKey(key parser.Field, name string) (value, missing string) {
+	if strings.HasPrefix(key.Type, "*") {
+		return fmt.Sprintf("*%s.%s", name, key.Name), fmt.Sprintf("%s.%s == nil", name, key.Name)
+	}
+	return fmt.Sprintf("%s.%s", name, key.Name), ""
+}
+
+// generatePreloadBelongsToMethod sets the relation field of every model to the
+// target whose primary key matches the foreign key of the model. Models with
+// a nil foreign key are left without a target.
+func generatePreloadBelongsToMethod(model parser.Model, relation parser.Relation, daoName, related string, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+	key := getFieldByColumn(model, relation.Column)
+	keyType := strings.TrimPrefix(key.Type, "*")
+	value, missing := foreignKey(key, "model")
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Preload%s(ctx context.Context, models []*%s) error {\n", daoName, relation.Name, model.Name))
+	content.WriteString("\tif len(models) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(fmt.Sprintf("\tseen := make(map[%s]bool, len(models))\n", keyType))
+	content.WriteString("\tkeys := make([]interface{}, 0, len(models))\n")
+	content.WriteString("\tfor _, model := range models {\n")
+	if missing != "" {
+		content.WriteString(fmt.Sprintf("\t\tif %s || seen[%s] {\n", missing, value))
+	} else {
+		content.WriteString(fmt.Sprintf("\t\tif seen[%s] {\n", value))
+	}
+	content.WriteString("\t\t\tcontinue\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(fmt.Sprintf("\t\tseen[%s] = true\n", value))
+	content.WriteString(fmt.Sprintf("\t\tkeys = append(keys, %s)\n", generatePrimaryKeyArg(target, value)))
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateRelatedQuery(target, getPrimaryColumn(target), "", related, "keys", d))
+
+	content.WriteString(fmt.Sprintf("\tbyKey := make(map[%s]*%s, len(related))\n", keyType, target.Name))
+	content.WriteString("\tfor _, r := range related {\n")
+	content.WriteString(fmt.Sprintf("\t\tbyKey[r.%s] = r\n", target.PrimaryKey))
+	content.WriteString("\t}\n")
+	content.WriteString("\tfor _, model := range models {\n")
+	if missing != "" {
+		content.WriteString(fmt.Sprintf("\t\tif %s {\n", missing))
+		if strings.HasPrefix(relation.Type, "*") {
+			content.WriteString(fmt.Sprintf("\t\t\tmodel.%s = nil\n", relation.Name))
+		}
+		content.WriteString("\t\t\tcontinue\n")
+		content.WriteString("\t\t}\n")
+	}
+	if strings.HasPrefix(relation.Type, "*") {
+		content.WriteString(fmt.Sprintf("\t\tmodel.%s = byKey[%s]\n", relation.Name, value))
+	} else {
+		content.WriteString(fmt.Sprintf("\t\tif r, ok := byKey[%s]; ok {\n", value))
+		content.WriteString(fmt.Sprintf("\t\t\tmodel.%s = *r\n", relation.Name))
+		content.WriteString("\t\t}\n")
+	}
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generatePreloadHasManyMethod fills the relation field of every model with
+// the targets whose foreign key matches the primary key of the model, in
+// primary key order.
+func generatePreloadHasManyMethod(model parser.Model, relation parser.Relation, daoName, related string, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+	key := getFieldByColumn(target, relation.Column)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Preload%s(ctx context.Context, models []*%s) error {\n", daoName, relation.Name, model.Name))
+	content.WriteString("\tif len(models) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateModelKeys(model, relation))
+
+	content.WriteString(generateRelatedQuery(target, relation.Column, d.quote(getPrimaryColumn(target)), related, "keys", d))
+
+	element := "r"
+	if !strings.HasPrefix(relation.Type, "[]*") {
+		element = "*r"
+	}
+	value, missing := foreignKey(key, "r")
+	content.WriteString("\tfor _, r := range related {\n")
+	if missing != "" {
+		content.WriteString(fmt.Sprintf("\t\tif %s {\n", missing))
+		content.WriteString("\t\t\tcontinue\n")
+		content.WriteString("\t\t}\n")
+	}
+	content.WriteString(fmt.Sprintf("\t\tif model, ok := byKey[%s]; ok {\n", value))
+	content.WriteString(fmt.Sprintf("\t\t\tmodel.%s = append(model.%s, %s)\n", relation.Name, relation.Name, element))
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateModelKeys indexes models by primary key into byKey, resetting their
+// relation field, and collects their distinct primary keys into keys.
+func generateModelKeys(model parser.Model, relation parser.Relation) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("\tbyKey := make(map[%s]*%s, len(models))\n", getPrimaryType(model), model.Name))
+	content.WriteString("\tkeys := make([]interface{}, 0, len(models))\n")
+	content.WriteString("\tfor _, model := range models {\n")
+	content.WriteString(fmt.Sprintf("\t\tmodel.%s = nil\n", relation.Name))
+	content.WriteString(fmt.Sprintf("\t\tif _, ok := byKey[model.%s]; ok {\n", model.PrimaryKey))
+	content.WriteString("\t\t\tcontinue\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString(fmt.Sprintf("\t\tbyKey[model.%s] = model\n", model.PrimaryKey))
+	content.WriteString(fmt.Sprintf("\t\tkeys = append(keys, %s)\n", generatePrimaryKeyArg(model, "model."+model.PrimaryKey)))
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateBatchLoop opens a loop over the arguments held in keys, in batches
+// of at most size declaring batch and the placeholders binding it after the
+// first offset arguments of the query, so that no IN list exceeds the limits
+// of the database.
+func generateBatchLoop(keys, size string, offset int, d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("\tfor start := 0; start < len(%s); start += %s {\n", keys, size))
+	content.WriteString(fmt.Sprintf("\t\tend := start + %s\n", size))
+	content.WriteString(fmt.Sprintf("\t\tif end > len(%s) {\n", keys))
+	content.WriteString(fmt.Sprintf("\t\t\tend = len(%s)\n", keys))
+	content.WriteString("\t\t}\n")
+	content.WriteString(fmt.Sprintf("\t\tbatch := %s[start:end]\n", keys))
+	content.WriteString("\t\tplaceholders := make([]string, len(batch))\n")
+	content.WriteString("\t\tfor i := range batch {\n")
+	content.WriteString(fmt.Sprintf("\t\t\tplaceholders[i] = %s\n", d.bindExpr(fmt.Sprintf("i+%d", offset+1))))
+	content.WriteString("\t\t}\n")
+
+	return content.String()
+}
+
+// generateRelatedQuery loads into related the targets whose column is one of
+// the arguments held in keys, through the FindAll method of the related DAO
+// called once per batch of keys.
+func generateRelatedQuery(target parser.Model, column, sort, related, keys string, d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("\trelatedDAO := %s\n", related))
+	content.WriteString(fmt.Sprintf("\tvar related []*%s\n", target.Name))
+	content.WriteString(generateBatchLoop(keys, "inListBatchSize", 0, d))
+	content.WriteString(fmt.Sprintf("\t\twhere := fmt.Sprintf(%s, strings.Join(placeholders, \", \"))\n", d.literal(d.quote(column)+" IN (%s)")))
+	content.WriteString(fmt.Sprintf("\t\tfound, err := relatedDAO.FindAll(ctx, where, %q, batch...)\n", sort))
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\trelated = append(related, found...)\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateBatchTransaction reruns a write method in a transaction when it is
+// split into several statements and ctx carries none, so that it applies
+// atomically.
+func generateBatchTransaction(call, count, size string) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("\tif %s > %s && dao.getTx(ctx) == nil {\n", count, size))
+	content.WriteString("\t\treturn dao.WithTransaction(ctx, func(ctx context.Context) error {\n")
+	content.WriteString(fmt.Sprintf("\t\t\treturn dao.%s\n", call))
+	content.WriteString("\t\t})\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+func generateFindAllWithMethod(model parser.Model, relation parser.Relation, daoName string) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) FindAllWith%s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, relation.Name, model.Name))
+	content.WriteString("\tmodels, err := dao.FindAll(ctx, where, sort, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+	content.WriteString(fmt.Sprintf("\tif err := dao.Preload%s(ctx, models); err != nil {\n", relation.Name))
+	content.WriteString("\t\treturn nil, err\n")
+	content.WriteString("\t}\n\n")
+	content.WriteString("\treturn models, nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// joinTable returns the quoted join table of relation, in the schema of model
+// unless qualified.
+func joinTable(model parser.Model, relation parser.Relation, d dialect) string {
+	if schema, table, ok := strings.Cut(relation.JoinTable, "."); ok {
+		return d.quote(schema) + "." + d.quote(table)
+	}
+	if model.Schema == "" {
+		return d.quote(relation.JoinTable)
+	}
+	return d.quote(model.Schema) + "." + d.quote(relation.JoinTable)
+}
+
+// linkTenantField returns the tenant field scoping the links of relation: the
+// tenant of model or, when model has none, the tenant of the target.
+func linkTenantField(model parser.Model, relation parser.Relation) (parser.Field, bool) {
+	if field, ok := getTenantField(model); ok {
+		return field, true
+	}
+	return getTenantField(*relation.Target)
+}
+
+// generateLinkTenantPrelude resolves tenantID at the start of the methods
+// linking the targets of relation, as the Join methods do: through the tenant
+// of model or, when model has none, as the tenant field type of the target.
+func generateLinkTenantPrelude(model parser.Model, relation parser.Relation) string {
+	if _, ok := getTenantField(model); ok {
+		return generateTenantPrelude(model, "")
+	}
+	field, ok := getTenantField(*relation.Target)
+	if !ok {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("\tvar tenantID %s\n", field.Type))
+	content.WriteString("\tif !tenantValue(ctx, &tenantID) {\n")
+	content.WriteString("\t\treturn ErrMissingTenant\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateLinkTenantCall checks that the model with primary key pk and the
+// targets held in relatedPks belong to the tenant, before the join table of
+// relation is written.
+func generateLinkTenantCall(model parser.Model, relation parser.Relation, relatedPks string) string {
+	if _, ok := linkTenantField(model, relation); !ok {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("\tif err := dao.check%sTenant(ctx, tenantID, pk, %s); err != nil {\n", relation.Name, relatedPks))
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateLinkTenantCheck generates the method returning sql.ErrNoRows unless
+// the model and the targets linked by relation belong to the tenant, each
+// counted among the rows of its table scoped to it. Untenanted sides are not
+// checked.
+func generateLinkTenantCheck(model parser.Model, relation parser.Relation, daoName string, d dialect) string {
+	tenantField, ok := linkTenantField(model, relation)
+	if !ok {
+		return ""
+	}
+
+	var content strings.Builder
+	target := *relation.Target
+	targetType := getPrimaryType(target)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) check%sTenant(ctx context.Context, tenantID %s, pk %s, relatedPks []%s) error {\n", daoName, relation.Name, tenantField.Type, getPrimaryType(model), targetType))
+
+	if field, ok := getTenantField(model); ok {
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = %s AND %s = %s", d.table(model), d.quote(getPrimaryColumn(model)), d.bind(1), d.quote(field.Column), d.bind(2))
+		content.WriteString("\tvar count int64\n")
+		content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+		content.WriteString(fmt.Sprintf("\tif err := dao.queryRowContext(ctx, query, %s, tenantID).Scan(&count); err != nil {\n", generatePrimaryKeyArg(model, "pk")))
+		content.WriteString("\t\treturn err\n")
+		content.WriteString("\t}\n")
+		content.WriteString("\tif count == 0 {\n")
+		content.WriteString("\t\treturn sql.ErrNoRows\n")
+		content.WriteString("\t}\n\n")
+	}
+
+	if field, ok := getTenantField(target); ok {
+		content.WriteString(fmt.Sprintf("\tseen := make(map[%s]bool, len(relatedPks))\n", targetType))
+		content.WriteString("\tkeys := make([]interface{}, 0, len(relatedPks))\n")
+		content.WriteString("\tfor _, relatedPk := range relatedPks {\n")
+		content.WriteString("\t\tif seen[relatedPk] {\n")
+		content.WriteString("\t\t\tcontinue\n")
+		content.WriteString("\t\t}\n")
+		content.WriteString("\t\tseen[relatedPk] = true\n")
+		content.WriteString(fmt.Sprintf("\t\tkeys = append(keys, %s)\n", generatePrimaryKeyArg(target, "relatedPk")))
+		content.WriteString("\t}\n\n")
+
+		content.WriteString(generateBatchLoop("keys", "inListBatchSize", 1, d))
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = %s AND %s IN (%%s)", d.table(target), d.quote(field.Column), d.bind(1), d.quote(getPrimaryColumn(target)))
+		content.WriteString(fmt.Sprintf("\t\tquery := fmt.Sprintf(%s, strings.Join(placeholders, \", \"))\n", d.literal(query)))
+		content.WriteString("\t\tvar found int64\n")
+		content.WriteString("\t\tif err := dao.queryRowContext(ctx, query, append([]interface{}{tenantID}, batch...)...).Scan(&found); err != nil {\n")
+		content.WriteString("\t\t\treturn err\n")
+		content.WriteString("\t\t}\n")
+		content.WriteString("\t\tif found != int64(len(batch)) {\n")
+		content.WriteString("\t\t\treturn sql.ErrNoRows\n")
+		content.WriteString("\t\t}\n")
+		content.WriteString("\t}\n\n")
+	}
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateAttachMethod links the model with primary key pk to the targets of
+// relation with one multi-row insert into the join table per batch.
+func generateAttachMethod(model parser.Model, relation parser.Relation, daoName string, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Attach%s(ctx context.Context, pk %s, relatedPks ...%s) error {\n", daoName, relation.Name, getPrimaryType(model), getPrimaryType(target)))
+	content.WriteString(generateLinkTenantPrelude(model, relation))
+	content.WriteString("\tif len(relatedPks) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateBatchTransaction(fmt.Sprintf("Attach%s(ctx, pk, relatedPks...)", relation.Name), "len(relatedPks)", "inListBatchSize/2"))
+	content.WriteString(generateLinkTenantCall(model, relation, "relatedPks"))
+
+	// Each row binds two arguments, so batches hold half as many rows.
+	content.WriteString("\tfor start := 0; start < len(relatedPks); start += inListBatchSize / 2 {\n")
+	content.WriteString("\t\tend := start + inListBatchSize/2\n")
+	content.WriteString("\t\tif end > len(relatedPks) {\n")
+	content.WriteString("\t\t\tend = len(relatedPks)\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tbatch := relatedPks[start:end]\n")
+	content.WriteString("\t\tplaceholders := make([]string, len(batch))\n")
+	content.WriteString("\t\targs := make([]interface{}, 0, len(batch)*2)\n")
+	into := fmt.Sprintf("%s (%s, %s)", joinTable(model, relation, d), d.quote(relation.Column), d.quote(relation.TargetColumn))
+	row := fmt.Sprintf("(%s, %s)", d.placeholder, d.placeholder)
+	rowLiteral := fmt.Sprintf("%q", row)
+	separator, query := ", ", fmt.Sprintf("INSERT INTO %s VALUES %%s", into)
+	if d.insertAll {
+		// Each row gets its own INTO clause, and INSERT ALL requires a subquery.
+		rowLiteral = d.literal(fmt.Sprintf("INTO %s VALUES %s", into, row))
+		separator, query = " ", "INSERT ALL %s SELECT 1 FROM DUAL"
+	}
+
+	content.WriteString("\t\tfor i, relatedPk := range batch {\n")
+	if d.isPositional() {
+		content.WriteString(fmt.Sprintf("\t\t\tplaceholders[i] = %s\n", rowLiteral))
+	} else {
+		content.WriteString(fmt.Sprintf("\t\t\tplaceholders[i] = fmt.Sprintf(%s, i*2+1, i*2+2)\n", rowLiteral))
+	}
+	content.WriteString(fmt.Sprintf("\t\t\targs = append(args, %s, %s)\n", generatePrimaryKeyArg(model, "pk"), generatePrimaryKeyArg(target, "relatedPk")))
+	content.WriteString("\t\t}\n")
+
+	content.WriteString(fmt.Sprintf("\t\tquery := fmt.Sprintf(%s, strings.Join(placeholders, %q))\n", d.literal(query), separator))
+	content.WriteString("\t\tif _, err := dao.execContext(ctx, query, args...); err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateDetachMethod unlinks the model with primary key pk from the given
+// targets of relation.
+func generateDetachMethod(model parser.Model, relation parser.Relation, daoName string, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Detach%s(ctx context.Context, pk %s, relatedPks ...%s) error {\n", daoName, relation.Name, getPrimaryType(model), getPrimaryType(target)))
+	content.WriteString(generateLinkTenantPrelude(model, relation))
+	content.WriteString("\tif len(relatedPks) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateBatchTransaction(fmt.Sprintf("Detach%s(ctx, pk, relatedPks...)", relation.Name), "len(relatedPks)", "inListBatchSize"))
+	content.WriteString(generateLinkTenantCall(model, relation, "relatedPks"))
+
+	content.WriteString("\tkeys := make([]interface{}, len(relatedPks))\n")
+	content.WriteString("\tfor i, relatedPk := range relatedPks {\n")
+	content.WriteString(fmt.Sprintf("\t\tkeys[i] = %s\n", generatePrimaryKeyArg(target, "relatedPk")))
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateBatchLoop("keys", "inListBatchSize", 1, d))
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = %s AND %s IN (%%s)", joinTable(model, relation, d), d.quote(relation.Column), d.bind(1), d.quote(relation.TargetColumn))
+	content.WriteString(fmt.Sprintf("\t\tquery := fmt.Sprintf(%s, strings.Join(placeholders, \", \"))\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\t\targs := append([]interface{}{%s}, batch...)\n", generatePrimaryKeyArg(model, "pk")))
+	content.WriteString("\t\tif _, err := dao.execContext(ctx, query, args...); err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateSyncMethod replaces the targets linked to the model with primary key
+// pk by relatedPks, in a transaction unless ctx already carries one.
+func generateSyncMethod(model parser.Model, relation parser.Relation, daoName string, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Sync%s(ctx context.Context, pk %s, relatedPks []%s) error {\n", daoName, relation.Name, getPrimaryType(model), getPrimaryType(target)))
+	content.WriteString(generateLinkTenantPrelude(model, relation))
+	content.WriteString("\tif dao.getTx(ctx) == nil {\n")
+	content.WriteString("\t\treturn dao.WithTransaction(ctx, func(ctx context.Context) error {\n")
+	content.WriteString(fmt.Sprintf("\t\t\treturn dao.Sync%s(ctx, pk, relatedPks)\n", relation.Name))
+	content.WriteString("\t\t})\n")
+	content.WriteString("\t}\n\n")
+
+	// The targets are checked by Attach, once the old links are deleted.
+	content.WriteString(generateLinkTenantCall(model, relation, "nil"))
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = %s", joinTable(model, relation, d), d.quote(relation.Column), d.bind(1))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\tif _, err := dao.execContext(ctx, query, %s); err != nil {\n", generatePrimaryKeyArg(model, "pk")))
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(fmt.Sprintf("\treturn dao.Attach%s(ctx, pk, relatedPks...)\n", relation.Name))
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateLoadMethod fills the relation field of every model with its linked
+// targets, reading the join table and then the targets with one query each.
+func generateLoadMethod(model parser.Model, relation parser.Relation, daoName, related string, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+	primaryType := getPrimaryType(model)
+	targetType := getPrimaryType(target)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Load%s(ctx context.Context, models []*%s) error {\n", daoName, relation.Name, model.Name))
+	content.WriteString("\tif len(models) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateModelKeys(model, relation))
+
+	content.WriteString(fmt.Sprintf("\tlinks := make(map[%s][]%s, len(models))\n", primaryType, targetType))
+	content.WriteString(fmt.Sprintf("\tseen := make(map[%s]bool)\n", targetType))
+	content.WriteString("\tvar relatedKeys []interface{}\n")
+	content.WriteString(generateBatchLoop("keys", "inListBatchSize", 0, d))
+	query := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s IN (%%s) ORDER BY %s", d.quote(relation.Column), d.quote(relation.TargetColumn), joinTable(model, relation, d), d.quote(relation.Column), d.quote(relation.TargetColumn))
+	content.WriteString(fmt.Sprintf("\t\tquery := fmt.Sprintf(%s, strings.Join(placeholders, \", \"))\n", d.literal(query)))
+	content.WriteString("\t\trows, err := dao.queryContext(ctx, query, batch...)\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tfor rows.Next() {\n")
+	content.WriteString(fmt.Sprintf("\t\t\tvar key %s\n", primaryType))
+	content.WriteString(fmt.Sprintf("\t\t\tvar relatedKey %s\n", targetType))
+	content.WriteString(fmt.Sprintf("\t\t\tif err := rows.Scan(%s, %s); err != nil {\n", generatePrimaryKeyScanArg(model, "key"), generatePrimaryKeyScanArg(target, "relatedKey")))
+	content.WriteString("\t\t\t\trows.Close()\n")
+	content.WriteString("\t\t\t\treturn err\n")
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t\tlinks[key] = append(links[key], relatedKey)\n")
+	content.WriteString("\t\t\tif seen[relatedKey] {\n")
+	content.WriteString("\t\t\t\tcontinue\n")
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t\tseen[relatedKey] = true\n")
+	content.WriteString(fmt.Sprintf("\t\t\trelatedKeys = append(relatedKeys, %s)\n", generatePrimaryKeyArg(target, "relatedKey")))
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\terr = rows.Err()\n")
+	content.WriteString("\t\trows.Close()\n")
+	content.WriteString("\t\tif err != nil {\n")
+	content.WriteString("\t\t\treturn err\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tif len(relatedKeys) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateRelatedQuery(target, getPrimaryColumn(target), "", related, "relatedKeys", d))
+
+	element := "r"
+	if !strings.HasPrefix(relation.Type, "[]*") {
+		element = "*r"
+	}
+	content.WriteString(fmt.Sprintf("\trelatedByKey := make(map[%s]*%s, len(related))\n", targetType, target.Name))
+	content.WriteString("\tfor _, r := range related {\n")
+	content.WriteString(fmt.Sprintf("\t\trelatedByKey[r.%s] = r\n", target.PrimaryKey))
+	content.WriteString("\t}\n")
+	content.WriteString("\tfor key, relatedKeys := range links {\n")
+	content.WriteString("\t\tmodel, ok := byKey[key]\n")
+	content.WriteString("\t\tif !ok {\n")
+	content.WriteString("\t\t\tcontinue\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t\tfor _, relatedKey := range relatedKeys {\n")
+	content.WriteString("\t\t\tif r, ok := relatedByKey[relatedKey]; ok {\n")
+	content.WriteString(fmt.Sprintf("\t\t\t\tmodel.%s = append(model.%s, %s)\n", relation.Name, relation.Name, element))
+	content.WriteString("\t\t\t}\n")
+	content.WriteString("\t\t}\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateBatchHelpers declares the number of keys bound in one IN list by the
+// relation and queue methods of d.
+func generateBatchHelpers(d dialect) string {
+	var content strings.Builder
+
+	content.WriteString("// inListBatchSize is the most keys the relation and queue methods bind in one\n")
+	content.WriteString("// IN list, splitting longer lists into several queries to stay within the\n")
+	content.WriteString(fmt.Sprintf("// limits of %s on list items and query parameters.\n", d.name))
+	content.WriteString(fmt.Sprintf("const inListBatchSize = %d\n", d.maxInList))
+
+	return content.String()
+}
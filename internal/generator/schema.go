@@ -0,0 +1,248 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/naming"
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// schemaFileName is the file holding the DDL of the models of a driver package.
+const schemaFileName = "schema.sql"
+
+// columnTypes maps the kind of a Go type to its column type in each dialect.
+// The sized and decimal entries are formats taking the size, or the precision
+// and scale, of the field. The uuid entry replaces a uuid type declared in a
+// tag, holding the text of the uuid where the database has no such type.
+var columnTypes = map[string]map[string]string{
+	"postgres":  {"string": "TEXT", "integer": "BIGINT", "bool": "BOOLEAN", "float": "DOUBLE PRECISION", "time": "TIMESTAMP", "bytes": "BYTEA", "json": "JSONB", "sized": "VARCHAR(%d)", "decimal": "NUMERIC(%d,%d)", "uuid": "UUID"},
+	"mysql":     {"string": "VARCHAR(255)", "integer": "BIGINT", "bool": "BOOLEAN", "float": "DOUBLE", "time": "DATETIME", "bytes": "BLOB", "json": "JSON", "sized": "VARCHAR(%d)", "decimal": "DECIMAL(%d,%d)", "uuid": "CHAR(36)"},
+	"sqlserver": {"string": "NVARCHAR(255)", "integer": "BIGINT", "bool": "BIT", "float": "FLOAT", "time": "DATETIME2", "bytes": "VARBINARY(MAX)", "json": "NVARCHAR(MAX)", "sized": "NVARCHAR(%d)", "decimal": "DECIMAL(%d,%d)", "uuid": "UNIQUEIDENTIFIER"},
+	"oracle":    {"string": "VARCHAR2(255)", "integer": "NUMBER(19)", "bool": "NUMBER(1)", "float": "BINARY_DOUBLE", "time": "TIMESTAMP", "bytes": "BLOB", "json": "CLOB", "sized": "VARCHAR2(%d)", "decimal": "NUMBER(%d,%d)", "uuid": "CHAR(36)"},
+	"sqlite":    {"string": "TEXT", "integer": "INTEGER", "bool": "INTEGER", "float": "REAL", "time": "DATETIME", "bytes": "BLOB", "json": "TEXT", "sized": "VARCHAR(%d)", "decimal": "NUMERIC(%d,%d)", "uuid": "TEXT"},
+}
+
+// GenerateSchema writes the DDL creating the tables of models, and the enum
+// types their columns use, to the driver directory of outputPath.
+func GenerateSchema(models []parser.Model, outputPath, driver string) error {
+	d, ok := dialectByName(driver)
+	if !ok {
+		return fmt.Errorf("unsupported driver: %s", driver)
+	}
+
+	driverPath := filepath.Join(outputPath, driver)
+
+	if err := os.MkdirAll(driverPath, 0755); err != nil {
+		return fmt.Errorf("failed to create driver directory: %v", err)
+	}
+
+	filePath := filepath.Join(driverPath, schemaFileName)
+
+	if _, err := os.Stat(filePath); err == nil {
+		return fmt.Errorf("file with name %s already exists", filePath)
+	}
+
+	if err := os.WriteFile(filePath, []byte(generateSchema(models, d)), 0644); err != nil {
+		return fmt.Errorf("failed to write schema file: %v", err)
+	}
+
+	return nil
+}
+
+func dialectByName(name string) (dialect, bool) {
+	for _, d := range []dialect{postgresDialect, mysqlDialect, sqlserverDialect, oracleDialect, sqliteDialect} {
+		if d.name == name {
+			return d, true
+		}
+	}
+	return dialect{}, false
+}
+
+func generateSchema(models []parser.Model, d dialect) string {
+	var content strings.Builder
+
+	declared := map[string]bool{}
+	for _, model := range models {
+		for _, field := range getEnumFields(model) {
+			if !usesEnumType(field, d) {
+				continue
+			}
+			name := enumTypeName(model, field, d)
+			if declared[name] {
+				continue
+			}
+			declared[name] = true
+			content.WriteString(fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);\n\n", name, strings.Join(enumSQLValues(field.Enum), ", ")))
+		}
+	}
+
+	for i, model := range models {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(generateCreateTable(model, d))
+	}
+
+	joinTables := map[string]bool{}
+	for _, model := range models {
+		for _, relation := range model.Relations {
+			if relation.Kind != parser.ManyToMany || relation.Target == nil {
+				continue
+			}
+			name := joinTable(model, relation, d)
+			if joinTables[name] {
+				continue
+			}
+			joinTables[name] = true
+			content.WriteString("\n")
+			content.WriteString(generateCreateJoinTable(model, relation, d))
+		}
+	}
+
+	return content.String()
+}
+
+// generateCreateJoinTable declares the join table of a many_to_many relation,
+// keyed by the pair of primary keys it links.
+func generateCreateJoinTable(model parser.Model, relation parser.Relation, d dialect) string {
+	var content strings.Builder
+	target := *relation.Target
+
+	content.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", joinTable(model, relation, d)))
+	content.WriteString(fmt.Sprintf("    %s %s NOT NULL,\n", d.quote(relation.Column), columnType(model, getFieldByColumn(model, getPrimaryColumn(model)), d)))
+	content.WriteString(fmt.Sprintf("    %s %s NOT NULL,\n", d.quote(relation.TargetColumn), columnType(target, getFieldByColumn(target, getPrimaryColumn(target)), d)))
+	content.WriteString(fmt.Sprintf("    PRIMARY KEY (%s, %s)\n", d.quote(relation.Column), d.quote(relation.TargetColumn)))
+	content.WriteString(");\n")
+
+	return content.String()
+}
+
+func generateCreateTable(model parser.Model, d dialect) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", d.table(model)))
+
+	definitions := make([]string, 0, len(model.Fields))
+	for _, field := range model.Fields {
+		definitions = append(definitions, "    "+columnDefinition(model, field, d))
+	}
+	content.WriteString(strings.Join(definitions, ",\n"))
+
+	content.WriteString("\n);\n")
+
+	return content.String()
+}
+
+// columnDefinition returns the column type and constraints of field.
+func columnDefinition(model parser.Model, field parser.Field, d dialect) string {
+	definition := fmt.Sprintf("%s %s", d.quote(field.Column), columnType(model, field, d))
+
+	if field.IsPrimary {
+		definition += " PRIMARY KEY"
+	} else if field.IsNotNull || !isNullableColumn(field) {
+		definition += " NOT NULL"
+	}
+
+	if field.Enum != nil && !usesEnumType(field, d) && !usesInlineEnum(field, d) {
+		definition += fmt.Sprintf(" CHECK (%s IN (%s))", d.quote(field.Column), strings.Join(enumSQLValues(field.Enum), ", "))
+	}
+
+	return definition
+}
+
+func columnType(model parser.Model, field parser.Field, d dialect) string {
+	switch {
+	case baseType(field.SQLType) == "uuid":
+		return columnTypes[d.name]["uuid"]
+	case field.SQLType != "":
+		return field.SQLType
+	case field.Precision > 0:
+		return fmt.Sprintf(columnTypes[d.name]["decimal"], field.Precision, field.Scale)
+	case usesEnumType(field, d):
+		return enumTypeName(model, field, d)
+	case usesInlineEnum(field, d):
+		return fmt.Sprintf("ENUM(%s)", strings.Join(enumSQLValues(field.Enum), ", "))
+	case d.isArray(field):
+		element := parser.Field{Type: strings.TrimPrefix(field.Type, "[]")}
+		return columnTypes[d.name][typeKind(element)] + "[]"
+	}
+
+	kind := typeKind(field)
+	if field.Size > 0 && kind == "string" {
+		return fmt.Sprintf(columnTypes[d.name]["sized"], field.Size)
+	}
+	return columnTypes[d.name][kind]
+}
+
+// typeKind classifies the Go type of field into a key of columnTypes.
+// Encrypted fields are stored as bytes and unknown types as strings.
+func typeKind(field parser.Field) string {
+	if field.IsEncrypted {
+		return "bytes"
+	}
+	if field.IsJSON {
+		return "json"
+	}
+	if field.Enum != nil {
+		if field.Enum.Integer {
+			return "integer"
+		}
+		return "string"
+	}
+
+	switch strings.TrimPrefix(field.Type, "*") {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"sql.NullInt64", "sql.NullInt32", "sql.NullInt16", "sql.NullByte":
+		return "integer"
+	case "bool", "sql.NullBool":
+		return "bool"
+	case "float32", "float64", "sql.NullFloat64":
+		return "float"
+	case "time.Time", "sql.NullTime":
+		return "time"
+	case "[]byte":
+		return "bytes"
+	}
+	return "string"
+}
+
+// isNullableColumn reports whether field is declared without NOT NULL.
+// Generated columns are left nullable since their expression or default is
+// not known from the model.
+func isNullableColumn(field parser.Field) bool {
+	return field.IsNullable || field.IsGenerated || field.IsJSON || strings.HasPrefix(field.Type, "*") || strings.HasPrefix(field.Type, "[]")
+}
+
+// usesEnumType reports whether field is declared with a CREATE TYPE enum.
+func usesEnumType(field parser.Field, d dialect) bool {
+	return field.Enum != nil && !field.Enum.Integer && d.name == "postgres"
+}
+
+// usesInlineEnum reports whether field is declared with an inline ENUM type.
+func usesInlineEnum(field parser.Field, d dialect) bool {
+	return field.Enum != nil && !field.Enum.Integer && d.name == "mysql"
+}
+
+func enumTypeName(model parser.Model, field parser.Field, d dialect) string {
+	name := d.quote(naming.ToSnakeCase(field.Enum.Type))
+	if model.Schema == "" {
+		return name
+	}
+	return d.quote(model.Schema) + "." + name
+}
+
+// enumSQLValues renders the values of enum as SQL literals.
+func enumSQLValues(enum *parser.Enum) []string {
+	values := make([]string, 0, len(enum.Values))
+	for _, value := range enum.Values {
+		if enum.Integer {
+			values = append(values, value)
+		} else {
+			values = append(values, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+		}
+	}
+	return values
+}
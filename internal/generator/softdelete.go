@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// generateSoftDeleteMethods generates the escape hatches available on models
+// tagged with softdelete. generateFindAll renders a FindAll variant for the
+// given method name and conditions in the caller's dialect.
+func generateSoftDeleteMethods(model parser.Model, daoName string, d dialect, generateFindAll func(model parser.Model, daoName, methodName string, conditions []string) string) string {
+	field, ok := getSoftDeleteField(model)
+	if !ok {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(generateRestoreMethod(model, daoName, d))
+	content.WriteString(generateHardDeleteMethod(model, daoName, d))
+	content.WriteString(generateFindAll(model, daoName, "FindAllWithDeleted", nil))
+	content.WriteString(generateFindAll(model, daoName, "FindAllOnlyDeleted", []string{fmt.Sprintf("%s IS NOT NULL", d.quote(field.Column))}))
+
+	return content.String()
+}
+
+func generateSoftDeleteByIDMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	field, _ := getSoftDeleteField(model)
+	primaryColumn := d.quote(getPrimaryColumn(model))
+	primaryType := getPrimaryType(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteByPk(ctx context.Context, pk %s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s IS NULL%s", d.table(model), d.quote(field.Column), d.bind(1), primaryColumn, d.bind(2), d.quote(field.Column), tenantCondition(model, d, 3))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query, time.Now(), %s%s)\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
+	content.WriteString("\treturn err\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+func generateSoftDeleteManyByIDsMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	field, _ := getSoftDeleteField(model)
+	primaryColumn := d.quote(getPrimaryColumn(model))
+	primaryType := getPrimaryType(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteManyByPks(ctx context.Context, pks []%s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
+	content.WriteString("\tif len(pks) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\tplaceholders := make([]string, len(pks))\n")
+	content.WriteString("\targs := make([]interface{}, 0, len(pks)+1)\n")
+	content.WriteString("\targs = append(args, time.Now())\n")
+	content.WriteString("\tfor i, pk := range pks {\n")
+	content.WriteString(fmt.Sprintf("\t\tplaceholders[i] = %s\n", d.bindExpr("i+2")))
+	content.WriteString(fmt.Sprintf("\t\targs = append(args, %s)\n", generatePrimaryKeyArg(model, "pk")))
+	content.WriteString("\t}\n\n")
+
+	tenantCond, tenantSprintfArg := tenantDynamicCondition(model, d)
+	if tenantCond != "" {
+		content.WriteString("\targs = append(args, tenantID)\n")
+	}
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s IN (%%s) AND %s IS NULL%s", d.table(model), d.quote(field.Column), d.bind(1), primaryColumn, d.quote(field.Column), tenantCond)
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, strings.Join(placeholders, \",\")%s)\n", d.literal(query), tenantSprintfArg))
+	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
+	content.WriteString("\treturn err\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+func generateRestoreMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	field, _ := getSoftDeleteField(model)
+	primaryColumn := d.quote(getPrimaryColumn(model))
+	primaryType := getPrimaryType(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) Restore(ctx context.Context, pk %s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
+	query := fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s = %s%s", d.table(model), d.quote(field.Column), primaryColumn, d.bind(1), tenantCondition(model, d, 2))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query, %s%s)\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
+	content.WriteString("\treturn err\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+func generateHardDeleteMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	primaryColumn := d.quote(getPrimaryColumn(model))
+	primaryType := getPrimaryType(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) HardDelete(ctx context.Context, pk %s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = %s%s", d.table(model), primaryColumn, d.bind(1), tenantCondition(model, d, 2))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n", d.literal(query)))
+	content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query, %s%s)\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
+	content.WriteString("\treturn err\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
@@ -8,14 +8,25 @@ import (
 )
 
 func GenerateSQLiteDAO(model parser.Model) (string, error) {
+	queries, err := analyzeQueries(model)
+	if err != nil {
+		return "", err
+	}
+
 	imports := []string{
 		"context",
 		"database/sql",
+		"errors",
 		"fmt",
 		"strings",
 		model.ImportPath,
 	}
 
+	if _, ok := getSoftDeleteField(model); ok || hasQueue(model) || aggregatesUsePackage(model, "time", sqliteDialect) || queriesUsePackage(queries, "time") {
+		imports = append(imports, "time")
+	}
+	imports = append(imports, converterImports(model)...)
+
 	var content strings.Builder
 
 	content.WriteString(fmt.Sprintf("package %s\n\n", "sqlite"))
@@ -26,18 +37,15 @@ func GenerateSQLiteDAO(model parser.Model) (string, error) {
 	content.WriteString(")\n\n")
 
 	content.WriteString(fmt.Sprintf("type %s = %s.%s\n\n", model.Name, model.Package, model.Name))
+	content.WriteString(generateJoinRowTypes(model))
 
 	daoName := fmt.Sprintf("%sDAO", model.Name)
 
-	content.WriteString(fmt.Sprintf("type %s struct {\n", daoName))
-	content.WriteString("\tdb *sql.DB\n")
-	content.WriteString("}\n\n")
-
-	content.WriteString(fmt.Sprintf("func New%s(db *sql.DB) *%s {\n", daoName, daoName))
-	content.WriteString(fmt.Sprintf("\treturn &%s{db: db}\n", daoName))
-	content.WriteString("}\n\n")
+	content.WriteString(generateDAOStruct(model, daoName))
 
 	content.WriteString(generateSQLiteHelperMethods(daoName))
+	content.WriteString(generateTenantMethods(model, daoName, sqliteDialect))
+	content.WriteString(generateValidMethod(model, daoName))
 	content.WriteString(generateSQLiteCreateMethod(model, daoName))
 	content.WriteString(generateSQLiteUpdateMethod(model, daoName))
 	content.WriteString(generateSQLitePartialUpdateMethod(model, daoName))
@@ -46,10 +54,23 @@ func GenerateSQLiteDAO(model parser.Model) (string, error) {
 	content.WriteString(generateSQLiteCreateManyMethod(model, daoName))
 	content.WriteString(generateSQLiteUpdateManyMethod(model, daoName))
 	content.WriteString(generateSQLiteDeleteManyByIDsMethod(model, daoName))
+	content.WriteString(generateBulkMethods(model, daoName, sqliteDialect))
 	content.WriteString(generateSQLiteFindOneMethod(model, daoName))
-	content.WriteString(generateSQLiteFindAllMethod(model, daoName))
+	content.WriteString(generateSQLiteFindAllMethod(model, daoName, "FindAll", defaultConditions(model, sqliteDialect)))
+	content.WriteString(generateFindAllColumnsMethod(model, daoName, sqliteDialect))
+	content.WriteString(generateLockMethods(model, daoName, sqliteDialect))
+	content.WriteString(generateQueueMethods(model, daoName, sqliteDialect))
 	content.WriteString(generateSQLiteFindPaginatedMethod(model, daoName))
 	content.WriteString(generateSQLiteCountMethod(model, daoName))
+	content.WriteString(generateExistsMethods(model, daoName, sqliteDialect))
+	content.WriteString(generateAggregateMethods(model, daoName, sqliteDialect))
+	content.WriteString(generateSoftDeleteMethods(model, daoName, sqliteDialect, generateSQLiteFindAllMethod))
+	relationMethods, err := generateRelationMethods(model, daoName, sqliteDialect)
+	if err != nil {
+		return "", err
+	}
+	content.WriteString(relationMethods)
+	content.WriteString(generateQueryMethods(model, queries, daoName, sqliteDialect))
 	content.WriteString(generateSQLiteWithTransactionMethod(daoName))
 
 	return content.String(), nil
@@ -95,26 +116,41 @@ func generateSQLiteCreateMethod(model parser.Model, daoName string) string {
 	var placeholders []string
 	var args []string
 
-	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		placeholders = append(placeholders, "?")
-		args = append(args, fmt.Sprintf("m.%s", field.Name))
+	for _, field := range getInsertFields(model) {
+		columns = append(columns, sqliteDialect.quote(field.Column))
+		placeholders = append(placeholders, sqliteDialect.valueBind(field, "?"))
+		args = append(args, generateValueArg(sqliteDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Create(ctx context.Context, m *%s) error {\n", daoName, model.Name))
+	content.WriteString(generateValidCall(model))
+	content.WriteString(generateTenantPrelude(model, ""))
+	if assignment := generateTenantAssignment(model, "m", "\t"); assignment != "" {
+		content.WriteString(assignment + "\n")
+	}
 	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", model.TableName, strings.Join(columns, ", ")))
+	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", sqliteDialect.table(model), strings.Join(columns, ", ")))
+	if output := outputClause(model, sqliteDialect); output != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", output))
+	}
 	content.WriteString(fmt.Sprintf("\t\tVALUES (%s)\n", strings.Join(placeholders, ", ")))
+	if returning := returningClause(model, sqliteDialect, len(args)); returning != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", returning))
+	}
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\t_, err := dao.execContext(\n")
-	content.WriteString("\t\tctx,\n")
-	content.WriteString("\t\tquery,\n")
-	for _, arg := range args {
-		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	if len(getGeneratedFields(model)) > 0 {
+		content.WriteString(generateReadBackWrite(model, sqliteDialect, args, false))
+	} else {
+		content.WriteString("\t_, err := dao.execContext(\n")
+		content.WriteString("\t\tctx,\n")
+		content.WriteString("\t\tquery,\n")
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n\n")
+		content.WriteString("\treturn err\n")
 	}
-	content.WriteString("\t)\n\n")
-	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
@@ -131,34 +167,80 @@ func generateSQLiteUpdateMethod(model parser.Model, daoName string) string {
 			primaryKeyField = field.Name
 			continue
 		}
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", field.Column))
-		args = append(args, fmt.Sprintf("m.%s", field.Name))
+		if field.IsTenant {
+			continue
+		}
+		if field.IsGenerated {
+			continue
+		}
+		if field.IsVersion {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", sqliteDialect.quote(field.Column), sqliteDialect.quote(field.Column)))
+			continue
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", sqliteDialect.quote(field.Column), sqliteDialect.valueBind(field, sqliteDialect.bind(len(args)+1))))
+		args = append(args, generateValueArg(sqliteDialect, field, "m"))
+	}
+
+	args = append(args, generatePrimaryKeyArg(model, "m."+primaryKeyField))
+	whereClause := fmt.Sprintf("WHERE %s = %s", sqliteDialect.quote(getPrimaryColumn(model)), sqliteDialect.bind(len(args)))
+
+	if _, ok := getTenantField(model); ok {
+		args = append(args, "tenantID")
+		whereClause += tenantCondition(model, sqliteDialect, len(args))
 	}
 
-	args = append(args, fmt.Sprintf("m.%s", primaryKeyField))
-	whereClause := fmt.Sprintf("WHERE %s = ?", getPrimaryColumn(model))
+	versionField, versioned := getVersionField(model)
+	if versioned {
+		args = append(args, fmt.Sprintf("m.%s", versionField.Name))
+		whereClause += fmt.Sprintf(" AND %s = %s", sqliteDialect.quote(versionField.Column), sqliteDialect.bind(len(args)))
+	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Update(ctx context.Context, m *%s) error {\n", daoName, model.Name))
+	content.WriteString(generateValidCall(model))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", sqliteDialect.table(model)))
 	content.WriteString(fmt.Sprintf("\t\tSET %s\n", strings.Join(setClauses, ",\n\t\t\t")))
+	if output := outputClause(model, sqliteDialect); output != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", output))
+	}
 	content.WriteString(fmt.Sprintf("\t\t%s\n", whereClause))
+	if returning := returningClause(model, sqliteDialect, len(args)); returning != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", returning))
+	}
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
-	for _, arg := range args {
-		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	if len(getGeneratedFields(model)) > 0 {
+		content.WriteString(generateReadBackWrite(model, sqliteDialect, args, versioned))
+	} else {
+		if versioned {
+			content.WriteString("\tresult, err := dao.execContext(ctx, query,\n")
+		} else {
+			content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
+		}
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n")
+		if versioned {
+			content.WriteString(generateVersionCheck(versionField, "m", "\t"))
+			content.WriteString("\treturn nil\n")
+		} else {
+			content.WriteString("\treturn err\n")
+		}
 	}
-	content.WriteString("\t)\n")
-	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
 }
 
 func generateSQLitePartialUpdateMethod(model parser.Model, daoName string) string {
+	if isUpdateGuarded(model) {
+		return generateGuardedPartialUpdateMethod(model, daoName, sqliteDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqliteDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) PartialUpdate(ctx context.Context, pk %s, fields map[string]interface{}) error {\n", daoName, primaryType))
@@ -170,13 +252,16 @@ func generateSQLitePartialUpdateMethod(model parser.Model, daoName string) strin
 	content.WriteString("\targs := make([]interface{}, 0, len(fields)+1)\n\n")
 
 	content.WriteString("\tfor field, value := range fields {\n")
-	content.WriteString("\t\tsetClauses = append(setClauses, field + \" = ?\")\n")
+	content.WriteString(generateColumnCheck(model, "field", "", "\t\t"))
+	content.WriteString(generateValidFieldCall(model, "", "\t\t"))
+	content.WriteString(generateFieldValueConversion(sqliteDialect, model, "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tsetClauses = append(setClauses, fmt.Sprintf(%q, field))\n", sqliteDialect.quote("%s")+" = ?"))
 	content.WriteString("\t\targs = append(args, value)\n")
 	content.WriteString("\t}\n\n")
 
-	content.WriteString("\targs = append(args, pk)\n\n")
+	content.WriteString(fmt.Sprintf("\targs = append(args, %s)\n\n", generatePrimaryKeyArg(model, "pk")))
 
-	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(\"UPDATE %s SET %%s WHERE %s = ?\", strings.Join(setClauses, \", \"))\n\n", model.TableName, primaryColumn))
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(`UPDATE %s SET %%s WHERE %s = ?`, strings.Join(setClauses, \", \"))\n\n", sqliteDialect.table(model), primaryColumn))
 
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
@@ -186,13 +271,18 @@ func generateSQLitePartialUpdateMethod(model parser.Model, daoName string) strin
 }
 
 func generateSQLiteDeleteByIDMethod(model parser.Model, daoName string) string {
+	if _, ok := getSoftDeleteField(model); ok {
+		return generateSoftDeleteByIDMethod(model, daoName, sqliteDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqliteDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteByPk(ctx context.Context, pk %s) error {\n", daoName, primaryType))
-	content.WriteString(fmt.Sprintf("\tquery := `DELETE FROM %s WHERE %s = ?`\n", model.TableName, primaryColumn))
-	content.WriteString("\t_, err := dao.execContext(ctx, query, pk)\n")
+	content.WriteString(generateTenantPrelude(model, ""))
+	content.WriteString(fmt.Sprintf("\tquery := `DELETE FROM %s WHERE %s = ?%s`\n", sqliteDialect.table(model), primaryColumn, tenantCondition(model, sqliteDialect, 2)))
+	content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query, %s%s)\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
 	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
@@ -203,23 +293,25 @@ func generateSQLiteFindByIDMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
 	var columns []string
 	var scanArgs []string
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqliteDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqliteDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqliteDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindByPk(ctx context.Context, pk %s) (*%s, error) {\n", daoName, primaryType, model.Name))
+	content.WriteString(generateTenantPrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
-	content.WriteString(fmt.Sprintf("\t\tWHERE %s = ?\n", primaryColumn))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqliteDialect.table(model)))
+	content.WriteString(fmt.Sprintf("\t\tWHERE %s = ?%s%s\n", primaryColumn, andConditions(defaultConditions(model, sqliteDialect)), tenantCondition(model, sqliteDialect, 2)))
 	content.WriteString("\t`\n")
-	content.WriteString("\trow := dao.queryRowContext(ctx, query, pk)\n\n")
+	content.WriteString(fmt.Sprintf("\trow := dao.queryRowContext(ctx, query, %s%s)\n\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
 
 	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
 	content.WriteString("\terr := row.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
@@ -230,6 +322,7 @@ func generateSQLiteFindByIDMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\treturn nil, err\n")
 	content.WriteString("\t}\n\n")
 
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
 	content.WriteString("\treturn &m, nil\n")
 	content.WriteString("}\n\n")
 
@@ -238,35 +331,40 @@ func generateSQLiteFindByIDMethod(model parser.Model, daoName string) string {
 
 func generateSQLiteCreateManyMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	insertFields := getInsertFields(model)
 	var columns []string
+	var placeholders []string
 
-	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
+	for _, field := range insertFields {
+		columns = append(columns, sqliteDialect.quote(field.Column))
+		placeholders = append(placeholders, sqliteDialect.valueBind(field, "?"))
 	}
 
-	fieldCount := len(model.Fields)
-	placeholders := strings.Repeat("?,", fieldCount-1) + "?"
+	fieldCount := len(insertFields)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) CreateMany(ctx context.Context, models []*%s) error {\n", daoName, model.Name))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(models) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
+	content.WriteString(generateValidManyCall(model))
 
 	content.WriteString("\tplaceholders := make([]string, len(models))\n")
 	content.WriteString(fmt.Sprintf("\targs := make([]interface{}, 0, len(models)*%d)\n\n", fieldCount))
 
 	content.WriteString("\tfor i, model := range models {\n")
-	content.WriteString(fmt.Sprintf("\t\tplaceholders[i] = \"(%s)\"\n\n", placeholders))
+	content.WriteString(generateTenantAssignment(model, "model", "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tplaceholders[i] = \"(%s)\"\n\n", strings.Join(placeholders, ",")))
 
 	content.WriteString("\t\targs = append(args,\n")
-	for _, field := range model.Fields {
-		content.WriteString(fmt.Sprintf("\t\t\tmodel.%s,\n", field.Name))
+	for _, field := range insertFields {
+		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", generateValueArg(sqliteDialect, field, "model")))
 	}
 	content.WriteString("\t\t)\n")
 	content.WriteString("\t}\n\n")
 
 	content.WriteString("\tquery := fmt.Sprintf(`\n")
-	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", model.TableName, strings.Join(columns, ", ")))
+	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", sqliteDialect.table(model), strings.Join(columns, ", ")))
 	content.WriteString("\t\tVALUES %s\n")
 	content.WriteString("\t`, strings.Join(placeholders, \", \"))\n\n")
 
@@ -288,33 +386,64 @@ func generateSQLiteUpdateManyMethod(model parser.Model, daoName string) string {
 			primaryKeyField = field.Name
 			continue
 		}
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", field.Column))
-		args = append(args, fmt.Sprintf("model.%s", field.Name))
+		if field.IsTenant {
+			continue
+		}
+		if field.IsGenerated {
+			continue
+		}
+		if field.IsVersion {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", sqliteDialect.quote(field.Column), sqliteDialect.quote(field.Column)))
+			continue
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", sqliteDialect.quote(field.Column), sqliteDialect.valueBind(field, sqliteDialect.bind(len(args)+1))))
+		args = append(args, generateValueArg(sqliteDialect, field, "model"))
 	}
 
-	args = append(args, fmt.Sprintf("model.%s", primaryKeyField))
-	whereClause := fmt.Sprintf("WHERE %s = ?", getPrimaryColumn(model))
+	args = append(args, generatePrimaryKeyArg(model, "model."+primaryKeyField))
+	whereClause := fmt.Sprintf("WHERE %s = %s", sqliteDialect.quote(getPrimaryColumn(model)), sqliteDialect.bind(len(args)))
+
+	if _, ok := getTenantField(model); ok {
+		args = append(args, "tenantID")
+		whereClause += tenantCondition(model, sqliteDialect, len(args))
+	}
+
+	versionField, versioned := getVersionField(model)
+	if versioned {
+		args = append(args, fmt.Sprintf("model.%s", versionField.Name))
+		whereClause += fmt.Sprintf(" AND %s = %s", sqliteDialect.quote(versionField.Column), sqliteDialect.bind(len(args)))
+	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) UpdateMany(ctx context.Context, models []*%s) error {\n", daoName, model.Name))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(models) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
+	content.WriteString(generateValidManyCall(model))
 
 	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", sqliteDialect.table(model)))
 	content.WriteString(fmt.Sprintf("\t\tSET %s\n", strings.Join(setClauses, ",\n\t\t\t")))
 	content.WriteString(fmt.Sprintf("\t\t%s\n", whereClause))
 	content.WriteString("\t`\n\n")
 
 	content.WriteString("\tfor _, model := range models {\n")
-	content.WriteString("\t\t_, err := dao.execContext(ctx, query,\n")
+	if versioned {
+		content.WriteString("\t\tresult, err := dao.execContext(ctx, query,\n")
+	} else {
+		content.WriteString("\t\t_, err := dao.execContext(ctx, query,\n")
+	}
 	for _, arg := range args {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
 	}
 	content.WriteString("\t\t)\n")
-	content.WriteString("\t\tif err != nil {\n")
-	content.WriteString("\t\t\treturn err\n")
-	content.WriteString("\t\t}\n")
+	if versioned {
+		content.WriteString(generateVersionCheck(versionField, "model", "\t\t"))
+	} else {
+		content.WriteString("\t\tif err != nil {\n")
+		content.WriteString("\t\t\treturn err\n")
+		content.WriteString("\t\t}\n")
+	}
 	content.WriteString("\t}\n\n")
 
 	content.WriteString("\treturn nil\n")
@@ -324,11 +453,16 @@ func generateSQLiteUpdateManyMethod(model parser.Model, daoName string) string {
 }
 
 func generateSQLiteDeleteManyByIDsMethod(model parser.Model, daoName string) string {
+	if _, ok := getSoftDeleteField(model); ok {
+		return generateSoftDeleteManyByIDsMethod(model, daoName, sqliteDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqliteDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteManyByPks(ctx context.Context, pks []%s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(pks) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
@@ -336,10 +470,14 @@ func generateSQLiteDeleteManyByIDsMethod(model parser.Model, daoName string) str
 	content.WriteString("\tplaceholders := strings.Repeat(\"?,\", len(pks)-1) + \"?\"\n")
 	content.WriteString("\targs := make([]interface{}, len(pks))\n")
 	content.WriteString("\tfor i, pk := range pks {\n")
-	content.WriteString("\t\targs[i] = pk\n")
+	content.WriteString(fmt.Sprintf("\t\targs[i] = %s\n", generatePrimaryKeyArg(model, "pk")))
 	content.WriteString("\t}\n\n")
 
-	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(\"DELETE FROM %s WHERE %s IN (%%s)\", placeholders)\n", model.TableName, primaryColumn))
+	tenantCond, tenantSprintfArg := tenantDynamicCondition(model, sqliteDialect)
+	if tenantCond != "" {
+		content.WriteString("\targs = append(args, tenantID)\n")
+	}
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(`DELETE FROM %s WHERE %s IN (%%s)%s`, placeholders%s)\n", sqliteDialect.table(model), primaryColumn, tenantCond, tenantSprintfArg))
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
@@ -349,23 +487,24 @@ func generateSQLiteDeleteManyByIDsMethod(model parser.Model, daoName string) str
 
 func generateSQLiteFindOneMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, sqliteDialect)
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqliteDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqliteDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*%s, error) {\n", daoName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqliteDialect.table(model)))
+	content.WriteString(generateWhereConditions(conditions))
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -374,6 +513,7 @@ func generateSQLiteFindOneMethod(model parser.Model, daoName string) string {
 	content.WriteString("\trow := dao.queryRowContext(ctx, query, args...)\n\n")
 
 	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
 	content.WriteString("\terr := row.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
@@ -384,31 +524,32 @@ func generateSQLiteFindOneMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\treturn nil, err\n")
 	content.WriteString("\t}\n\n")
 
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
 	content.WriteString("\treturn &m, nil\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
 }
 
-func generateSQLiteFindAllMethod(model parser.Model, daoName string) string {
+func generateSQLiteFindAllMethod(model parser.Model, daoName, methodName string, conditions []string) string {
 	var content strings.Builder
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqliteDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqliteDialect, field, "m"))
 	}
 
-	content.WriteString(fmt.Sprintf("func (dao *%s) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, methodName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqliteDialect.table(model)))
+	content.WriteString(generateWhereConditions(conditions))
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -423,6 +564,7 @@ func generateSQLiteFindAllMethod(model parser.Model, daoName string) string {
 	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
 	content.WriteString("\tfor rows.Next() {\n")
 	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
 	content.WriteString("\t\terr := rows.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
@@ -431,6 +573,7 @@ func generateSQLiteFindAllMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\tif err != nil {\n")
 	content.WriteString("\t\t\treturn nil, err\n")
 	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
 	content.WriteString("\t\tmodels = append(models, &m)\n")
 	content.WriteString("\t}\n\n")
 
@@ -446,23 +589,24 @@ func generateSQLiteFindAllMethod(model parser.Model, daoName string) string {
 
 func generateSQLiteFindPaginatedMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, sqliteDialect)
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqliteDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqliteDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqliteDialect.table(model)))
+	content.WriteString(generateWhereConditions(conditions))
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -479,6 +623,7 @@ func generateSQLiteFindPaginatedMethod(model parser.Model, daoName string) strin
 	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
 	content.WriteString("\tfor rows.Next() {\n")
 	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
 	content.WriteString("\t\terr := rows.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
@@ -487,6 +632,7 @@ func generateSQLiteFindPaginatedMethod(model parser.Model, daoName string) strin
 	content.WriteString("\t\tif err != nil {\n")
 	content.WriteString("\t\t\treturn nil, err\n")
 	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
 	content.WriteString("\t\tmodels = append(models, &m)\n")
 	content.WriteString("\t}\n\n")
 
@@ -502,13 +648,12 @@ func generateSQLiteFindPaginatedMethod(model parser.Model, daoName string) strin
 
 func generateSQLiteCountMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, sqliteDialect)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {\n", daoName))
-	content.WriteString(fmt.Sprintf("\tquery := \"SELECT COUNT(*) FROM %s\"\n\n", model.TableName))
-
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateScopeWherePrelude(model, "0, "))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", sqliteDialect.literal(fmt.Sprintf("SELECT COUNT(*) FROM %s%s", sqliteDialect.table(model), whereConditions(conditions)))))
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\trow := dao.queryRowContext(ctx, query, args...)\n\n")
 
@@ -8,14 +8,25 @@ import (
 )
 
 func GenerateSQLServerDAO(model parser.Model) (string, error) {
+	queries, err := analyzeQueries(model)
+	if err != nil {
+		return "", err
+	}
+
 	imports := []string{
 		"context",
 		"database/sql",
+		"errors",
 		"fmt",
 		"strings",
 		model.ImportPath,
 	}
 
+	if _, ok := getSoftDeleteField(model); ok || hasQueue(model) || aggregatesUsePackage(model, "time", sqlserverDialect) || queriesUsePackage(queries, "time") {
+		imports = append(imports, "time")
+	}
+	imports = append(imports, converterImports(model)...)
+
 	var content strings.Builder
 
 	content.WriteString(fmt.Sprintf("package %s\n\n", "sqlserver"))
@@ -26,18 +37,15 @@ func GenerateSQLServerDAO(model parser.Model) (string, error) {
 	content.WriteString(")\n\n")
 
 	content.WriteString(fmt.Sprintf("type %s = %s.%s\n\n", model.Name, model.Package, model.Name))
+	content.WriteString(generateJoinRowTypes(model))
 
 	daoName := fmt.Sprintf("%sDAO", model.Name)
 
-	content.WriteString(fmt.Sprintf("type %s struct {\n", daoName))
-	content.WriteString("\tdb *sql.DB\n")
-	content.WriteString("}\n\n")
-
-	content.WriteString(fmt.Sprintf("func New%s(db *sql.DB) *%s {\n", daoName, daoName))
-	content.WriteString(fmt.Sprintf("\treturn &%s{db: db}\n", daoName))
-	content.WriteString("}\n\n")
+	content.WriteString(generateDAOStruct(model, daoName))
 
 	content.WriteString(generateSQLServerHelperMethods(daoName))
+	content.WriteString(generateTenantMethods(model, daoName, sqlserverDialect))
+	content.WriteString(generateValidMethod(model, daoName))
 	content.WriteString(generateSQLServerCreateMethod(model, daoName))
 	content.WriteString(generateSQLServerUpdateMethod(model, daoName))
 	content.WriteString(generateSQLServerPartialUpdateMethod(model, daoName))
@@ -46,10 +54,23 @@ func GenerateSQLServerDAO(model parser.Model) (string, error) {
 	content.WriteString(generateSQLServerCreateManyMethod(model, daoName))
 	content.WriteString(generateSQLServerUpdateManyMethod(model, daoName))
 	content.WriteString(generateSQLServerDeleteManyByIDsMethod(model, daoName))
+	content.WriteString(generateBulkMethods(model, daoName, sqlserverDialect))
 	content.WriteString(generateSQLServerFindOneMethod(model, daoName))
-	content.WriteString(generateSQLServerFindAllMethod(model, daoName))
+	content.WriteString(generateSQLServerFindAllMethod(model, daoName, "FindAll", defaultConditions(model, sqlserverDialect)))
+	content.WriteString(generateFindAllColumnsMethod(model, daoName, sqlserverDialect))
+	content.WriteString(generateLockMethods(model, daoName, sqlserverDialect))
+	content.WriteString(generateQueueMethods(model, daoName, sqlserverDialect))
 	content.WriteString(generateSQLServerFindPaginatedMethod(model, daoName))
 	content.WriteString(generateSQLServerCountMethod(model, daoName))
+	content.WriteString(generateExistsMethods(model, daoName, sqlserverDialect))
+	content.WriteString(generateAggregateMethods(model, daoName, sqlserverDialect))
+	content.WriteString(generateSoftDeleteMethods(model, daoName, sqlserverDialect, generateSQLServerFindAllMethod))
+	relationMethods, err := generateRelationMethods(model, daoName, sqlserverDialect)
+	if err != nil {
+		return "", err
+	}
+	content.WriteString(relationMethods)
+	content.WriteString(generateQueryMethods(model, queries, daoName, sqlserverDialect))
 	content.WriteString(generateSQLServerWithTransactionMethod(daoName))
 
 	return content.String(), nil
@@ -95,26 +116,41 @@ func generateSQLServerCreateMethod(model parser.Model, daoName string) string {
 	var placeholders []string
 	var args []string
 
-	for i, field := range model.Fields {
-		columns = append(columns, field.Column)
-		placeholders = append(placeholders, fmt.Sprintf("@p%d", i+1))
-		args = append(args, fmt.Sprintf("m.%s", field.Name))
+	for _, field := range getInsertFields(model) {
+		columns = append(columns, sqlserverDialect.quote(field.Column))
+		placeholders = append(placeholders, sqlserverDialect.valueBind(field, sqlserverDialect.bind(len(placeholders)+1)))
+		args = append(args, generateValueArg(sqlserverDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Create(ctx context.Context, m *%s) error {\n", daoName, model.Name))
+	content.WriteString(generateValidCall(model))
+	content.WriteString(generateTenantPrelude(model, ""))
+	if assignment := generateTenantAssignment(model, "m", "\t"); assignment != "" {
+		content.WriteString(assignment + "\n")
+	}
 	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", model.TableName, strings.Join(columns, ", ")))
+	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", sqlserverDialect.table(model), strings.Join(columns, ", ")))
+	if output := outputClause(model, sqlserverDialect); output != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", output))
+	}
 	content.WriteString(fmt.Sprintf("\t\tVALUES (%s)\n", strings.Join(placeholders, ", ")))
+	if returning := returningClause(model, sqlserverDialect, len(args)); returning != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", returning))
+	}
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\t_, err := dao.execContext(\n")
-	content.WriteString("\t\tctx,\n")
-	content.WriteString("\t\tquery,\n")
-	for _, arg := range args {
-		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	if len(getGeneratedFields(model)) > 0 {
+		content.WriteString(generateReadBackWrite(model, sqlserverDialect, args, false))
+	} else {
+		content.WriteString("\t_, err := dao.execContext(\n")
+		content.WriteString("\t\tctx,\n")
+		content.WriteString("\t\tquery,\n")
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n\n")
+		content.WriteString("\treturn err\n")
 	}
-	content.WriteString("\t)\n\n")
-	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
@@ -126,39 +162,85 @@ func generateSQLServerUpdateMethod(model parser.Model, daoName string) string {
 	var args []string
 	var primaryKeyField string
 
-	for i, field := range model.Fields {
+	for _, field := range model.Fields {
 		if field.IsPrimary {
 			primaryKeyField = field.Name
 			continue
 		}
-		setClauses = append(setClauses, fmt.Sprintf("%s = @p%d", field.Column, i))
-		args = append(args, fmt.Sprintf("m.%s", field.Name))
+		if field.IsTenant {
+			continue
+		}
+		if field.IsGenerated {
+			continue
+		}
+		if field.IsVersion {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", sqlserverDialect.quote(field.Column), sqlserverDialect.quote(field.Column)))
+			continue
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", sqlserverDialect.quote(field.Column), sqlserverDialect.valueBind(field, sqlserverDialect.bind(len(args)+1))))
+		args = append(args, generateValueArg(sqlserverDialect, field, "m"))
+	}
+
+	args = append(args, generatePrimaryKeyArg(model, "m."+primaryKeyField))
+	whereClause := fmt.Sprintf("WHERE %s = %s", sqlserverDialect.quote(getPrimaryColumn(model)), sqlserverDialect.bind(len(args)))
+
+	if _, ok := getTenantField(model); ok {
+		args = append(args, "tenantID")
+		whereClause += tenantCondition(model, sqlserverDialect, len(args))
 	}
 
-	args = append(args, fmt.Sprintf("m.%s", primaryKeyField))
-	whereClause := fmt.Sprintf("WHERE %s = @p%d", getPrimaryColumn(model), len(args))
+	versionField, versioned := getVersionField(model)
+	if versioned {
+		args = append(args, fmt.Sprintf("m.%s", versionField.Name))
+		whereClause += fmt.Sprintf(" AND %s = %s", sqlserverDialect.quote(versionField.Column), sqlserverDialect.bind(len(args)))
+	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Update(ctx context.Context, m *%s) error {\n", daoName, model.Name))
+	content.WriteString(generateValidCall(model))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", sqlserverDialect.table(model)))
 	content.WriteString(fmt.Sprintf("\t\tSET %s\n", strings.Join(setClauses, ",\n\t\t\t")))
+	if output := outputClause(model, sqlserverDialect); output != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", output))
+	}
 	content.WriteString(fmt.Sprintf("\t\t%s\n", whereClause))
+	if returning := returningClause(model, sqlserverDialect, len(args)); returning != "" {
+		content.WriteString(fmt.Sprintf("\t\t%s\n", returning))
+	}
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
-	for _, arg := range args {
-		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+	if len(getGeneratedFields(model)) > 0 {
+		content.WriteString(generateReadBackWrite(model, sqlserverDialect, args, versioned))
+	} else {
+		if versioned {
+			content.WriteString("\tresult, err := dao.execContext(ctx, query,\n")
+		} else {
+			content.WriteString("\t_, err := dao.execContext(ctx, query,\n")
+		}
+		for _, arg := range args {
+			content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
+		}
+		content.WriteString("\t)\n")
+		if versioned {
+			content.WriteString(generateVersionCheck(versionField, "m", "\t"))
+			content.WriteString("\treturn nil\n")
+		} else {
+			content.WriteString("\treturn err\n")
+		}
 	}
-	content.WriteString("\t)\n")
-	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
 }
 
 func generateSQLServerPartialUpdateMethod(model parser.Model, daoName string) string {
+	if isUpdateGuarded(model) {
+		return generateGuardedPartialUpdateMethod(model, daoName, sqlserverDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqlserverDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) PartialUpdate(ctx context.Context, pk %s, fields map[string]interface{}) error {\n", daoName, primaryType))
@@ -171,14 +253,17 @@ func generateSQLServerPartialUpdateMethod(model parser.Model, daoName string) st
 	content.WriteString("\ti := 1\n\n")
 
 	content.WriteString("\tfor field, value := range fields {\n")
-	content.WriteString("\t\tsetClauses = append(setClauses, fmt.Sprintf(\"%s = @p%d\", field, i))\n")
+	content.WriteString(generateColumnCheck(model, "field", "", "\t\t"))
+	content.WriteString(generateValidFieldCall(model, "", "\t\t"))
+	content.WriteString(generateFieldValueConversion(sqlserverDialect, model, "\t\t"))
+	content.WriteString(fmt.Sprintf("\t\tsetClauses = append(setClauses, fmt.Sprintf(%q, field, i))\n", sqlserverDialect.quote("%s")+" = @p%d"))
 	content.WriteString("\t\targs = append(args, value)\n")
 	content.WriteString("\t\ti++\n")
 	content.WriteString("\t}\n\n")
 
-	content.WriteString("\targs = append(args, pk)\n\n")
+	content.WriteString(fmt.Sprintf("\targs = append(args, %s)\n\n", generatePrimaryKeyArg(model, "pk")))
 
-	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(`UPDATE %s SET %%s WHERE %s = @p%%d`, strings.Join(setClauses, \", \"), i)\n\n", model.TableName, primaryColumn))
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(`UPDATE %s SET %%s WHERE %s = @p%%d`, strings.Join(setClauses, \", \"), i)\n\n", sqlserverDialect.table(model), primaryColumn))
 
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
@@ -188,13 +273,18 @@ func generateSQLServerPartialUpdateMethod(model parser.Model, daoName string) st
 }
 
 func generateSQLServerDeleteByIDMethod(model parser.Model, daoName string) string {
+	if _, ok := getSoftDeleteField(model); ok {
+		return generateSoftDeleteByIDMethod(model, daoName, sqlserverDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqlserverDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteByPk(ctx context.Context, pk %s) error {\n", daoName, primaryType))
-	content.WriteString(fmt.Sprintf("\tquery := `DELETE FROM %s WHERE %s = @p1`\n", model.TableName, primaryColumn))
-	content.WriteString("\t_, err := dao.execContext(ctx, query, pk)\n")
+	content.WriteString(generateTenantPrelude(model, ""))
+	content.WriteString(fmt.Sprintf("\tquery := `DELETE FROM %s WHERE %s = @p1%s`\n", sqlserverDialect.table(model), primaryColumn, tenantCondition(model, sqlserverDialect, 2)))
+	content.WriteString(fmt.Sprintf("\t_, err := dao.execContext(ctx, query, %s%s)\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
 	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
 
@@ -205,23 +295,25 @@ func generateSQLServerFindByIDMethod(model parser.Model, daoName string) string
 	var content strings.Builder
 	var columns []string
 	var scanArgs []string
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqlserverDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqlserverDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqlserverDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindByPk(ctx context.Context, pk %s) (*%s, error) {\n", daoName, primaryType, model.Name))
+	content.WriteString(generateTenantPrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
-	content.WriteString(fmt.Sprintf("\t\tWHERE %s = @p1\n", primaryColumn))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqlserverDialect.table(model)))
+	content.WriteString(fmt.Sprintf("\t\tWHERE %s = @p1%s%s\n", primaryColumn, andConditions(defaultConditions(model, sqlserverDialect)), tenantCondition(model, sqlserverDialect, 2)))
 	content.WriteString("\t`\n")
-	content.WriteString("\trow := dao.queryRowContext(ctx, query, pk)\n\n")
+	content.WriteString(fmt.Sprintf("\trow := dao.queryRowContext(ctx, query, %s%s)\n\n", generatePrimaryKeyArg(model, "pk"), tenantArg(model)))
 
 	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
 	content.WriteString("\terr := row.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
@@ -232,6 +324,7 @@ func generateSQLServerFindByIDMethod(model parser.Model, daoName string) string
 	content.WriteString("\t\treturn nil, err\n")
 	content.WriteString("\t}\n\n")
 
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
 	content.WriteString("\treturn &m, nil\n")
 	content.WriteString("}\n\n")
 
@@ -240,26 +333,30 @@ func generateSQLServerFindByIDMethod(model parser.Model, daoName string) string
 
 func generateSQLServerCreateManyMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	insertFields := getInsertFields(model)
 	var columns []string
 
-	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
+	for _, field := range insertFields {
+		columns = append(columns, sqlserverDialect.quote(field.Column))
 	}
 
-	fieldCount := len(model.Fields)
+	fieldCount := len(insertFields)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) CreateMany(ctx context.Context, models []*%s) error {\n", daoName, model.Name))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(models) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
+	content.WriteString(generateValidManyCall(model))
 
 	content.WriteString("\tplaceholders := make([]string, len(models))\n")
 	content.WriteString(fmt.Sprintf("\targs := make([]interface{}, 0, len(models)*%d)\n\n", fieldCount))
 
 	content.WriteString("\tfor i, model := range models {\n")
+	content.WriteString(generateTenantAssignment(model, "model", "\t\t"))
 	placeholderParts := make([]string, fieldCount)
-	for j := 0; j < fieldCount; j++ {
-		placeholderParts[j] = fmt.Sprintf("@p%%d")
+	for j, field := range insertFields {
+		placeholderParts[j] = sqlserverDialect.valueBind(field, "@p%d")
 	}
 
 	content.WriteString(fmt.Sprintf("\t\tplaceholders[i] = fmt.Sprintf(\"(%s)\",\n", strings.Join(placeholderParts, ", ")))
@@ -272,14 +369,14 @@ func generateSQLServerCreateManyMethod(model parser.Model, daoName string) strin
 	content.WriteString(")\n\n")
 
 	content.WriteString("\t\targs = append(args,\n")
-	for _, field := range model.Fields {
-		content.WriteString(fmt.Sprintf("\t\t\tmodel.%s,\n", field.Name))
+	for _, field := range insertFields {
+		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", generateValueArg(sqlserverDialect, field, "model")))
 	}
 	content.WriteString("\t\t)\n")
 	content.WriteString("\t}\n\n")
 
 	content.WriteString("\tquery := fmt.Sprintf(`\n")
-	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", model.TableName, strings.Join(columns, ", ")))
+	content.WriteString(fmt.Sprintf("\t\tINSERT INTO %s (%s)\n", sqlserverDialect.table(model), strings.Join(columns, ", ")))
 	content.WriteString("\t\tVALUES %s\n")
 	content.WriteString("\t`, strings.Join(placeholders, \", \"))\n\n")
 
@@ -296,38 +393,69 @@ func generateSQLServerUpdateManyMethod(model parser.Model, daoName string) strin
 	var args []string
 	var primaryKeyField string
 
-	for i, field := range model.Fields {
+	for _, field := range model.Fields {
 		if field.IsPrimary {
 			primaryKeyField = field.Name
 			continue
 		}
-		setClauses = append(setClauses, fmt.Sprintf("%s = @p%d", field.Column, i))
-		args = append(args, fmt.Sprintf("model.%s", field.Name))
+		if field.IsTenant {
+			continue
+		}
+		if field.IsGenerated {
+			continue
+		}
+		if field.IsVersion {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", sqlserverDialect.quote(field.Column), sqlserverDialect.quote(field.Column)))
+			continue
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s", sqlserverDialect.quote(field.Column), sqlserverDialect.valueBind(field, sqlserverDialect.bind(len(args)+1))))
+		args = append(args, generateValueArg(sqlserverDialect, field, "model"))
 	}
 
-	args = append(args, fmt.Sprintf("model.%s", primaryKeyField))
-	whereClause := fmt.Sprintf("WHERE %s = @p%d", getPrimaryColumn(model), len(args))
+	args = append(args, generatePrimaryKeyArg(model, "model."+primaryKeyField))
+	whereClause := fmt.Sprintf("WHERE %s = %s", sqlserverDialect.quote(getPrimaryColumn(model)), sqlserverDialect.bind(len(args)))
+
+	if _, ok := getTenantField(model); ok {
+		args = append(args, "tenantID")
+		whereClause += tenantCondition(model, sqlserverDialect, len(args))
+	}
+
+	versionField, versioned := getVersionField(model)
+	if versioned {
+		args = append(args, fmt.Sprintf("model.%s", versionField.Name))
+		whereClause += fmt.Sprintf(" AND %s = %s", sqlserverDialect.quote(versionField.Column), sqlserverDialect.bind(len(args)))
+	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) UpdateMany(ctx context.Context, models []*%s) error {\n", daoName, model.Name))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(models) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
+	content.WriteString(generateValidManyCall(model))
 
 	content.WriteString("\tquery := `\n")
-	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tUPDATE %s\n", sqlserverDialect.table(model)))
 	content.WriteString(fmt.Sprintf("\t\tSET %s\n", strings.Join(setClauses, ",\n\t\t\t")))
 	content.WriteString(fmt.Sprintf("\t\t%s\n", whereClause))
 	content.WriteString("\t`\n\n")
 
 	content.WriteString("\tfor _, model := range models {\n")
-	content.WriteString("\t\t_, err := dao.execContext(ctx, query,\n")
+	if versioned {
+		content.WriteString("\t\tresult, err := dao.execContext(ctx, query,\n")
+	} else {
+		content.WriteString("\t\t_, err := dao.execContext(ctx, query,\n")
+	}
 	for _, arg := range args {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
 	}
 	content.WriteString("\t\t)\n")
-	content.WriteString("\t\tif err != nil {\n")
-	content.WriteString("\t\t\treturn err\n")
-	content.WriteString("\t\t}\n")
+	if versioned {
+		content.WriteString(generateVersionCheck(versionField, "model", "\t\t"))
+	} else {
+		content.WriteString("\t\tif err != nil {\n")
+		content.WriteString("\t\t\treturn err\n")
+		content.WriteString("\t\t}\n")
+	}
 	content.WriteString("\t}\n\n")
 
 	content.WriteString("\treturn nil\n")
@@ -337,11 +465,16 @@ func generateSQLServerUpdateManyMethod(model parser.Model, daoName string) strin
 }
 
 func generateSQLServerDeleteManyByIDsMethod(model parser.Model, daoName string) string {
+	if _, ok := getSoftDeleteField(model); ok {
+		return generateSoftDeleteManyByIDsMethod(model, daoName, sqlserverDialect)
+	}
+
 	var content strings.Builder
-	primaryColumn := getPrimaryColumn(model)
+	primaryColumn := sqlserverDialect.quote(getPrimaryColumn(model))
 	primaryType := getPrimaryType(model)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) DeleteManyByPks(ctx context.Context, pks []%s) error {\n", daoName, primaryType))
+	content.WriteString(generateTenantPrelude(model, ""))
 	content.WriteString("\tif len(pks) == 0 {\n")
 	content.WriteString("\t\treturn nil\n")
 	content.WriteString("\t}\n\n")
@@ -350,10 +483,14 @@ func generateSQLServerDeleteManyByIDsMethod(model parser.Model, daoName string)
 	content.WriteString("\targs := make([]interface{}, len(pks))\n")
 	content.WriteString("\tfor i, pk := range pks {\n")
 	content.WriteString("\t\tplaceholders[i] = fmt.Sprintf(\"@p%d\", i+1)\n")
-	content.WriteString("\t\targs[i] = pk\n")
+	content.WriteString(fmt.Sprintf("\t\targs[i] = %s\n", generatePrimaryKeyArg(model, "pk")))
 	content.WriteString("\t}\n\n")
 
-	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(`DELETE FROM %s WHERE %s IN (%%s)`, strings.Join(placeholders, \",\"))\n", model.TableName, primaryColumn))
+	tenantCond, tenantSprintfArg := tenantDynamicCondition(model, sqlserverDialect)
+	if tenantCond != "" {
+		content.WriteString("\targs = append(args, tenantID)\n")
+	}
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(`DELETE FROM %s WHERE %s IN (%%s)%s`, strings.Join(placeholders, \",\")%s)\n", sqlserverDialect.table(model), primaryColumn, tenantCond, tenantSprintfArg))
 	content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
 	content.WriteString("\treturn err\n")
 	content.WriteString("}\n\n")
@@ -363,23 +500,24 @@ func generateSQLServerDeleteManyByIDsMethod(model parser.Model, daoName string)
 
 func generateSQLServerFindOneMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, sqlserverDialect)
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqlserverDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqlserverDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindOne(ctx context.Context, where string, sort string, args ...interface{}) (*%s, error) {\n", daoName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqlserverDialect.table(model)))
+	content.WriteString(generateWhereConditions(conditions))
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -388,6 +526,7 @@ func generateSQLServerFindOneMethod(model parser.Model, daoName string) string {
 	content.WriteString("\trow := dao.queryRowContext(ctx, query, args...)\n\n")
 
 	content.WriteString(fmt.Sprintf("\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t"))
 	content.WriteString("\terr := row.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t%s,\n", arg))
@@ -398,31 +537,32 @@ func generateSQLServerFindOneMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\treturn nil, err\n")
 	content.WriteString("\t}\n\n")
 
+	content.WriteString(generateNullScanAssignments(model, "m", "\t"))
 	content.WriteString("\treturn &m, nil\n")
 	content.WriteString("}\n\n")
 
 	return content.String()
 }
 
-func generateSQLServerFindAllMethod(model parser.Model, daoName string) string {
+func generateSQLServerFindAllMethod(model parser.Model, daoName, methodName string, conditions []string) string {
 	var content strings.Builder
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqlserverDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqlserverDialect, field, "m"))
 	}
 
-	content.WriteString(fmt.Sprintf("func (dao *%s) FindAll(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString(fmt.Sprintf("func (dao *%s) %s(ctx context.Context, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, methodName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqlserverDialect.table(model)))
+	content.WriteString(generateWhereConditions(conditions))
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -437,6 +577,7 @@ func generateSQLServerFindAllMethod(model parser.Model, daoName string) string {
 	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
 	content.WriteString("\tfor rows.Next() {\n")
 	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
 	content.WriteString("\t\terr := rows.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
@@ -445,6 +586,7 @@ func generateSQLServerFindAllMethod(model parser.Model, daoName string) string {
 	content.WriteString("\t\tif err != nil {\n")
 	content.WriteString("\t\t\treturn nil, err\n")
 	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
 	content.WriteString("\t\tmodels = append(models, &m)\n")
 	content.WriteString("\t}\n\n")
 
@@ -460,23 +602,24 @@ func generateSQLServerFindAllMethod(model parser.Model, daoName string) string {
 
 func generateSQLServerFindPaginatedMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, sqlserverDialect)
 	var columns []string
 	var scanArgs []string
 
 	for _, field := range model.Fields {
-		columns = append(columns, field.Column)
-		scanArgs = append(scanArgs, fmt.Sprintf("&m.%s", field.Name))
+		columns = append(columns, sqlserverDialect.quote(field.Column))
+		scanArgs = append(scanArgs, generateScanArg(sqlserverDialect, field, "m"))
 	}
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) FindPaginated(ctx context.Context, limit, offset int, where string, sort string, args ...interface{}) ([]*%s, error) {\n", daoName, model.Name))
+	content.WriteString(generateScopeWherePrelude(model, "nil, "))
 	content.WriteString("\tquery := `\n")
 	content.WriteString(fmt.Sprintf("\t\tSELECT %s\n", strings.Join(columns, ", ")))
-	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", model.TableName))
+	content.WriteString(fmt.Sprintf("\t\tFROM %s\n", sqlserverDialect.table(model)))
+	content.WriteString(generateWhereConditions(conditions))
 	content.WriteString("\t`\n\n")
 
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\tif sort != \"\" {\n")
 	content.WriteString("\t\tquery += \" ORDER BY \" + sort\n")
@@ -495,6 +638,7 @@ func generateSQLServerFindPaginatedMethod(model parser.Model, daoName string) st
 	content.WriteString(fmt.Sprintf("\tvar models []*%s\n", model.Name))
 	content.WriteString("\tfor rows.Next() {\n")
 	content.WriteString(fmt.Sprintf("\t\tvar m %s\n", model.Name))
+	content.WriteString(generateNullScanDeclarations(model, "\t\t"))
 	content.WriteString("\t\terr := rows.Scan(\n")
 	for _, arg := range scanArgs {
 		content.WriteString(fmt.Sprintf("\t\t\t%s,\n", arg))
@@ -503,6 +647,7 @@ func generateSQLServerFindPaginatedMethod(model parser.Model, daoName string) st
 	content.WriteString("\t\tif err != nil {\n")
 	content.WriteString("\t\t\treturn nil, err\n")
 	content.WriteString("\t\t}\n")
+	content.WriteString(generateNullScanAssignments(model, "m", "\t\t"))
 	content.WriteString("\t\tmodels = append(models, &m)\n")
 	content.WriteString("\t}\n\n")
 
@@ -518,13 +663,12 @@ func generateSQLServerFindPaginatedMethod(model parser.Model, daoName string) st
 
 func generateSQLServerCountMethod(model parser.Model, daoName string) string {
 	var content strings.Builder
+	conditions := defaultConditions(model, sqlserverDialect)
 
 	content.WriteString(fmt.Sprintf("func (dao *%s) Count(ctx context.Context, where string, args ...interface{}) (int64, error) {\n", daoName))
-	content.WriteString(fmt.Sprintf("\tquery := \"SELECT COUNT(*) FROM %s\"\n\n", model.TableName))
-
-	content.WriteString("\tif where != \"\" {\n")
-	content.WriteString("\t\tquery += \" WHERE \" + where\n")
-	content.WriteString("\t}\n\n")
+	content.WriteString(generateScopeWherePrelude(model, "0, "))
+	content.WriteString(fmt.Sprintf("\tquery := %s\n\n", sqlserverDialect.literal(fmt.Sprintf("SELECT COUNT(*) FROM %s%s", sqlserverDialect.table(model), whereConditions(conditions)))))
+	content.WriteString(generateWhereAppend("query", conditions))
 
 	content.WriteString("\trow := dao.queryRowContext(ctx, query, args...)\n\n")
 
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+func getTenantField(model parser.Model) (parser.Field, bool) {
+	for _, field := range model.Fields {
+		if field.IsTenant {
+			return field, true
+		}
+	}
+	return parser.Field{}, false
+}
+
+// generateTenantMethods generates the DAO methods resolving the tenant of the
+// current context for models tagged with tenant.
+func generateTenantMethods(model parser.Model, daoName string, d dialect) string {
+	field, ok := getTenantField(model)
+	if !ok {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) tenantID(ctx context.Context) (%s, bool) {\n", daoName, field.Type))
+	content.WriteString(fmt.Sprintf("\tvar tenantID %s\n", field.Type))
+	content.WriteString("\tok := tenantValue(ctx, &tenantID)\n")
+	content.WriteString("\treturn tenantID, ok\n")
+	content.WriteString("}\n\n")
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) scopeWhere(ctx context.Context, where string, args []interface{}) (string, []interface{}, bool) {\n", daoName))
+	content.WriteString("\ttenantID, ok := dao.tenantID(ctx)\n")
+	content.WriteString("\tif !ok {\n")
+	content.WriteString("\t\treturn \"\", nil, false\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\targs = append(args, tenantID)\n")
+	if d.isPositional() {
+		content.WriteString(fmt.Sprintf("\tcondition := %q\n", fmt.Sprintf("%s = %s", d.quote(field.Column), d.placeholder)))
+	} else {
+		content.WriteString(fmt.Sprintf("\tcondition := fmt.Sprintf(%q, len(args))\n", fmt.Sprintf("%s = %s", d.quote(field.Column), d.placeholder)))
+	}
+	content.WriteString("\tif where != \"\" {\n")
+	content.WriteString("\t\tcondition = \"(\" + where + \") AND \" + condition\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn condition, args, true\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
+
+// generateTenantPrelude resolves tenantID at the start of a method, returning
+// ErrMissingTenant preceded by zeroResults when the context has no tenant.
+func generateTenantPrelude(model parser.Model, zeroResults string) string {
+	if _, ok := getTenantField(model); !ok {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString("\ttenantID, ok := dao.tenantID(ctx)\n")
+	content.WriteString("\tif !ok {\n")
+	content.WriteString(fmt.Sprintf("\t\treturn %sErrMissingTenant\n", zeroResults))
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateScopeWherePrelude scopes the caller provided where and args to the
+// tenant of the context.
+func generateScopeWherePrelude(model parser.Model, zeroResults string) string {
+	if _, ok := getTenantField(model); !ok {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString("\twhere, args, ok := dao.scopeWhere(ctx, where, args)\n")
+	content.WriteString("\tif !ok {\n")
+	content.WriteString(fmt.Sprintf("\t\treturn %sErrMissingTenant\n", zeroResults))
+	content.WriteString("\t}\n\n")
+
+	return content.String()
+}
+
+// generateTenantAssignment stamps the tenant of the context on target before it is written.
+func generateTenantAssignment(model parser.Model, target, indent string) string {
+	field, ok := getTenantField(model)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s%s.%s = tenantID\n", indent, target, field.Name)
+}
+
+// tenantCondition renders the tenant predicate bound to the n-th placeholder.
+func tenantCondition(model parser.Model, d dialect, n int) string {
+	field, ok := getTenantField(model)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" AND %s = %s", d.quote(field.Column), d.bind(n))
+}
+
+// tenantArg renders the trailing tenantID argument matching tenantCondition.
+func tenantArg(model parser.Model) string {
+	if _, ok := getTenantField(model); !ok {
+		return ""
+	}
+	return ", tenantID"
+}
+
+// tenantDynamicCondition renders the tenant predicate for a query built with
+// fmt.Sprintf, along with the Sprintf argument numbering its placeholder after
+// the args bound so far.
+func tenantDynamicCondition(model parser.Model, d dialect) (string, string) {
+	field, ok := getTenantField(model)
+	if !ok {
+		return "", ""
+	}
+	verb, arg := d.sprintfBind("len(args)")
+	return fmt.Sprintf(" AND %s = %s", d.quote(field.Column), verb), arg
+}
@@ -0,0 +1,274 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+// generateValueArg renders the query argument writing field of target.
+func generateValueArg(d dialect, field parser.Field, target string) string {
+	if field.IsEncrypted {
+		if field.IsNullZero {
+			return fmt.Sprintf("encryptedColumn{dao.encryptor, nullIfZero(%s.%s)}", target, field.Name)
+		}
+		return fmt.Sprintf("encryptedColumn{dao.encryptor, %s.%s}", target, field.Name)
+	}
+	if field.Converter != nil {
+		return fmt.Sprintf("convertedValue(%s.%s, %s)", target, field.Name, field.Converter.Encode)
+	}
+	if d.isArray(field) {
+		return fmt.Sprintf("pgArray{%s.%s}", target, field.Name)
+	}
+	if field.IsJSON {
+		return fmt.Sprintf("jsonColumn{%s.%s}", target, field.Name)
+	}
+	if field.IsNullZero {
+		return fmt.Sprintf("nullIfZero(%s.%s)", target, field.Name)
+	}
+	return fmt.Sprintf("%s.%s", target, field.Name)
+}
+
+// generatePrimaryKeyArg renders the query argument matching the primary key
+// of model against expr.
+func generatePrimaryKeyArg(model parser.Model, expr string) string {
+	for _, field := range model.Fields {
+		if field.IsPrimary && field.Converter != nil {
+			return fmt.Sprintf("convertedValue(%s, %s)", expr, field.Converter.Encode)
+		}
+	}
+	return expr
+}
+
+// generatePrimaryKeyScanArg renders the Scan destination reading a primary
+// key of model into the variable expr.
+func generatePrimaryKeyScanArg(model parser.Model, expr string) string {
+	for _, field := range model.Fields {
+		if field.IsPrimary && field.Converter != nil {
+			return fmt.Sprintf("convertedScanner(&%s, %s)", expr, field.Converter.Decode)
+		}
+	}
+	return "&" + expr
+}
+
+// generateScanArg renders the Scan destination reading field of target.
+func generateScanArg(d dialect, field parser.Field, target string) string {
+	if field.IsEncrypted {
+		return fmt.Sprintf("encryptedColumn{dao.encryptor, &%s.%s}", target, field.Name)
+	}
+	if field.Converter != nil {
+		return fmt.Sprintf("convertedScanner(&%s.%s, %s)", target, field.Name, field.Converter.Decode)
+	}
+	if d.isArray(field) {
+		return fmt.Sprintf("pgArray{&%s.%s}", target, field.Name)
+	}
+	if field.IsJSON {
+		return fmt.Sprintf("jsonColumn{&%s.%s}", target, field.Name)
+	}
+	if _, _, ok := nullScanType(field); ok {
+		return "&" + nullScanVar(field)
+	}
+	if isNullableNamed(field) {
+		return fmt.Sprintf("nullableValue(&%s.%s)", target, field.Name)
+	}
+	return fmt.Sprintf("&%s.%s", target, field.Name)
+}
+
+// isNullableNamed reports whether field is a nullable field of a named type
+// with a builtin underlying type, which is scanned through sql.Null of the
+// named type.
+func isNullableNamed(field parser.Field) bool {
+	return field.IsNullable && field.Underlying != "" && !field.IsJSON && !field.IsEncrypted && field.Converter == nil
+}
+
+// nullScanType returns the sql.Null* type scanning the nullable field and the
+// member holding its value. ok is false for fields scanned directly, either
+// because they cannot be NULL or because their type already handles it.
+func nullScanType(field parser.Field) (nullType, member string, ok bool) {
+	if !field.IsNullable || field.IsJSON || field.IsEncrypted || field.Converter != nil {
+		return "", "", false
+	}
+	switch field.Type {
+	case "string":
+		return "sql.NullString", "String", true
+	case "bool":
+		return "sql.NullBool", "Bool", true
+	case "float32", "float64":
+		return "sql.NullFloat64", "Float64", true
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "sql.NullInt64", "Int64", true
+	case "time.Time":
+		return "sql.NullTime", "Time", true
+	}
+	return "", "", false
+}
+
+func nullScanVar(field parser.Field) string {
+	return "null" + field.Name
+}
+
+// generateNullScanDeclarations declares the intermediate values nullable
+// fields are scanned into.
+func generateNullScanDeclarations(model parser.Model, indent string) string {
+	var content strings.Builder
+	for _, field := range model.Fields {
+		if nullType, _, ok := nullScanType(field); ok {
+			content.WriteString(fmt.Sprintf("%svar %s %s\n", indent, nullScanVar(field), nullType))
+		}
+	}
+	return content.String()
+}
+
+// generateNullScanAssignments copies the intermediate values of nullable
+// fields into target, leaving the zero value for NULL.
+func generateNullScanAssignments(model parser.Model, target, indent string) string {
+	var content strings.Builder
+	for _, field := range model.Fields {
+		_, member, ok := nullScanType(field)
+		if !ok {
+			continue
+		}
+		value := fmt.Sprintf("%s.%s", nullScanVar(field), member)
+		if field.Type != "time.Time" && strings.ToLower(member) != field.Type {
+			value = fmt.Sprintf("%s(%s)", field.Type, value)
+		}
+		content.WriteString(fmt.Sprintf("%s%s.%s = %s\n", indent, target, field.Name, value))
+	}
+	if content.Len() > 0 {
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// generateFieldValueConversion converts value, the entry of field in a
+// PartialUpdate fields map, the way generateValueArg converts model fields,
+// and skips the columns computed by the database.
+func generateFieldValueConversion(d dialect, model parser.Model, indent string) string {
+	var generatedColumns, encryptedColumns, encryptedNullZeroColumns, arrayColumns, jsonColumns, nullZeroColumns []string
+	var encoders []string
+	convertedColumns := map[string][]string{}
+
+	for _, field := range model.Fields {
+		column := fmt.Sprintf("%q", field.Column)
+		switch {
+		case field.IsGenerated:
+			generatedColumns = append(generatedColumns, column)
+		case field.IsEncrypted && field.IsNullZero:
+			encryptedNullZeroColumns = append(encryptedNullZeroColumns, column)
+		case field.IsEncrypted:
+			encryptedColumns = append(encryptedColumns, column)
+		case field.Converter != nil:
+			encode := field.Converter.Encode
+			if _, ok := convertedColumns[encode]; !ok {
+				encoders = append(encoders, encode)
+			}
+			convertedColumns[encode] = append(convertedColumns[encode], column)
+		case d.isArray(field):
+			arrayColumns = append(arrayColumns, column)
+		case field.IsJSON:
+			jsonColumns = append(jsonColumns, column)
+		case field.IsNullZero:
+			nullZeroColumns = append(nullZeroColumns, column)
+		}
+	}
+
+	if len(generatedColumns) == 0 && len(encryptedColumns) == 0 && len(encryptedNullZeroColumns) == 0 && len(arrayColumns) == 0 && len(jsonColumns) == 0 && len(nullZeroColumns) == 0 && len(encoders) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString(indent + "switch field {\n")
+	if len(generatedColumns) > 0 {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(generatedColumns, ", ")))
+		content.WriteString(indent + "\tcontinue\n")
+	}
+	if len(encryptedColumns) > 0 {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(encryptedColumns, ", ")))
+		content.WriteString(indent + "\tvalue = encryptedColumn{dao.encryptor, value}\n")
+	}
+	if len(encryptedNullZeroColumns) > 0 {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(encryptedNullZeroColumns, ", ")))
+		content.WriteString(indent + "\tvalue = encryptedColumn{dao.encryptor, nullIfZero(value)}\n")
+	}
+	for _, encode := range encoders {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(convertedColumns[encode], ", ")))
+		content.WriteString(fmt.Sprintf("%s\tvalue = convertedValue(value, %s)\n", indent, encode))
+	}
+	if len(arrayColumns) > 0 {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(arrayColumns, ", ")))
+		content.WriteString(indent + "\tvalue = pgArray{value}\n")
+	}
+	if len(jsonColumns) > 0 {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(jsonColumns, ", ")))
+		content.WriteString(indent + "\tvalue = jsonColumn{value}\n")
+	}
+	if len(nullZeroColumns) > 0 {
+		content.WriteString(fmt.Sprintf("%scase %s:\n", indent, strings.Join(nullZeroColumns, ", ")))
+		content.WriteString(indent + "\tvalue = nullIfZero(value)\n")
+	}
+	content.WriteString(indent + "}\n")
+
+	return content.String()
+}
+
+func hasJSONField(model parser.Model) bool {
+	for _, field := range model.Fields {
+		if field.IsJSON && field.Converter == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNullZeroField(model parser.Model) bool {
+	for _, field := range model.Fields {
+		if field.IsNullZero && !field.IsJSON && field.Converter == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNullableNamedField(model parser.Model) bool {
+	for _, field := range model.Fields {
+		if isNullableNamed(field) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasConvertedField(model parser.Model) bool {
+	for _, field := range model.Fields {
+		if field.Converter != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// converterImports lists the packages of the converters used by model, other
+// than the model package itself.
+func converterImports(model parser.Model) []string {
+	var imports []string
+	seen := map[string]bool{model.ImportPath: true}
+	for _, field := range model.Fields {
+		if field.Converter == nil || field.Converter.Import == "" || seen[field.Converter.Import] {
+			continue
+		}
+		seen[field.Converter.Import] = true
+		imports = append(imports, field.Converter.Import)
+	}
+	return imports
+}
+
+func hasArrayField(model parser.Model, d dialect) bool {
+	for _, field := range model.Fields {
+		if d.isArray(field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/parser"
+)
+
+func getVersionField(model parser.Model) (parser.Field, bool) {
+	for _, field := range model.Fields {
+		if field.IsVersion {
+			return field, true
+		}
+	}
+	return parser.Field{}, false
+}
+
+// generateVersionCheck turns a versioned update that matched no rows into
+// ErrStaleObject and bumps the in-memory version of target otherwise.
+// It expects result and err to be in scope.
+func generateVersionCheck(versionField parser.Field, target, indent string) string {
+	var content strings.Builder
+
+	content.WriteString(indent + "if err != nil {\n")
+	content.WriteString(indent + "\treturn err\n")
+	content.WriteString(indent + "}\n\n")
+
+	content.WriteString(indent + "affected, err := result.RowsAffected()\n")
+	content.WriteString(indent + "if err != nil {\n")
+	content.WriteString(indent + "\treturn err\n")
+	content.WriteString(indent + "}\n")
+	content.WriteString(indent + "if affected == 0 {\n")
+	content.WriteString(indent + "\treturn ErrStaleObject\n")
+	content.WriteString(indent + "}\n\n")
+
+	content.WriteString(fmt.Sprintf("%s%s.%s++\n", indent, target, versionField.Name))
+
+	return content.String()
+}
+
+// isUpdateGuarded reports whether updates of the model carry conditions
+// beyond the primary key, requiring a dedicated PartialUpdate.
+func isUpdateGuarded(model parser.Model) bool {
+	_, versioned := getVersionField(model)
+	_, tenanted := getTenantField(model)
+	return versioned || tenanted
+}
+
+// generateGuardedPartialUpdateMethod generates a PartialUpdate scoped to the
+// tenant of the context and, for versioned models, reading the expected
+// version from fields instead of writing it.
+func generateGuardedPartialUpdateMethod(model parser.Model, daoName string, d dialect) string {
+	var content strings.Builder
+	versionField, versioned := getVersionField(model)
+	tenantField, tenanted := getTenantField(model)
+	primaryColumn := d.quote(getPrimaryColumn(model))
+	primaryType := getPrimaryType(model)
+
+	content.WriteString(fmt.Sprintf("func (dao *%s) PartialUpdate(ctx context.Context, pk %s, fields map[string]interface{}) error {\n", daoName, primaryType))
+	content.WriteString("\tif len(fields) == 0 {\n")
+	content.WriteString("\t\treturn nil\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString(generateTenantPrelude(model, ""))
+
+	if tenanted {
+		content.WriteString(fmt.Sprintf("\tif _, ok := fields[%q]; ok {\n", tenantField.Column))
+		content.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"fields must not contain the %s\")\n", tenantField.Column))
+		content.WriteString("\t}\n\n")
+	}
+
+	if versioned {
+		content.WriteString(fmt.Sprintf("\tversion, ok := fields[%q]\n", versionField.Column))
+		content.WriteString("\tif !ok {\n")
+		content.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"fields must contain the current %s\")\n", versionField.Column))
+		content.WriteString("\t}\n\n")
+	}
+
+	content.WriteString("\tsetClauses := make([]string, 0, len(fields))\n")
+	content.WriteString("\targs := make([]interface{}, 0, len(fields)+2)\n\n")
+
+	content.WriteString("\tfor field, value := range fields {\n")
+	content.WriteString(generateColumnCheck(model, "field", "", "\t\t"))
+	content.WriteString(generateValidFieldCall(model, "", "\t\t"))
+	content.WriteString(generateFieldValueConversion(d, model, "\t\t"))
+	if versioned {
+		content.WriteString(fmt.Sprintf("\t\tif field == %q {\n", versionField.Column))
+		content.WriteString("\t\t\tcontinue\n")
+		content.WriteString("\t\t}\n")
+	}
+	content.WriteString("\t\targs = append(args, value)\n")
+	content.WriteString(fmt.Sprintf("\t\tsetClauses = append(setClauses, %q+field+%s)\n", d.openQuote, d.concatBind(d.closeQuote+" = ", "len(args)")))
+	content.WriteString("\t}\n")
+	if versioned {
+		content.WriteString(fmt.Sprintf("\tsetClauses = append(setClauses, %q)\n", fmt.Sprintf("%s = %s + 1", d.quote(versionField.Column), d.quote(versionField.Column))))
+	}
+	content.WriteString("\n")
+
+	content.WriteString(fmt.Sprintf("\targs = append(args, %s)\n", generatePrimaryKeyArg(model, "pk")))
+	content.WriteString(fmt.Sprintf("\twhereClause := %s\n", d.concatBind(primaryColumn+" = ", "len(args)")))
+	if tenanted {
+		content.WriteString("\targs = append(args, tenantID)\n")
+		content.WriteString(fmt.Sprintf("\twhereClause += %s\n", d.concatBind(" AND "+d.quote(tenantField.Column)+" = ", "len(args)")))
+	}
+	if versioned {
+		content.WriteString("\targs = append(args, version)\n")
+		content.WriteString(fmt.Sprintf("\twhereClause += %s\n", d.concatBind(" AND "+d.quote(versionField.Column)+" = ", "len(args)")))
+	}
+	content.WriteString("\n")
+
+	content.WriteString(fmt.Sprintf("\tquery := fmt.Sprintf(%s, strings.Join(setClauses, \", \"), whereClause)\n\n", d.literal(fmt.Sprintf("UPDATE %s SET %%s WHERE %%s", d.table(model)))))
+
+	if !versioned {
+		content.WriteString("\t_, err := dao.execContext(ctx, query, args...)\n")
+		content.WriteString("\treturn err\n")
+		content.WriteString("}\n\n")
+		return content.String()
+	}
+
+	content.WriteString("\tresult, err := dao.execContext(ctx, query, args...)\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\taffected, err := result.RowsAffected()\n")
+	content.WriteString("\tif err != nil {\n")
+	content.WriteString("\t\treturn err\n")
+	content.WriteString("\t}\n")
+	content.WriteString("\tif affected == 0 {\n")
+	content.WriteString("\t\treturn ErrStaleObject\n")
+	content.WriteString("\t}\n\n")
+
+	content.WriteString("\treturn nil\n")
+	content.WriteString("}\n\n")
+
+	return content.String()
+}
@@ -0,0 +1,111 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Strategy derives database names from Go identifiers.
+type Strategy string
+
+const (
+	AsIs      Strategy = "as-is"
+	SnakeCase Strategy = "snake"
+	CamelCase Strategy = "camel"
+)
+
+// ParseStrategy returns the strategy with the given name.
+func ParseStrategy(name string) (Strategy, error) {
+	switch Strategy(name) {
+	case AsIs, SnakeCase, CamelCase:
+		return Strategy(name), nil
+	}
+	return "", fmt.Errorf("invalid naming strategy: %s. Allowed strategies: %s, %s, %s", name, SnakeCase, CamelCase, AsIs)
+}
+
+// Apply converts name according to the strategy.
+func (s Strategy) Apply(name string) string {
+	switch s {
+	case SnakeCase:
+		return ToSnakeCase(name)
+	case CamelCase:
+		return ToCamelCase(name)
+	}
+	return name
+}
+
+// ToSnakeCase converts a Go identifier to snake_case, keeping initialisms
+// together: UserID becomes user_id and HTTPStatus becomes http_status.
+func ToSnakeCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// ToCamelCase converts a Go identifier to camelCase, treating initialisms as
+// single words: UserID becomes userId and HTTPStatus becomes httpStatus.
+func ToCamelCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words[i] = word
+	}
+	return strings.Join(words, "")
+}
+
+// Pluralize returns the English plural of the last word of name.
+func Pluralize(name string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case name == "":
+		return name
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
+
+// splitWords splits an identifier at case changes, underscores and the end of
+// initialisms.
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '_' || r == '-' {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+
+		if i > 0 && unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+
+	return words
+}
@@ -0,0 +1,74 @@
+package naming_test
+
+import (
+	"testing"
+
+	"github.com/Jibaru/gormless/internal/naming"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"URL":        "url",
+		"ProfileURL": "profile_url",
+		"HTTPStatus": "http_status",
+		"CreatedAt":  "created_at",
+		"User":       "user",
+		"Address2":   "address2",
+		"already_ok": "already_ok",
+	}
+
+	for input, expected := range tests {
+		if got := naming.ToSnakeCase(input); got != expected {
+			t.Errorf("ToSnakeCase(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestToCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "userId",
+		"HTTPStatus": "httpStatus",
+		"CreatedAt":  "createdAt",
+		"User":       "user",
+	}
+
+	for input, expected := range tests {
+		if got := naming.ToCamelCase(input); got != expected {
+			t.Errorf("ToCamelCase(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"user":       "users",
+		"order_item": "order_items",
+		"category":   "categories",
+		"key":        "keys",
+		"address":    "addresses",
+		"box":        "boxes",
+		"branch":     "branches",
+		"OrderItem":  "OrderItems",
+	}
+
+	for input, expected := range tests {
+		if got := naming.Pluralize(input); got != expected {
+			t.Errorf("Pluralize(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, name := range []string{"snake", "camel", "as-is"} {
+		if _, err := naming.ParseStrategy(name); err != nil {
+			t.Errorf("unexpected error for %q: %v", name, err)
+		}
+	}
+
+	if _, err := naming.ParseStrategy("kebab"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
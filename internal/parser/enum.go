@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Enum lists the values of the constants declared in the model package for
+// the named string or integer type of a field, in declaration order.
+type Enum struct {
+	Type    string
+	Values  []string
+	Integer bool
+}
+
+// resolveEnum returns the enum of typeName, or nil when typeName is not a
+// string or integer type declared in files with at least one constant whose
+// value can be evaluated statically.
+func resolveEnum(typeName string, files []*ast.File) *Enum {
+	if !token.IsIdentifier(typeName) {
+		return nil
+	}
+
+	underlying, ok := findTypeUnderlying(typeName, files)
+	if !ok {
+		return nil
+	}
+
+	enum := &Enum{Type: typeName}
+	switch {
+	case underlying == "string":
+	case isIntegerType(underlying):
+		enum.Integer = true
+	default:
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+
+			var specType string
+			var specValues []ast.Expr
+			for i, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				// A spec without values repeats the type and values of the
+				// previous one with the next iota.
+				if len(vs.Values) > 0 {
+					specType = ""
+					if ident, ok := vs.Type.(*ast.Ident); ok {
+						specType = ident.Name
+					}
+					specValues = vs.Values
+				}
+
+				for j, ident := range vs.Names {
+					if ident.Name == "_" || j >= len(specValues) {
+						continue
+					}
+
+					valueType, expr := specType, specValues[j]
+					if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+						if fun, ok := call.Fun.(*ast.Ident); ok && valueType == "" {
+							valueType, expr = fun.Name, call.Args[0]
+						}
+					}
+					if valueType != typeName {
+						continue
+					}
+
+					var value string
+					if enum.Integer {
+						n, err := evalIntExpr(expr, int64(i), typeName, files, map[string]bool{})
+						if err != nil {
+							return nil
+						}
+						value = strconv.FormatInt(n, 10)
+					} else {
+						s, err := evalStringExpr(expr, files, map[string]bool{})
+						if err != nil {
+							return nil
+						}
+						value = s
+					}
+
+					if !seen[value] {
+						seen[value] = true
+						enum.Values = append(enum.Values, value)
+					}
+				}
+			}
+		}
+	}
+
+	if len(enum.Values) == 0 {
+		return nil
+	}
+
+	return enum
+}
+
+// findTypeUnderlying returns the name of the type typeName is declared with
+// in files, when it is a plain identifier.
+func findTypeUnderlying(typeName string, files []*ast.File) (string, bool) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Name.Name != typeName || ts.Assign.IsValid() {
+					continue
+				}
+				ident, ok := ts.Type.(*ast.Ident)
+				if !ok {
+					return "", false
+				}
+				return ident.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// evalIntExpr evaluates a constant integer expression made of literals, iota,
+// package-level constants, conversions to typeName and arithmetic.
+func evalIntExpr(expr ast.Expr, iota int64, typeName string, files []*ast.File, visiting map[string]bool) (int64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return 0, fmt.Errorf("%s is not an integer", e.Value)
+		}
+		return strconv.ParseInt(e.Value, 0, 64)
+	case *ast.ParenExpr:
+		return evalIntExpr(e.X, iota, typeName, files, visiting)
+	case *ast.CallExpr:
+		if fun, ok := e.Fun.(*ast.Ident); ok && fun.Name == typeName && len(e.Args) == 1 {
+			return evalIntExpr(e.Args[0], iota, typeName, files, visiting)
+		}
+	case *ast.UnaryExpr:
+		x, err := evalIntExpr(e.X, iota, typeName, files, visiting)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.SUB:
+			return -x, nil
+		case token.ADD:
+			return x, nil
+		}
+		return 0, fmt.Errorf("unsupported operator %s", e.Op)
+	case *ast.BinaryExpr:
+		x, err := evalIntExpr(e.X, iota, typeName, files, visiting)
+		if err != nil {
+			return 0, err
+		}
+		y, err := evalIntExpr(e.Y, iota, typeName, files, visiting)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		case token.QUO:
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		case token.REM:
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return x % y, nil
+		case token.SHL:
+			return x << y, nil
+		case token.SHR:
+			return x >> y, nil
+		case token.OR:
+			return x | y, nil
+		case token.AND:
+			return x & y, nil
+		}
+		return 0, fmt.Errorf("unsupported operator %s", e.Op)
+	case *ast.Ident:
+		if e.Name == "iota" {
+			return iota, nil
+		}
+		if visiting[e.Name] {
+			return 0, fmt.Errorf("constant %s refers to itself", e.Name)
+		}
+		value, ok := findConst(e.Name, files)
+		if !ok {
+			return 0, fmt.Errorf("%s is not a package-level integer constant", e.Name)
+		}
+		visiting[e.Name] = true
+		defer delete(visiting, e.Name)
+		return evalIntExpr(value, iota, typeName, files, visiting)
+	}
+	return 0, fmt.Errorf("unsupported expression of type %T", expr)
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -8,28 +9,105 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+
+	"github.com/Jibaru/gormless/internal/naming"
 )
 
 type Model struct {
 	Name       string
 	Fields     []Field
 	TableName  string
+	Schema     string
 	PrimaryKey string
 	Package    string
 	ImportPath string
+	Relations  []Relation
+	// Queue is set for models declared with a //gormless:queue directive.
+	Queue *Queue
+	// Queries are the named queries of the queries file of the model.
+	Queries []Query
 }
 
 type Field struct {
-	Name      string
-	Type      string
-	Column    string
-	IsPrimary bool
+	Name         string
+	Type         string
+	Column       string
+	IsPrimary    bool
+	IsSoftDelete bool
+	IsVersion    bool
+	IsTenant     bool
+	IsJSON       bool
+	IsNullable   bool
+	IsNullZero   bool
+	IsGenerated  bool
+	IsNotNull    bool
+	IsEncrypted  bool
+	// IsGroupBy is set by the groupby option, generating a GroupBy method
+	// counting the rows per value of the field.
+	IsGroupBy bool
+	// SQLType is the column type declared with the type option, used verbatim
+	// in the schema and to cast bound values on databases that need it.
+	SQLType string
+	// Size, Precision and Scale refine the column type derived from the Go
+	// type when SQLType is empty.
+	Size      int
+	Precision int
+	Scale     int
+	Converter *Converter
+	Enum      *Enum
+	// Underlying is the builtin type a nullable field of a named type
+	// declared in the model package is declared with, such as string for
+	// type Status string. Such fields are scanned through sql.Null of their
+	// named type.
+	Underlying string
+}
+
+// Converter names the functions converting a Go type to and from its column
+// value. Encode has the signature func(T) (driver.Value, error) and Decode
+// func(interface{}) (T, error); both are qualified by the package they are
+// imported from.
+type Converter struct {
+	Import string
+	Encode string
+	Decode string
+}
+
+// Options configures how names missing from the models are derived.
+type Options struct {
+	// ColumnNaming derives the column of fields without a column in their tag.
+	ColumnNaming naming.Strategy
+	// TableNaming derives the table of models without a TableName method.
+	TableNaming naming.Strategy
+	// PluralTables pluralizes derived table names.
+	PluralTables bool
+	// TagKeys lists the struct tag keys read for column mappings, by priority.
+	TagKeys []string
+	// Converters maps Go types, as written in the models, to their converter.
+	Converters map[string]Converter
+}
+
+// DefaultOptions keeps Go names verbatim.
+func DefaultOptions() Options {
+	return Options{
+		ColumnNaming: naming.AsIs,
+		TableNaming:  naming.AsIs,
+		TagKeys:      []string{"sql"},
+	}
 }
 
 func ParseModels(inputPath string) ([]Model, error) {
+	return ParseModelsWithOptions(inputPath, DefaultOptions())
+}
+
+func ParseModelsWithOptions(inputPath string, opts Options) ([]Model, error) {
 	var models []Model
 
+	if len(opts.TagKeys) == 0 {
+		opts.TagKeys = DefaultOptions().TagKeys
+	}
+
 	info, err := os.Stat(inputPath)
 	if err != nil {
 		return nil, err
@@ -41,7 +119,7 @@ func ParseModels(inputPath string) ([]Model, error) {
 				return err
 			}
 			if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
-				fileModels, err := parseFileModels(path)
+				fileModels, err := parseFileModels(path, opts)
 				if err != nil {
 					return err
 				}
@@ -53,16 +131,20 @@ func ParseModels(inputPath string) ([]Model, error) {
 			return nil, err
 		}
 	} else if strings.HasSuffix(inputPath, ".go") {
-		models, err = parseFileModels(inputPath)
+		models, err = parseFileModels(inputPath, opts)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if err := resolveRelations(models); err != nil {
+		return nil, err
+	}
+
 	return models, nil
 }
 
-func parseFileModels(filePath string) ([]Model, error) {
+func parseFileModels(filePath string, opts Options) ([]Model, error) {
 	fset := token.NewFileSet()
 	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
 	if err != nil {
@@ -77,19 +159,44 @@ func parseFileModels(filePath string) ([]Model, error) {
 		return nil, err
 	}
 
+	files, err := parsePackageFiles(fset, filePath, node)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolveErr error
+
 	ast.Inspect(node, func(n ast.Node) bool {
+		if resolveErr != nil {
+			return false
+		}
 		switch x := n.(type) {
 		case *ast.GenDecl:
 			if x.Tok == token.TYPE {
 				for _, spec := range x.Specs {
 					if ts, ok := spec.(*ast.TypeSpec); ok {
 						if st, ok := ts.Type.(*ast.StructType); ok {
-							model, err := parseStruct(ts.Name.Name, st, node)
-							if err == nil && len(model.Fields) > 0 {
-								model.Package = packageName
-								model.ImportPath = importPath
-								models = append(models, model)
+							doc := ts.Doc
+							if doc == nil && len(x.Specs) == 1 {
+								doc = x.Doc
+							}
+							model, err := parseStruct(ts.Name.Name, st, doc, files, opts)
+							if errors.Is(err, errNotModel) {
+								continue
+							}
+							if err != nil {
+								resolveErr = err
+								return false
+							}
+							queries, err := loadQueries(filePath, model.Name)
+							if err != nil {
+								resolveErr = err
+								return false
 							}
+							model.Package = packageName
+							model.ImportPath = importPath
+							model.Queries = queries
+							models = append(models, model)
 						}
 					}
 				}
@@ -98,6 +205,10 @@ func parseFileModels(filePath string) ([]Model, error) {
 		return true
 	})
 
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
 	return models, nil
 }
 
@@ -155,19 +266,23 @@ func findGoMod(dir string) string {
 	return ""
 }
 
-func parseStruct(name string, st *ast.StructType, file *ast.File) (Model, error) {
+// errNotModel is wrapped by the errors of parseStruct for structs without
+// exposed fields or without a primary tag, which are not models and are
+// skipped. Any other error is reported.
+var errNotModel = errors.New("not a model")
+
+func parseStruct(name string, st *ast.StructType, doc *ast.CommentGroup, files []*ast.File, opts Options) (Model, error) {
 	model := Model{
 		Name:      name,
-		TableName: name,
+		TableName: defaultTableName(name, opts),
 		Fields:    []Field{},
 	}
 
-	tableName := getTableNameFromMethods(name, file)
-	if tableName != "" {
-		model.TableName = tableName
-	}
-
 	var primaryKeyFound bool
+	var softDeleteFound bool
+	var versionFound bool
+	var tenantFound bool
+	var tagSchema string
 
 	for _, field := range st.Fields.List {
 		if len(field.Names) == 0 {
@@ -175,74 +290,231 @@ func parseStruct(name string, st *ast.StructType, file *ast.File) (Model, error)
 		}
 
 		fieldName := field.Names[0].Name
+		if fieldName == "_" {
+			if field.Tag != nil {
+				tagSchema = reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("schema")
+			}
+			continue
+		}
 		if fieldName[0:1] != strings.ToUpper(fieldName[0:1]) {
 			continue
 		}
 
 		fieldType := getTypeString(field.Type)
-		column := fieldName
+
+		if field.Tag != nil {
+			if tag, ok := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Lookup("rel"); ok {
+				relation, err := parseRelation(fieldName, fieldType, tag)
+				if err != nil {
+					return Model{}, fmt.Errorf("invalid relation in the %s model: %v", name, err)
+				}
+				model.Relations = append(model.Relations, relation)
+				continue
+			}
+		}
+
+		column := opts.ColumnNaming.Apply(fieldName)
 		isPrimary := false
+		isSoftDelete := false
+		isVersion := false
+		isTenant := false
+		isJSON := false
+		isNullable := strings.HasPrefix(fieldType, "sql.Null")
+		isNullZero := false
+		isGenerated := false
+		isNotNull := false
+		isEncrypted := false
+		isGroupBy := false
+		sqlType := ""
+		size, precision, scale := 0, 0, 0
 
 		if field.Tag != nil {
 			tag := strings.Trim(field.Tag.Value, "`")
-			sqlTag := extractTag(tag, "sql")
-			if sqlTag != "" {
-				parts := strings.Split(sqlTag, ",")
-				if len(parts) > 0 && parts[0] != "" {
-					column = parts[0]
+			tagColumn, options, skip := extractTag(tag, opts.TagKeys)
+			if skip {
+				continue
+			}
+			if tagColumn != "" {
+				column = tagColumn
+			}
+			for _, option := range options {
+				key, arg, hasArg := strings.Cut(option, ":")
+				if hasArg {
+					switch key {
+					case "type":
+						sqlType = strings.TrimSpace(arg)
+						if sqlType == "" {
+							return Model{}, fmt.Errorf("the type of the %s field in the %s model is empty", fieldName, name)
+						}
+					case "size", "precision", "scale":
+						n, err := strconv.Atoi(strings.TrimSpace(arg))
+						if err != nil || n < 0 || (n == 0 && key != "scale") {
+							return Model{}, fmt.Errorf("the %s of the %s field in the %s model must be a positive integer", key, fieldName, name)
+						}
+						switch key {
+						case "size":
+							size = n
+						case "precision":
+							precision = n
+						case "scale":
+							scale = n
+						}
+					}
+					continue
 				}
-				for _, part := range parts {
-					if strings.TrimSpace(part) == "primary" {
-						isPrimary = true
-						primaryKeyFound = true
-						model.PrimaryKey = fieldName
+				switch option {
+				case "primary":
+					isPrimary = true
+					primaryKeyFound = true
+					model.PrimaryKey = fieldName
+				case "softdelete":
+					if softDeleteFound {
+						return Model{}, fmt.Errorf("there is more than one softdelete tag in the %s model", name)
+					}
+					if fieldType != "*time.Time" && fieldType != "sql.NullTime" {
+						return Model{}, fmt.Errorf("the softdelete field %s in the %s model must be a *time.Time or sql.NullTime, so that live rows hold NULL", fieldName, name)
+					}
+					isSoftDelete = true
+					softDeleteFound = true
+				case "version":
+					if versionFound {
+						return Model{}, fmt.Errorf("there is more than one version tag in the %s model", name)
+					}
+					if !isIntegerType(fieldType) {
+						return Model{}, fmt.Errorf("the version field %s in the %s model must be an integer", fieldName, name)
 					}
+					isVersion = true
+					versionFound = true
+				case "tenant":
+					if tenantFound {
+						return Model{}, fmt.Errorf("there is more than one tenant tag in the %s model", name)
+					}
+					isTenant = true
+					tenantFound = true
+				case "json":
+					isJSON = true
+				case "nullable":
+					isNullable = true
+				case "nullzero":
+					isNullable = true
+					isNullZero = true
+				case "generated":
+					isGenerated = true
+				case "notnull":
+					isNotNull = true
+				case "encrypted":
+					isEncrypted = true
+				case "groupby":
+					isGroupBy = true
 				}
 			}
 		}
 
+		if isEncrypted {
+			if fieldType != "string" && fieldType != "[]byte" {
+				return Model{}, fmt.Errorf("the encrypted field %s in the %s model must be a string or []byte", fieldName, name)
+			}
+			if isPrimary || isSoftDelete || isVersion || isTenant || isJSON || isGenerated {
+				return Model{}, fmt.Errorf("the encrypted field %s in the %s model cannot also be primary, softdelete, version, tenant, json or generated", fieldName, name)
+			}
+		}
+
+		if isGroupBy && (isPrimary || isSoftDelete || isVersion || isTenant || isJSON || isEncrypted) {
+			return Model{}, fmt.Errorf("the groupby field %s in the %s model cannot also be primary, softdelete, version, tenant, json or encrypted", fieldName, name)
+		}
+
+		underlying := ""
+		if isNullable && token.IsIdentifier(fieldType) {
+			if typeName, ok := findTypeUnderlying(fieldType, files); ok && isNullScannableType(typeName) {
+				underlying = typeName
+			}
+		}
+
+		if scale > 0 && precision == 0 {
+			return Model{}, fmt.Errorf("the %s field in the %s model has a scale without a precision", fieldName, name)
+		}
+
 		model.Fields = append(model.Fields, Field{
-			Name:      fieldName,
-			Type:      fieldType,
-			Column:    column,
-			IsPrimary: isPrimary,
+			Name:         fieldName,
+			Type:         fieldType,
+			Column:       column,
+			IsPrimary:    isPrimary,
+			IsSoftDelete: isSoftDelete,
+			IsVersion:    isVersion,
+			IsTenant:     isTenant,
+			IsJSON:       isJSON,
+			IsNullable:   isNullable,
+			IsNullZero:   isNullZero,
+			IsGenerated:  isGenerated,
+			IsNotNull:    isNotNull,
+			IsEncrypted:  isEncrypted,
+			IsGroupBy:    isGroupBy,
+			SQLType:      sqlType,
+			Size:         size,
+			Precision:    precision,
+			Scale:        scale,
+			Converter:    converterFor(fieldType, opts),
+			Enum:         resolveEnum(fieldType, files),
+			Underlying:   underlying,
 		})
 	}
 
 	if len(model.Fields) == 0 {
-		return Model{}, fmt.Errorf("there are no exposed fields in the %s model", name)
+		return Model{}, fmt.Errorf("there are no exposed fields in the %s model: %w", name, errNotModel)
 	}
 
 	if !primaryKeyFound {
-		return Model{}, fmt.Errorf("there is no primary tag in the %s model", name)
+		return Model{}, fmt.Errorf("there is no primary tag in the %s model: %w", name, errNotModel)
+	}
+
+	queue, err := parseQueue(model, doc)
+	if err != nil {
+		return Model{}, err
+	}
+	model.Queue = queue
+
+	tableName, err := resolveTableName(name, doc, files)
+	if err != nil {
+		return Model{}, err
+	}
+	if tableName != "" {
+		model.TableName = tableName
+	}
+
+	if schema, table, ok := strings.Cut(model.TableName, "."); ok {
+		model.Schema = schema
+		model.TableName = table
+	}
+
+	schema, err := resolveMethodString(name, "Schema", files)
+	if err != nil {
+		return Model{}, err
+	}
+	if schema != "" {
+		model.Schema = schema
+	}
+
+	if tagSchema != "" {
+		model.Schema = tagSchema
 	}
 
 	return model, nil
 }
 
-func getTableNameFromMethods(structName string, file *ast.File) string {
-	for _, decl := range file.Decls {
-		if fn, ok := decl.(*ast.FuncDecl); ok {
-			if fn.Recv != nil && len(fn.Recv.List) > 0 {
-				if receiverType := getReceiverType(fn.Recv.List[0].Type); receiverType == structName {
-					if fn.Name.Name == "TableName" {
-						if fn.Body != nil {
-							for _, stmt := range fn.Body.List {
-								if ret, ok := stmt.(*ast.ReturnStmt); ok {
-									if len(ret.Results) > 0 {
-										if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
-											return strings.Trim(lit.Value, `"`)
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
+func defaultTableName(structName string, opts Options) string {
+	tableName := opts.TableNaming.Apply(structName)
+	if opts.PluralTables {
+		tableName = naming.Pluralize(tableName)
 	}
-	return ""
+	return tableName
+}
+
+func converterFor(fieldType string, opts Options) *Converter {
+	converter, ok := opts.Converters[fieldType]
+	if !ok {
+		return nil
+	}
+	return &converter
 }
 
 func getReceiverType(expr ast.Expr) string {
@@ -273,7 +545,109 @@ func getTypeString(expr ast.Expr) string {
 	return "interface{}"
 }
 
-func extractTag(tag, key string) string {
+func isIntegerType(typeName string) bool {
+	switch typeName {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// isNullScannableType reports whether the builtin typeName has a sql.Null
+// type scanning it.
+func isNullScannableType(typeName string) bool {
+	switch typeName {
+	case "string", "bool", "float32", "float64":
+		return true
+	}
+	return isIntegerType(typeName)
+}
+
+// extractTag reads the column of a field from the first of keys present in
+// tag, and merges the options of all of them. skip reports that the first key
+// present excludes the field with "-".
+func extractTag(tag string, keys []string) (string, []string, bool) {
 	st := reflect.StructTag(tag)
-	return st.Get(key)
+
+	var column string
+	var options []string
+	var found bool
+
+	for _, key := range keys {
+		value, ok := st.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		keyColumn, keyOptions := parseTagValue(key, value)
+		if !found && keyColumn == "-" {
+			return "", nil, true
+		}
+		found = true
+
+		if column == "" && keyColumn != "-" {
+			column = keyColumn
+		}
+		options = append(options, keyOptions...)
+	}
+
+	return column, options, false
+}
+
+// parseTagValue splits a tag value into its column and options following the
+// syntax of the tag key: gorm uses "column:name;primaryKey", while sql, db and
+// json use "name,option".
+func parseTagValue(key, value string) (string, []string) {
+	if key == "gorm" {
+		if value == "-" {
+			return "-", nil
+		}
+
+		var column string
+		var options []string
+		for _, setting := range strings.Split(value, ";") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(setting), ":")
+			switch strings.ToLower(name) {
+			case "column":
+				column = strings.TrimSpace(arg)
+			case "primarykey", "primary_key":
+				options = append(options, "primary")
+			case "type", "size", "precision", "scale":
+				options = append(options, strings.ToLower(name)+":"+strings.TrimSpace(arg))
+			case "not null":
+				options = append(options, "notnull")
+			}
+		}
+		return column, options
+	}
+
+	parts := splitTagValue(value)
+	options := make([]string, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		options = append(options, strings.TrimSpace(part))
+	}
+	return strings.TrimSpace(parts[0]), options
+}
+
+// splitTagValue splits value on the commas outside parentheses, keeping
+// options like "type:numeric(12,2)" whole.
+func splitTagValue(value string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, value[start:])
 }
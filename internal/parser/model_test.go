@@ -4,8 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/Jibaru/gormless/internal/naming"
 	"github.com/Jibaru/gormless/internal/parser"
 )
 
@@ -356,9 +358,12 @@ type NoPrimaryKey struct {
 			}
 
 			models, err := parser.ParseModels(testFile)
-			// Should return error or empty models since there's no primary key
-			if err == nil && len(models) > 0 {
-				t.Fatal("expected error or no models for struct without primary key")
+			// Structs without a primary key are not models and are skipped
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(models) != 0 {
+				t.Fatalf("expected no models for struct without primary key, got %d", len(models))
 			}
 		})
 
@@ -447,6 +452,1581 @@ func (c *CustomTableModel) TableName() string {
 			t.Errorf("expected custom_table_name, got %s", model.TableName)
 		}
 	})
+
+	t.Run("soft delete tag", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "softdelete.go")
+
+		testContent := `package models
+
+import "time"
+
+type Post struct {
+	ID        int        ` + "`sql:\"id,primary\"`" + `
+	Title     string     ` + "`sql:\"title\"`" + `
+	DeletedAt *time.Time ` + "`sql:\"deleted_at,softdelete\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Title", Type: "string", Column: "title"},
+			{Name: "DeletedAt", Type: "*time.Time", Column: "deleted_at", IsSoftDelete: true},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+
+		invalidContent := `package models
+
+import "time"
+
+type TwoSoftDeletes struct {
+	ID        int        ` + "`sql:\"id,primary\"`" + `
+	DeletedAt *time.Time ` + "`sql:\"deleted_at,softdelete\"`" + `
+	RemovedAt *time.Time ` + "`sql:\"removed_at,softdelete\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(invalidContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil || !strings.Contains(err.Error(), "more than one softdelete tag") {
+			t.Errorf("expected an error for two softdelete tags, got: %v", err)
+		}
+
+		plainTimeContent := `package models
+
+import "time"
+
+type PlainSoftDelete struct {
+	ID        int       ` + "`sql:\"id,primary\"`" + `
+	DeletedAt time.Time ` + "`sql:\"deleted_at,softdelete\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(plainTimeContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil || !strings.Contains(err.Error(), "must be a *time.Time or sql.NullTime") {
+			t.Errorf("expected an error for a time.Time softdelete field, got: %v", err)
+		}
+	})
+
+	t.Run("version tag", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "version.go")
+
+		testContent := `package models
+
+type Article struct {
+	ID      int    ` + "`sql:\"id,primary\"`" + `
+	Title   string ` + "`sql:\"title\"`" + `
+	Version int64  ` + "`sql:\"version,version\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Title", Type: "string", Column: "title"},
+			{Name: "Version", Type: "int64", Column: "version", IsVersion: true},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+
+		invalidContent := `package models
+
+type StringVersion struct {
+	ID      int    ` + "`sql:\"id,primary\"`" + `
+	Version string ` + "`sql:\"version,version\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(invalidContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil || !strings.Contains(err.Error(), "must be an integer") {
+			t.Errorf("expected an error for a non integer version, got: %v", err)
+		}
+	})
+
+	t.Run("tenant tag", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "tenant.go")
+
+		testContent := `package models
+
+type Invoice struct {
+	ID       int    ` + "`sql:\"id,primary\"`" + `
+	TenantID int64  ` + "`sql:\"tenant_id,tenant\"`" + `
+	Number   string ` + "`sql:\"number\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "TenantID", Type: "int64", Column: "tenant_id", IsTenant: true},
+			{Name: "Number", Type: "string", Column: "number"},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+
+		invalidContent := `package models
+
+type DoubleTenant struct {
+	ID     int   ` + "`sql:\"id,primary\"`" + `
+	OrgID  int64 ` + "`sql:\"org_id,tenant\"`" + `
+	ShopID int64 ` + "`sql:\"shop_id,tenant\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(invalidContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil || !strings.Contains(err.Error(), "more than one tenant tag") {
+			t.Errorf("expected an error for two tenant tags, got: %v", err)
+		}
+	})
+
+	t.Run("schema qualified tables", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "schema.go")
+
+		testContent := `package models
+
+type Invoice struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+func (i *Invoice) TableName() string {
+	return "billing.invoices"
+}
+
+type Order struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+func (o *Order) TableName() string {
+	return "order"
+}
+
+func (o *Order) Schema() string {
+	return "sales"
+}
+
+type Payment struct {
+	_  struct{} ` + "`schema:\"billing\"`" + `
+	ID int      ` + "`sql:\"id,primary\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 3 {
+			t.Fatalf("expected 3 models, got %d", len(models))
+		}
+
+		tests := []struct {
+			name      string
+			schema    string
+			tableName string
+		}{
+			{name: "Invoice", schema: "billing", tableName: "invoices"},
+			{name: "Order", schema: "sales", tableName: "order"},
+			{name: "Payment", schema: "billing", tableName: "Payment"},
+		}
+
+		for _, tt := range tests {
+			model := findModel(models, tt.name)
+			if model == nil {
+				t.Fatalf("%s model not found", tt.name)
+			}
+			if model.Schema != tt.schema {
+				t.Errorf("expected %s schema %q, got %q", tt.name, tt.schema, model.Schema)
+			}
+			if model.TableName != tt.tableName {
+				t.Errorf("expected %s table name %q, got %q", tt.name, tt.tableName, model.TableName)
+			}
+			if len(model.Fields) != 1 {
+				t.Errorf("expected %s to have 1 field, got %d", tt.name, len(model.Fields))
+			}
+		}
+	})
+
+	t.Run("naming strategy", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "naming.go")
+
+		testContent := `package models
+
+type OrderItem struct {
+	ID         int    ` + "`sql:\",primary\"`" + `
+	ProfileURL string
+	HTTPStatus int
+	Quantity   int    ` + "`sql:\"qty\"`" + `
+}
+
+type Category struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+func (c *Category) TableName() string {
+	return "category"
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		opts := parser.Options{
+			ColumnNaming: naming.SnakeCase,
+			TableNaming:  naming.SnakeCase,
+			PluralTables: true,
+		}
+
+		models, err := parser.ParseModelsWithOptions(testFile, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		orderItem := findModel(models, "OrderItem")
+		if orderItem == nil {
+			t.Fatal("OrderItem model not found")
+		}
+
+		if orderItem.TableName != "order_items" {
+			t.Errorf("expected table name order_items, got %s", orderItem.TableName)
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "ProfileURL", Type: "string", Column: "profile_url"},
+			{Name: "HTTPStatus", Type: "int", Column: "http_status"},
+			{Name: "Quantity", Type: "int", Column: "qty"},
+		}
+
+		if !reflect.DeepEqual(orderItem.Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, orderItem.Fields)
+		}
+
+		category := findModel(models, "Category")
+		if category == nil {
+			t.Fatal("Category model not found")
+		}
+
+		if category.TableName != "category" {
+			t.Errorf("expected TableName method to take precedence, got %s", category.TableName)
+		}
+	})
+
+	t.Run("alternative tag keys", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "tags.go")
+
+		testContent := `package models
+
+type GormUser struct {
+	ID       int    ` + "`gorm:\"column:user_id;primaryKey;autoIncrement\"`" + `
+	Name     string ` + "`gorm:\"column:full_name;size:255\" json:\"name\"`" + `
+	Internal string ` + "`gorm:\"-\"`" + `
+}
+
+type SqlxUser struct {
+	ID       int    ` + "`db:\"id\" sql:\",primary\"`" + `
+	Email    string ` + "`db:\"email\" json:\"mail\"`" + `
+	Password string ` + "`db:\"password\" json:\"-\"`" + `
+	Nickname string ` + "`json:\"nickname,omitempty\"`" + `
+	Secret   string ` + "`json:\"-\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		opts := parser.DefaultOptions()
+		opts.TagKeys = []string{"sql", "gorm", "db", "json"}
+
+		models, err := parser.ParseModelsWithOptions(testFile, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		gormUser := findModel(models, "GormUser")
+		if gormUser == nil {
+			t.Fatal("GormUser model not found")
+		}
+
+		expectedGormFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "user_id", IsPrimary: true},
+			{Name: "Name", Type: "string", Column: "full_name", Size: 255},
+		}
+
+		if !reflect.DeepEqual(gormUser.Fields, expectedGormFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedGormFields, gormUser.Fields)
+		}
+
+		sqlxUser := findModel(models, "SqlxUser")
+		if sqlxUser == nil {
+			t.Fatal("SqlxUser model not found")
+		}
+
+		expectedSqlxFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Email", Type: "string", Column: "email"},
+			{Name: "Password", Type: "string", Column: "password"},
+			{Name: "Nickname", Type: "string", Column: "nickname"},
+		}
+
+		if !reflect.DeepEqual(sqlxUser.Fields, expectedSqlxFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedSqlxFields, sqlxUser.Fields)
+		}
+	})
+
+	t.Run("table name resolution", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		modelsContent := `package models
+
+const (
+	schema      = "billing"
+	ordersTable = "orders"
+)
+
+type Order struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+func (o *Order) TableName() string {
+	return ordersTable
+}
+
+type Invoice struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+func (i Invoice) TableName() string {
+	return schema + "." + invoicesTable
+}
+
+type Customer struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+//gormless:table crm.customers
+type Lead struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+`
+
+		namesContent := `package models
+
+const invoicesTable = "invoices"
+
+func (c Customer) TableName() string {
+	return "customers"
+}
+`
+
+		if err := os.WriteFile(filepath.Join(tmpDir, "models.go"), []byte(modelsContent), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, "names.go"), []byte(namesContent), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(filepath.Join(tmpDir, "models.go"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			name      string
+			schema    string
+			tableName string
+		}{
+			{name: "Order", tableName: "orders"},
+			{name: "Invoice", schema: "billing", tableName: "invoices"},
+			{name: "Customer", tableName: "customers"},
+			{name: "Lead", schema: "crm", tableName: "customers"},
+		}
+
+		for _, tt := range tests {
+			model := findModel(models, tt.name)
+			if model == nil {
+				t.Fatalf("%s model not found", tt.name)
+			}
+			if model.Schema != tt.schema || model.TableName != tt.tableName {
+				t.Errorf("expected %s table %q.%q, got %q.%q", tt.name, tt.schema, tt.tableName, model.Schema, model.TableName)
+			}
+		}
+	})
+
+	t.Run("table name not statically determinable", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "dynamic.go")
+
+		testContent := `package models
+
+import "os"
+
+type Event struct {
+	ID int ` + "`sql:\"id,primary\"`" + `
+}
+
+func (e *Event) TableName() string {
+	return os.Getenv("EVENTS_TABLE")
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil {
+			t.Fatal("expected error for table name that cannot be determined statically")
+		}
+
+		if !strings.Contains(err.Error(), "Event.TableName") || !strings.Contains(err.Error(), "//gormless:table") {
+			t.Errorf("expected error to name the method and suggest the directive, got: %v", err)
+		}
+	})
+
+	t.Run("json tag", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "json.go")
+
+		testContent := `package models
+
+type Event struct {
+	ID       int                    ` + "`sql:\"id,primary\"`" + `
+	Metadata map[string]interface{} ` + "`sql:\"metadata,json\"`" + `
+	Tags     []string               ` + "`sql:\"tags,json\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Metadata", Type: "map[string]interface{}", Column: "metadata", IsJSON: true},
+			{Name: "Tags", Type: "[]string", Column: "tags", IsJSON: true},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+	})
+
+	t.Run("nullable tags and sql null types", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "contact.go")
+
+		testContent := `package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Mood string
+
+type Rank int
+
+type Contact struct {
+	ID       int            ` + "`sql:\"id,primary\"`" + `
+	Bio      string         ` + "`sql:\"bio,nullable\"`" + `
+	LastSeen time.Time      ` + "`sql:\"last_seen,nullzero\"`" + `
+	Phone    sql.NullString ` + "`sql:\"phone\"`" + `
+	Mood     Mood           ` + "`sql:\"mood,nullable\"`" + `
+	Rank     Rank           ` + "`sql:\"rank,nullzero\"`" + `
+	Level    Rank           ` + "`sql:\"level\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Bio", Type: "string", Column: "bio", IsNullable: true},
+			{Name: "LastSeen", Type: "time.Time", Column: "last_seen", IsNullable: true, IsNullZero: true},
+			{Name: "Phone", Type: "sql.NullString", Column: "phone", IsNullable: true},
+			{Name: "Mood", Type: "Mood", Column: "mood", IsNullable: true, Underlying: "string"},
+			{Name: "Rank", Type: "Rank", Column: "rank", IsNullable: true, IsNullZero: true, Underlying: "int"},
+			{Name: "Level", Type: "Rank", Column: "level"},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+	})
+
+	t.Run("type converters", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "account.go")
+
+		testContent := `package models
+
+import "github.com/google/uuid"
+
+type Account struct {
+	ID   uuid.UUID ` + "`sql:\"id,primary\"`" + `
+	Name string    ` + "`sql:\"name\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		converter := parser.Converter{
+			Import: "github.com/test/convert",
+			Encode: "convert.EncodeUUID",
+			Decode: "convert.DecodeUUID",
+		}
+
+		opts := parser.DefaultOptions()
+		opts.Converters = map[string]parser.Converter{"uuid.UUID": converter}
+
+		models, err := parser.ParseModelsWithOptions(testFile, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "uuid.UUID", Column: "id", IsPrimary: true, Converter: &converter},
+			{Name: "Name", Type: "string", Column: "name"},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+	})
+
+	t.Run("enum constants", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "ticket.go")
+
+		testContent := `package models
+
+type Ticket struct {
+	ID       int      ` + "`sql:\"id,primary\"`" + `
+	Status   Status   ` + "`sql:\"status\"`" + `
+	Priority Priority ` + "`sql:\"priority\"`" + `
+	Label    Label    ` + "`sql:\"label\"`" + `
+}
+`
+
+		enumContent := `package models
+
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed        = Status("clo" + "sed")
+	statusPrefix        = "st"
+)
+
+type Priority int
+
+const (
+	_ Priority = iota
+	PriorityLow
+	PriorityHigh
+)
+
+type Label string
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		err = os.WriteFile(filepath.Join(tmpDir, "enums.go"), []byte(enumContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create enums file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int", Column: "id", IsPrimary: true},
+			{Name: "Status", Type: "Status", Column: "status", Enum: &parser.Enum{Type: "Status", Values: []string{"open", "closed"}}},
+			{Name: "Priority", Type: "Priority", Column: "priority", Enum: &parser.Enum{Type: "Priority", Values: []string{"1", "2"}, Integer: true}},
+			{Name: "Label", Type: "Label", Column: "label"},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+	})
+
+	t.Run("generated columns", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "page.go")
+
+		testContent := `package models
+
+import "time"
+
+type Page struct {
+	ID        int64     ` + "`sql:\"id,primary\"`" + `
+	Slug      string    ` + "`sql:\"slug,generated\"`" + `
+	UpdatedAt time.Time ` + "`sql:\"updated_at,generated\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "Slug", Type: "string", Column: "slug", IsGenerated: true},
+			{Name: "UpdatedAt", Type: "time.Time", Column: "updated_at", IsGenerated: true},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+	})
+
+	t.Run("column type annotations", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "payment.go")
+
+		testContent := `package models
+
+import "time"
+
+type Payment struct {
+	ID     string    ` + "`sql:\"id,primary,type:uuid\"`" + `
+	Code   string    ` + "`sql:\"code,size:16,notnull\"`" + `
+	Amount float64   ` + "`sql:\"amount,precision:12,scale:2\"`" + `
+	Fee    float64   ` + "`sql:\"fee,type:numeric(8,2),notnull\"`" + `
+	PaidOn time.Time ` + "`gorm:\"column:paid_on;type:date;not null\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModelsWithOptions(testFile, parser.Options{TagKeys: []string{"sql", "gorm"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "string", Column: "id", IsPrimary: true, SQLType: "uuid"},
+			{Name: "Code", Type: "string", Column: "code", IsNotNull: true, Size: 16},
+			{Name: "Amount", Type: "float64", Column: "amount", Precision: 12, Scale: 2},
+			{Name: "Fee", Type: "float64", Column: "fee", IsNotNull: true, SQLType: "numeric(8,2)"},
+			{Name: "PaidOn", Type: "time.Time", Column: "paid_on", IsNotNull: true, SQLType: "date"},
+		}
+
+		if !reflect.DeepEqual(models[0].Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, models[0].Fields)
+		}
+	})
+
+	t.Run("invalid scale without precision", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "payment.go")
+
+		testContent := `package models
+
+type Payment struct {
+	ID     int     ` + "`sql:\"id,primary\"`" + `
+	Amount float64 ` + "`sql:\"amount,scale:2\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil || !strings.Contains(err.Error(), "scale without a precision") {
+			t.Fatalf("expected an error for a scale without a precision, got: %v", err)
+		}
+	})
+
+	t.Run("encrypted fields", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "patient.go")
+
+		testContent := `package models
+
+type Patient struct {
+	ID    int64  ` + "`sql:\"id,primary\"`" + `
+	SSN   string ` + "`sql:\"ssn,encrypted\"`" + `
+	Notes []byte ` + "`sql:\"notes,encrypted\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		patient := findModel(models, "Patient")
+		if patient == nil {
+			t.Fatal("Patient model not found")
+		}
+
+		expectedFields := []parser.Field{
+			{Name: "ID", Type: "int64", Column: "id", IsPrimary: true},
+			{Name: "SSN", Type: "string", Column: "ssn", IsEncrypted: true},
+			{Name: "Notes", Type: "[]byte", Column: "notes", IsEncrypted: true},
+		}
+
+		if !reflect.DeepEqual(patient.Fields, expectedFields) {
+			t.Errorf("Fields mismatch.\nExpected: %+v\nGot: %+v", expectedFields, patient.Fields)
+		}
+
+		invalidContent := `package models
+
+type Visit struct {
+	ID    int64 ` + "`sql:\"id,primary\"`" + `
+	Score int   ` + "`sql:\"score,encrypted\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(invalidContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil || !strings.Contains(err.Error(), "must be a string or []byte") {
+			t.Errorf("expected an error for an encrypted int field, got: %v", err)
+		}
+	})
+
+	t.Run("relations", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "order.go")
+
+		testContent := `package models
+
+type User struct {
+	ID int64 ` + "`sql:\"id,primary\"`" + `
+}
+
+type Order struct {
+	ID     int64        ` + "`sql:\"id,primary\"`" + `
+	UserID int64        ` + "`sql:\"user_id\"`" + `
+	User   *User        ` + "`rel:\"belongs_to,User,user_id\"`" + `
+	Items  []*OrderItem ` + "`rel:\"has_many,OrderItem,order_id\"`" + `
+}
+
+type OrderItem struct {
+	ID      int64 ` + "`sql:\"id,primary\"`" + `
+	OrderID int64 ` + "`sql:\"order_id\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		order := findModel(models, "Order")
+		if order == nil {
+			t.Fatal("Order model not found")
+		}
+
+		if len(order.Fields) != 2 {
+			t.Errorf("expected relation fields not to be mapped to columns, got %+v", order.Fields)
+		}
+
+		if len(order.Relations) != 2 {
+			t.Fatalf("expected 2 relations, got %d", len(order.Relations))
+		}
+
+		user := order.Relations[0]
+		if user.Name != "User" || user.Type != "*User" || user.Kind != parser.BelongsTo || user.Model != "User" || user.Column != "user_id" {
+			t.Errorf("unexpected belongs_to relation: %+v", user)
+		}
+		if user.Target == nil || user.Target.Name != "User" {
+			t.Errorf("expected the User relation to target the User model, got %+v", user.Target)
+		}
+
+		items := order.Relations[1]
+		if items.Name != "Items" || items.Type != "[]*OrderItem" || items.Kind != parser.HasMany || items.Model != "OrderItem" || items.Column != "order_id" {
+			t.Errorf("unexpected has_many relation: %+v", items)
+		}
+		if items.Target == nil || items.Target.Name != "OrderItem" {
+			t.Errorf("expected the Items relation to target the OrderItem model, got %+v", items.Target)
+		}
+	})
+
+	t.Run("relations with pointer foreign keys", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "order.go")
+
+		testContent := `package models
+
+type User struct {
+	ID int64 ` + "`sql:\"id,primary\"`" + `
+}
+
+type Order struct {
+	ID     int64        ` + "`sql:\"id,primary\"`" + `
+	UserID *int64       ` + "`sql:\"user_id\"`" + `
+	User   *User        ` + "`rel:\"belongs_to,User,user_id\"`" + `
+	Items  []*OrderItem ` + "`rel:\"has_many,OrderItem,order_id\"`" + `
+}
+
+type OrderItem struct {
+	ID      int64  ` + "`sql:\"id,primary\"`" + `
+	OrderID *int64 ` + "`sql:\"order_id\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		order := findModel(models, "Order")
+		if order == nil {
+			t.Fatal("Order model not found")
+		}
+		if len(order.Relations) != 2 {
+			t.Fatalf("expected 2 relations, got %d", len(order.Relations))
+		}
+	})
+
+	t.Run("relation with mismatched foreign key", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "order.go")
+
+		testContent := `package models
+
+type User struct {
+	ID int64 ` + "`sql:\"id,primary\"`" + `
+}
+
+type Order struct {
+	ID     int64   ` + "`sql:\"id,primary\"`" + `
+	UserID *string ` + "`sql:\"user_id\"`" + `
+	User   *User   ` + "`rel:\"belongs_to,User,user_id\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil {
+			t.Fatal("expected error for a foreign key not matching the primary key")
+		}
+
+		if !strings.Contains(err.Error(), "user_id") {
+			t.Errorf("expected error to name the column, got: %v", err)
+		}
+	})
+
+	t.Run("groupby fields", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "ticket.go")
+
+		testContent := `package models
+
+type Ticket struct {
+	ID     int64  ` + "`sql:\"id,primary\"`" + `
+	Status string ` + "`sql:\"status,groupby\"`" + `
+	Title  string ` + "`sql:\"title\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("ParseModels failed: %v", err)
+		}
+
+		fields := models[0].Fields
+		if fields[0].IsGroupBy || !fields[1].IsGroupBy || fields[2].IsGroupBy {
+			t.Errorf("expected only Status to be grouped by, got %+v", fields)
+		}
+	})
+
+	t.Run("groupby primary key", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "ticket.go")
+
+		testContent := `package models
+
+type Ticket struct {
+	ID int64 ` + "`sql:\"id,primary,groupby\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil {
+			t.Fatal("expected error for a groupby primary key")
+		}
+
+		if !strings.Contains(err.Error(), "groupby") {
+			t.Errorf("expected error to name the option, got: %v", err)
+		}
+	})
+
+	t.Run("relation to unknown model", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "order.go")
+
+		testContent := `package models
+
+type Order struct {
+	ID     int64 ` + "`sql:\"id,primary\"`" + `
+	UserID int64 ` + "`sql:\"user_id\"`" + `
+	User   *User ` + "`rel:\"belongs_to,User,user_id\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		_, err = parser.ParseModels(testFile)
+		if err == nil {
+			t.Fatal("expected error for a relation to an unknown model")
+		}
+
+		if !strings.Contains(err.Error(), "User") {
+			t.Errorf("expected error to name the model, got: %v", err)
+		}
+	})
+
+	t.Run("many to many relation", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "user.go")
+
+		testContent := `package models
+
+type User struct {
+	ID    int64   ` + "`sql:\"id,primary\"`" + `
+	Roles []*Role ` + "`rel:\"many_to_many,Role,user_roles,user_id,role_id\"`" + `
+}
+
+type Role struct {
+	ID int64 ` + "`sql:\"id,primary\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		user := findModel(models, "User")
+		if user == nil {
+			t.Fatal("User model not found")
+		}
+
+		if len(user.Relations) != 1 {
+			t.Fatalf("expected 1 relation, got %d", len(user.Relations))
+		}
+
+		roles := user.Relations[0]
+		if roles.Kind != parser.ManyToMany || roles.Model != "Role" || roles.JoinTable != "user_roles" || roles.Column != "user_id" || roles.TargetColumn != "role_id" {
+			t.Errorf("unexpected many_to_many relation: %+v", roles)
+		}
+		if roles.Target == nil || roles.Target.Name != "Role" {
+			t.Errorf("expected the Roles relation to target the Role model, got %+v", roles.Target)
+		}
+	})
+
+	t.Run("queue directive", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "job.go")
+
+		testContent := `package models
+
+import "time"
+
+type JobState string
+
+const (
+	JobQueued  JobState = "queued"
+	JobRunning JobState = "running"
+	JobDone    JobState = "done"
+)
+
+//gormless:queue
+type Job struct {
+	ID          int64      ` + "`sql:\"id,primary\"`" + `
+	Status      string     ` + "`sql:\"status\"`" + `
+	LeasedUntil *time.Time ` + "`sql:\"leased_until\"`" + `
+}
+
+//gormless:queue status=state lease=locked_until pending=queued claimed=running
+type Task struct {
+	ID          int64     ` + "`sql:\"id,primary\"`" + `
+	State       JobState  ` + "`sql:\"state\"`" + `
+	LockedUntil time.Time ` + "`sql:\"locked_until\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			name  string
+			queue parser.Queue
+		}{
+			{name: "Job", queue: parser.Queue{StatusColumn: "status", LeaseColumn: "leased_until", Pending: "pending", Claimed: "claimed", Done: "done"}},
+			{name: "Task", queue: parser.Queue{StatusColumn: "state", LeaseColumn: "locked_until", Pending: "queued", Claimed: "running", Done: "done"}},
+		}
+
+		for _, tt := range tests {
+			model := findModel(models, tt.name)
+			if model == nil {
+				t.Fatalf("%s model not found", tt.name)
+			}
+			if model.Queue == nil || *model.Queue != tt.queue {
+				t.Errorf("expected %s queue %+v, got %+v", tt.name, tt.queue, model.Queue)
+			}
+		}
+
+		invalid := []struct {
+			content string
+			err     string
+		}{
+			{content: `package models
+
+import "time"
+
+type JobState string
+
+const JobDone JobState = "done"
+
+//gormless:queue pending=waiting
+type Step struct {
+	ID          int64     ` + "`sql:\"id,primary\"`" + `
+	Status      JobState  ` + "`sql:\"status\"`" + `
+	LeasedUntil time.Time ` + "`sql:\"leased_until\"`" + `
+}
+`, err: "is not a value of JobState"},
+			{content: `package models
+
+//gormless:queue
+type Batch struct {
+	ID     int64  ` + "`sql:\"id,primary\"`" + `
+	Status string ` + "`sql:\"status\"`" + `
+}
+`, err: "has no leased_until column"},
+		}
+		for _, tt := range invalid {
+			err = os.WriteFile(testFile, []byte(tt.content), 0644)
+			if err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+			if _, err := parser.ParseModels(testFile); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected an error containing %q, got: %v", tt.err, err)
+			}
+		}
+	})
+
+	t.Run("queries file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// Create a temporary go.mod file for import path determination
+		goModContent := `module github.com/test/models
+go 1.21
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goModContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create go.mod file: %v", err)
+		}
+
+		testFile := filepath.Join(tmpDir, "user_account.go")
+
+		testContent := `package models
+
+type UserAccount struct {
+	ID   int    ` + "`sql:\"id,primary\"`" + `
+	Name string ` + "`sql:\"name\"`" + `
+	Age  int    ` + "`sql:\"age\"`" + `
+}
+`
+
+		err = os.WriteFile(testFile, []byte(testContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		queriesContent := `-- Queries of user accounts.
+
+-- name: ActiveAdults :many
+SELECT * FROM user_accounts
+WHERE age >= $1;
+
+-- name: CountByAge :many
+-- type: oldest *time.Time
+SELECT age, COUNT(*) AS total, MAX(created_at) AS oldest
+FROM user_accounts GROUP BY age;
+
+-- name: Rename :exec
+UPDATE user_accounts SET name = $1 WHERE id = $2;
+
+-- name: PurgeAll :exec
+-- unscoped
+DELETE FROM user_accounts;
+`
+
+		err = os.MkdirAll(filepath.Join(tmpDir, "queries"), 0755)
+		if err != nil {
+			t.Fatalf("failed to create queries dir: %v", err)
+		}
+		queriesFile := filepath.Join(tmpDir, "queries", "user_account.sql")
+		err = os.WriteFile(queriesFile, []byte(queriesContent), 0644)
+		if err != nil {
+			t.Fatalf("failed to create queries file: %v", err)
+		}
+
+		models, err := parser.ParseModels(testFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(models) != 1 {
+			t.Fatalf("expected 1 model, got %d", len(models))
+		}
+
+		expected := []parser.Query{
+			{Name: "ActiveAdults", Kind: parser.QueryMany, SQL: "SELECT * FROM user_accounts\nWHERE age >= $1", Types: map[string]string{}},
+			{Name: "CountByAge", Kind: parser.QueryMany, SQL: "SELECT age, COUNT(*) AS total, MAX(created_at) AS oldest\nFROM user_accounts GROUP BY age", Types: map[string]string{"oldest": "*time.Time"}},
+			{Name: "Rename", Kind: parser.QueryExec, SQL: "UPDATE user_accounts SET name = $1 WHERE id = $2", Types: map[string]string{}},
+			{Name: "PurgeAll", Kind: parser.QueryExec, SQL: "DELETE FROM user_accounts", Types: map[string]string{}, Unscoped: true},
+		}
+		if !reflect.DeepEqual(models[0].Queries, expected) {
+			t.Errorf("expected queries %+v, got %+v", expected, models[0].Queries)
+		}
+
+		invalid := []string{
+			"SELECT 1;\n",
+			"-- name: activeAdults :many\nSELECT 1;\n",
+			"-- name: ActiveAdults :all\nSELECT 1;\n",
+			"-- name: ActiveAdults :many\nSELECT 1;\n-- name: ActiveAdults :one\nSELECT 1;\n",
+			"-- name: ActiveAdults :many\n-- name: CountByAge :many\nSELECT 1;\n",
+			"-- name: CountByAge :many\n-- type: total decimal.Decimal\nSELECT 1;\n",
+		}
+		for _, content := range invalid {
+			err = os.WriteFile(queriesFile, []byte(content), 0644)
+			if err != nil {
+				t.Fatalf("failed to write queries file: %v", err)
+			}
+			if _, err := parser.ParseModels(testFile); err == nil {
+				t.Errorf("expected an error for the queries file %q", content)
+			}
+		}
+	})
 }
 
 // Helper function to find a model by name
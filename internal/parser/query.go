@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Jibaru/gormless/internal/naming"
+)
+
+// Query kinds, as written after the name of a query.
+const (
+	QueryOne      = "one"
+	QueryMany     = "many"
+	QueryExec     = "exec"
+	QueryExecRows = "execrows"
+)
+
+// queriesDir is the directory, next to the file of a model, holding the SQL
+// files of named queries, one per model named after it in snake_case.
+const queriesDir = "queries"
+
+var (
+	queryNamePattern = regexp.MustCompile(`^--\s*name:\s*(\S+)\s+:(\S+)\s*$`)
+	queryTypePattern = regexp.MustCompile(`^--\s*type:\s*(\S+)\s+(.+?)\s*$`)
+	unscopedPattern  = regexp.MustCompile(`^--\s*unscoped\s*$`)
+)
+
+// Query is a named SQL statement declared for a model in its queries file,
+// from which the DAO gets a method of the same name.
+type Query struct {
+	Name string
+	// Kind is how the statement is run: QueryOne, QueryMany, QueryExec or
+	// QueryExecRows.
+	Kind string
+	SQL  string
+	// Types maps the names of the selected expressions declared with a
+	// "-- type:" line to their Go types.
+	Types map[string]string
+	// Unscoped is set by a "-- unscoped" line, running a query of a tenant
+	// model without binding the tenant of the context.
+	Unscoped bool
+}
+
+// loadQueries parses the queries file of the modelName model declared in
+// filePath, returning no queries when there is none.
+func loadQueries(filePath, modelName string) ([]Query, error) {
+	path := filepath.Join(filepath.Dir(filePath), queriesDir, naming.ToSnakeCase(modelName)+".sql")
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	queries, err := parseQueries(bufio.NewScanner(file))
+	if err != nil {
+		return nil, fmt.Errorf("invalid queries file %s: %v", path, err)
+	}
+	return queries, nil
+}
+
+// parseQueries parses blocks of SQL each starting with a line like
+// "-- name: ActiveAdults :many", optionally followed by "-- type: total int64"
+// lines declaring the Go types of selected expressions and a "-- unscoped"
+// line.
+func parseQueries(scanner *bufio.Scanner) ([]Query, error) {
+	var queries []Query
+	var lines []string
+	names := map[string]bool{}
+	lineNumber := 0
+
+	flush := func() error {
+		if len(queries) == 0 {
+			return nil
+		}
+		query := &queries[len(queries)-1]
+		query.SQL = strings.TrimSuffix(strings.TrimSpace(strings.Join(lines, "\n")), ";")
+		if query.SQL == "" {
+			return fmt.Errorf("the %s query has no SQL", query.Name)
+		}
+		lines = nil
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+
+		if match := queryNamePattern.FindStringSubmatch(trimmed); match != nil {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			name, kind := match[1], match[2]
+			if !token.IsIdentifier(name) || !token.IsExported(name) {
+				return nil, fmt.Errorf("line %d: the query name %s must be an exported Go identifier", lineNumber, name)
+			}
+			if names[name] {
+				return nil, fmt.Errorf("line %d: there is more than one %s query", lineNumber, name)
+			}
+			switch kind {
+			case QueryOne, QueryMany, QueryExec, QueryExecRows:
+			default:
+				return nil, fmt.Errorf("line %d: unknown query kind :%s, expected :one, :many, :exec or :execrows", lineNumber, kind)
+			}
+			names[name] = true
+			queries = append(queries, Query{Name: name, Kind: kind, Types: map[string]string{}})
+			continue
+		}
+
+		if match := queryTypePattern.FindStringSubmatch(trimmed); match != nil && len(queries) > 0 {
+			if err := checkQueryType(match[2]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid type of %s: %v", lineNumber, match[1], err)
+			}
+			queries[len(queries)-1].Types[match[1]] = match[2]
+			continue
+		}
+
+		if unscopedPattern.MatchString(trimmed) && len(queries) > 0 {
+			queries[len(queries)-1].Unscoped = true
+			continue
+		}
+
+		if len(queries) == 0 {
+			if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: SQL before the first -- name: line", lineNumber)
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// checkQueryType reports an error unless goType is a Go type whose qualified
+// identifiers all belong to the time or database/sql packages, which are the
+// only ones the generated DAOs import for them.
+func checkQueryType(goType string) error {
+	expr, err := parser.ParseExpr(goType)
+	if err != nil {
+		return err
+	}
+
+	var typeErr error
+	ast.Inspect(expr, func(n ast.Node) bool {
+		selector, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := selector.X.(*ast.Ident); !ok || (pkg.Name != "time" && pkg.Name != "sql") {
+			typeErr = fmt.Errorf("only types of the time and database/sql packages can be qualified")
+		}
+		return false
+	})
+	return typeErr
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+)
+
+// queueDirective makes a model a work queue from its doc comment.
+const queueDirective = "//gormless:queue"
+
+// Queue is a model whose rows are jobs claimed by workers. StatusColumn holds
+// the state of a job, one of Pending, Claimed and Done, and LeaseColumn the
+// time the claim of a job expires.
+type Queue struct {
+	StatusColumn string
+	LeaseColumn  string
+	Pending      string
+	Claimed      string
+	Done         string
+}
+
+// parseQueue returns the queue declared by a //gormless:queue directive in
+// doc, like "//gormless:queue status=state lease=locked_until", or nil when
+// there is none. Its columns must be among the fields of model.
+func parseQueue(model Model, doc *ast.CommentGroup) (*Queue, error) {
+	if doc == nil {
+		return nil, nil
+	}
+
+	for _, comment := range doc.List {
+		if comment.Text != queueDirective && !strings.HasPrefix(comment.Text, queueDirective+" ") {
+			continue
+		}
+
+		queue := &Queue{
+			StatusColumn: "status",
+			LeaseColumn:  "leased_until",
+			Pending:      "pending",
+			Claimed:      "claimed",
+			Done:         "done",
+		}
+
+		for _, option := range strings.Fields(strings.TrimPrefix(comment.Text, queueDirective)) {
+			key, value, ok := strings.Cut(option, "=")
+			if !ok || value == "" {
+				return nil, fmt.Errorf("the queue option %q of the %s model must be key=value", option, model.Name)
+			}
+			switch key {
+			case "status":
+				queue.StatusColumn = value
+			case "lease":
+				queue.LeaseColumn = value
+			case "pending":
+				queue.Pending = value
+			case "claimed":
+				queue.Claimed = value
+			case "done":
+				queue.Done = value
+			default:
+				return nil, fmt.Errorf("unknown queue option %q in the %s model", key, model.Name)
+			}
+		}
+
+		if queue.Pending == queue.Claimed || queue.Pending == queue.Done || queue.Claimed == queue.Done {
+			return nil, fmt.Errorf("the queue states of the %s model must be distinct", model.Name)
+		}
+
+		status, ok := findFieldByColumn(model, queue.StatusColumn)
+		if !ok {
+			return nil, fmt.Errorf("the %s model has no %s column for the queue status", model.Name, queue.StatusColumn)
+		}
+		if status.Enum != nil && !status.Enum.Integer {
+			for _, state := range []string{queue.Pending, queue.Claimed, queue.Done} {
+				if !slices.Contains(status.Enum.Values, state) {
+					return nil, fmt.Errorf("the queue state %q is not a value of %s in the %s model", state, status.Enum.Type, model.Name)
+				}
+			}
+		} else if status.Type != "string" {
+			return nil, fmt.Errorf("the queue status field %s in the %s model must be a string", status.Name, model.Name)
+		}
+
+		lease, ok := findFieldByColumn(model, queue.LeaseColumn)
+		if !ok {
+			return nil, fmt.Errorf("the %s model has no %s column for the queue lease", model.Name, queue.LeaseColumn)
+		}
+		if lease.Type != "time.Time" && lease.Type != "*time.Time" {
+			return nil, fmt.Errorf("the queue lease field %s in the %s model must be a time.Time or *time.Time", lease.Name, model.Name)
+		}
+
+		for _, field := range []Field{status, lease} {
+			if field.IsPrimary || field.IsSoftDelete || field.IsVersion || field.IsTenant || field.IsJSON || field.IsGenerated || field.IsEncrypted || field.Converter != nil {
+				return nil, fmt.Errorf("the queue field %s in the %s model cannot be primary, softdelete, version, tenant, json, generated, encrypted or converted", field.Name, model.Name)
+			}
+		}
+
+		return queue, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Relation kinds, as written in the rel tag.
+const (
+	BelongsTo  = "belongs_to"
+	HasMany    = "has_many"
+	ManyToMany = "many_to_many"
+)
+
+// Relation is a field holding the models related to its model through a
+// foreign key. It is loaded by the DAO instead of being mapped to a column.
+type Relation struct {
+	Name  string
+	Type  string
+	Kind  string
+	Model string
+	// Column is the foreign key column, in the table of the model for
+	// belongs_to relations, in the table of Target for has_many ones and in
+	// JoinTable, holding the primary key of the model, for many_to_many ones.
+	Column string
+	// JoinTable links the model to Target in many_to_many relations, with
+	// TargetColumn holding the primary key of Target.
+	JoinTable    string
+	TargetColumn string
+	// Target is the related model, resolved once every model is parsed.
+	Target *Model
+}
+
+// parseRelation parses a rel tag like "belongs_to,User,user_id" or
+// "many_to_many,Role,user_roles,user_id,role_id" on the field fieldName of
+// type fieldType.
+func parseRelation(fieldName, fieldType, tag string) (Relation, error) {
+	parts := strings.Split(tag, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	relation := Relation{Name: fieldName, Type: fieldType, Kind: parts[0]}
+
+	element := fieldType
+	switch relation.Kind {
+	case BelongsTo, HasMany:
+		if len(parts) != 3 {
+			return Relation{}, fmt.Errorf("the rel tag of the %s field must be %s,Model,column", fieldName, relation.Kind)
+		}
+		relation.Model, relation.Column = parts[1], parts[2]
+	case ManyToMany:
+		if len(parts) != 5 {
+			return Relation{}, fmt.Errorf("the rel tag of the %s field must be many_to_many,Model,join_table,column,target_column", fieldName)
+		}
+		relation.Model, relation.JoinTable, relation.Column, relation.TargetColumn = parts[1], parts[2], parts[3], parts[4]
+		if relation.JoinTable == "" || relation.TargetColumn == "" {
+			return Relation{}, fmt.Errorf("the rel tag of the %s field has no join table or target column", fieldName)
+		}
+	default:
+		return Relation{}, fmt.Errorf("unknown relation %q on the %s field", relation.Kind, fieldName)
+	}
+
+	if relation.Kind != BelongsTo {
+		if !strings.HasPrefix(element, "[]") {
+			return Relation{}, fmt.Errorf("the %s field %s must be a slice", relation.Kind, fieldName)
+		}
+		element = strings.TrimPrefix(element, "[]")
+	}
+
+	if strings.TrimPrefix(element, "*") != relation.Model {
+		return Relation{}, fmt.Errorf("the %s field must hold %s models", fieldName, relation.Model)
+	}
+	if relation.Column == "" {
+		return Relation{}, fmt.Errorf("the rel tag of the %s field has no column", fieldName)
+	}
+
+	return relation, nil
+}
+
+// resolveRelations sets the Target of the relations of models to the model
+// of the same package they name, and checks their foreign key matches the
+// primary key it refers to.
+func resolveRelations(models []Model) error {
+	targets := make([]Model, len(models))
+	for i, model := range models {
+		targets[i] = model
+		targets[i].Relations = append([]Relation(nil), model.Relations...)
+	}
+
+	for i := range models {
+		model := &models[i]
+		for j := range model.Relations {
+			relation := &model.Relations[j]
+
+			for k := range targets {
+				if targets[k].Name == relation.Model && targets[k].ImportPath == model.ImportPath {
+					relation.Target = &targets[k]
+					break
+				}
+			}
+			if relation.Target == nil {
+				return fmt.Errorf("the %s relation of the %s model refers to the unknown model %s", relation.Name, model.Name, relation.Model)
+			}
+
+			// The keys of a join table are not modelled.
+			if relation.Kind == ManyToMany {
+				continue
+			}
+
+			owner, referenced := *model, *relation.Target
+			if relation.Kind == HasMany {
+				owner, referenced = referenced, owner
+			}
+
+			key, ok := findFieldByColumn(owner, relation.Column)
+			if !ok {
+				return fmt.Errorf("the %s relation of the %s model refers to the missing column %s of the %s model", relation.Name, model.Name, relation.Column, owner.Name)
+			}
+			if key.IsEncrypted {
+				return fmt.Errorf("the %s relation of the %s model cannot use the encrypted column %s", relation.Name, model.Name, relation.Column)
+			}
+			// A pointer foreign key makes the relation optional.
+			primary := findPrimaryField(referenced)
+			if strings.TrimPrefix(key.Type, "*") != primary.Type {
+				return fmt.Errorf("the %s relation of the %s model joins %s %s with the %s primary key of the %s model", relation.Name, model.Name, relation.Column, key.Type, primary.Type, referenced.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func findFieldByColumn(model Model, column string) (Field, bool) {
+	for _, field := range model.Fields {
+		if field.Column == column {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+func findPrimaryField(model Model) Field {
+	for _, field := range model.Fields {
+		if field.IsPrimary {
+			return field
+		}
+	}
+	return Field{}
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tableDirective sets the table of a model from its doc comment.
+const tableDirective = "//gormless:table"
+
+// tableNameError reports a table name that cannot be determined statically.
+type tableNameError struct {
+	model  string
+	method string
+	reason string
+}
+
+func (e *tableNameError) Error() string {
+	return fmt.Sprintf("cannot determine the result of %s.%s statically: %s; return a string literal or constant, or annotate the struct with %s <name>", e.model, e.method, e.reason, tableDirective)
+}
+
+// parsePackageFiles parses the non-test files of the package of file, which is
+// read from filePath, so declarations spread over the package can be resolved.
+func parsePackageFiles(fset *token.FileSet, filePath string, file *ast.File) ([]*ast.File, error) {
+	files := []*ast.File{file}
+
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(filepath.Dir(filePath), name)
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == filepath.Base(filePath) {
+			continue
+		}
+
+		sibling, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if sibling.Name.Name == file.Name.Name {
+			files = append(files, sibling)
+		}
+	}
+
+	return files, nil
+}
+
+// resolveTableName returns the table of the structName model declared by a
+// //gormless:table directive in doc or by its TableName method, or an empty
+// string when there is neither.
+func resolveTableName(structName string, doc *ast.CommentGroup, files []*ast.File) (string, error) {
+	if doc != nil {
+		for _, comment := range doc.List {
+			if name, ok := strings.CutPrefix(comment.Text, tableDirective+" "); ok {
+				return strings.TrimSpace(name), nil
+			}
+		}
+	}
+
+	return resolveMethodString(structName, "TableName", files)
+}
+
+// resolveMethodString statically evaluates the string returned by the
+// methodName method of structName, declared with a value or pointer receiver
+// in any of files. It returns an empty string when there is no such method.
+func resolveMethodString(structName, methodName string, files []*ast.File) (string, error) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.Name.Name != methodName {
+				continue
+			}
+			if getReceiverType(fn.Recv.List[0].Type) != structName {
+				continue
+			}
+
+			if fn.Body == nil || len(fn.Body.List) != 1 {
+				return "", &tableNameError{model: structName, method: methodName, reason: "the method must consist of a single return statement"}
+			}
+
+			ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				return "", &tableNameError{model: structName, method: methodName, reason: "the method must consist of a single return statement"}
+			}
+
+			value, err := evalStringExpr(ret.Results[0], files, map[string]bool{})
+			if err != nil {
+				return "", &tableNameError{model: structName, method: methodName, reason: err.Error()}
+			}
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+// evalStringExpr evaluates a constant string expression made of literals,
+// package-level constants and concatenations.
+func evalStringExpr(expr ast.Expr, files []*ast.File, visiting map[string]bool) (string, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", fmt.Errorf("%s is not a string", e.Value)
+		}
+		return strconv.Unquote(e.Value)
+	case *ast.ParenExpr:
+		return evalStringExpr(e.X, files, visiting)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", fmt.Errorf("unsupported operator %s", e.Op)
+		}
+		left, err := evalStringExpr(e.X, files, visiting)
+		if err != nil {
+			return "", err
+		}
+		right, err := evalStringExpr(e.Y, files, visiting)
+		if err != nil {
+			return "", err
+		}
+		return left + right, nil
+	case *ast.Ident:
+		if visiting[e.Name] {
+			return "", fmt.Errorf("constant %s refers to itself", e.Name)
+		}
+		value, ok := findConst(e.Name, files)
+		if !ok {
+			return "", fmt.Errorf("%s is not a package-level string constant", e.Name)
+		}
+		visiting[e.Name] = true
+		defer delete(visiting, e.Name)
+		return evalStringExpr(value, files, visiting)
+	}
+	return "", fmt.Errorf("unsupported expression of type %T", expr)
+}
+
+// findConst returns the value expression of the package-level constant name.
+func findConst(name string, files []*ast.File) (ast.Expr, bool) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, ident := range vs.Names {
+					if ident.Name == name && i < len(vs.Values) {
+						return vs.Values[i], true
+					}
+				}
+			}
+		}
+	}
+	return nil, false
+}